@@ -33,8 +33,6 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 package main
 
 import (
-	log "github.com/sirupsen/logrus"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
@@ -44,9 +42,8 @@ import (
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
 func main() {
-	log.SetLevel(log.DebugLevel)
-	log.Debug("Debug logging enabled")
-
+	// provider logs are written with tflog, so that their level is controlled by
+	// TF_LOG and TF_LOG_PROVIDER environment variables
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return provider.Provider()
@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package acctest contains helpers for resource tests, which run CRUD handlers of resources against
+// the fake DECORT controller, see controller/fake. resource.UnitTest tests run them with Terraform
+// CLI and are skipped without it, handler tests call them directly and always run.
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/provider"
+)
+
+// ProviderFactories serve the provider to Terraform CLI run by resource.UnitTest
+var ProviderFactories = map[string]func() (*schema.Provider, error){
+	"decort": func() (*schema.Provider, error) {
+		return provider.Provider(), nil
+	},
+}
+
+// PreCheck skips the test, if Terraform CLI cannot be found. The testing framework takes it from
+// TF_ACC_TERRAFORM_PATH, installs TF_ACC_TERRAFORM_VERSION or looks it up in PATH, and the last
+// one is checked here, so that tests are skipped rather than fail in environments without Terraform.
+func PreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Terraform CLI is not found in PATH, set TF_ACC_TERRAFORM_PATH to run resource tests")
+	}
+}
+
+// NewServer starts fake DECORT controller, which is closed when the test completes
+func NewServer(t *testing.T) *fake.Server {
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Config prepends provider block pointing to the fake controller to the resource configuration
+func Config(s *fake.Server, format string, args ...interface{}) string {
+	return s.ProviderConfig() + fmt.Sprintf(format, args...)
+}
+
+// NewRG creates resource group in the default account and grid of the fake controller, which is
+// the parent of resources under test, and returns its ID
+func NewRG(t *testing.T, s *fake.Server) int {
+	t.Helper()
+	urlValues := &url.Values{}
+	urlValues.Add("accountId", strconv.Itoa(fake.DefaultAccountID))
+	urlValues.Add("gid", strconv.Itoa(fake.DefaultGridID))
+	urlValues.Add("name", "rg-test")
+	res, err := s.Client().DecortAPICall(context.Background(), "POST", "/restmachine/cloudapi/rg/create", urlValues)
+	if err != nil {
+		t.Fatalf("rg/create: %v", err)
+	}
+	id, err := strconv.Atoi(res)
+	if err != nil {
+		t.Fatalf("rg/create returned %q: %v", res, err)
+	}
+	return id
+}
+
+// ResourceData returns data of resource r planned to change from prior to raw configuration, as it
+// is passed to the resource handlers on apply. Nil prior plans creation of the resource. Handler
+// tests call CreateContext, ReadContext, UpdateContext and DeleteContext of the resource with it,
+// so that they run against the fake controller without Terraform CLI.
+func ResourceData(t *testing.T, r *schema.Resource, prior *schema.ResourceData, raw map[string]interface{}, m interface{}) *schema.ResourceData {
+	t.Helper()
+	var state *terraform.InstanceState
+	if prior != nil {
+		state = prior.State()
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), m)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// Imported returns data of resource r with the given ID and nothing else set, as it is passed to
+// Read handler on import
+func Imported(r *schema.Resource, id string) *schema.ResourceData {
+	d := r.Data(nil)
+	d.SetId(id)
+	return d
+}
+
+// objectID returns ID of DECORT object from ID of the resource. Child resources use
+// "<parent>#<key>" IDs, so that the parent ID is returned for them.
+func objectID(rs *terraform.ResourceState) (int, error) {
+	id := rs.Primary.ID
+	if i := strings.Index(id, "#"); i >= 0 {
+		id = id[:i]
+	}
+	return strconv.Atoi(id)
+}
+
+// CheckObject verifies field of the object in the fake controller, which is managed by the resource
+func CheckObject(s *fake.Server, kind, name, field string, want interface{}) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s is not found in state", name)
+		}
+		id, err := objectID(rs)
+		if err != nil {
+			return fmt.Errorf("resource %s: %w", name, err)
+		}
+		obj, ok := s.Get(kind, id)
+		if !ok {
+			return fmt.Errorf("%s %d of resource %s is not found in fake controller", kind, id, name)
+		}
+		if got := fmt.Sprint(obj[field]); got != fmt.Sprint(want) {
+			return fmt.Errorf("%s %d: %s = %s, want %v", kind, id, field, got, want)
+		}
+		return nil
+	}
+}
+
+// CheckDestroyed verifies that objects of all resources of the type are deleted from the fake controller
+func CheckDestroyed(s *fake.Server, kind, resourceType string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for name, rs := range state.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			id, err := objectID(rs)
+			if err != nil {
+				return fmt.Errorf("resource %s: %w", name, err)
+			}
+			obj, ok := s.Get(kind, id)
+			if !ok {
+				continue
+			}
+			if status := fmt.Sprint(obj["status"]); status != "DELETED" && status != "DESTROYED" {
+				return fmt.Errorf("%s %d of resource %s still exists with status %s", kind, id, name, status)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudinit renders typed cloud-init settings of compute resources into
+// #cloud-config userdata and normalizes userdata documents for comparison
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Header starts every #cloud-config userdata document
+const Header = "#cloud-config"
+
+// User is a guest OS user to create, rendered as an item of "users" list
+type User struct {
+	Name              string
+	Groups            []string
+	Shell             string
+	Sudo              string
+	LockPasswd        bool
+	SSHAuthorizedKeys []string
+}
+
+// WriteFile is a file to write on first boot, rendered as an item of "write_files" list
+type WriteFile struct {
+	Path        string
+	Content     string
+	Owner       string
+	Permissions string
+	Encoding    string
+	Append      bool
+}
+
+// Config holds typed cloud-init settings. Its lists are appended to the lists of the
+// same keys of the raw document, if any.
+type Config struct {
+	Users             []User
+	SSHAuthorizedKeys []string
+	WriteFiles        []WriteFile
+	RunCmd            []string
+	Packages          []string
+}
+
+// IsEmpty reports whether config has no settings to render
+func (c Config) IsEmpty() bool {
+	return len(c.Users) == 0 && len(c.SSHAuthorizedKeys) == 0 && len(c.WriteFiles) == 0 &&
+		len(c.RunCmd) == 0 && len(c.Packages) == 0
+}
+
+// Render merges typed config into raw userdata and returns resulting #cloud-config document.
+// Raw userdata is returned as is when config is empty, so that shell scripts and other
+// formats supported by cloud-init still can be passed. Otherwise raw userdata must be
+// either empty or a #cloud-config (or JSON) mapping.
+func Render(raw string, config Config) (string, error) {
+	if config.IsEmpty() {
+		return raw, nil
+	}
+
+	doc := map[string]interface{}{}
+	if strings.TrimSpace(raw) != "" {
+		if strings.HasPrefix(strings.TrimSpace(raw), "#!") {
+			return "", fmt.Errorf("cloud_init script can't be merged with cloud_config, use #cloud-config document instead")
+		}
+		if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+			return "", fmt.Errorf("cloud_init is not a valid #cloud-config document: %w", err)
+		}
+		if doc == nil {
+			doc = map[string]interface{}{}
+		}
+	}
+
+	for _, u := range config.Users {
+		user := map[string]interface{}{"name": u.Name, "lock_passwd": u.LockPasswd}
+		if len(u.Groups) > 0 {
+			user["groups"] = strings.Join(u.Groups, ", ")
+		}
+		if u.Shell != "" {
+			user["shell"] = u.Shell
+		}
+		if u.Sudo != "" {
+			user["sudo"] = u.Sudo
+		}
+		if len(u.SSHAuthorizedKeys) > 0 {
+			user["ssh_authorized_keys"] = u.SSHAuthorizedKeys
+		}
+		if err := appendItem(doc, "users", user); err != nil {
+			return "", err
+		}
+	}
+
+	for _, key := range config.SSHAuthorizedKeys {
+		if err := appendItem(doc, "ssh_authorized_keys", key); err != nil {
+			return "", err
+		}
+	}
+
+	for _, f := range config.WriteFiles {
+		file := map[string]interface{}{"path": f.Path, "content": f.Content}
+		if f.Owner != "" {
+			file["owner"] = f.Owner
+		}
+		if f.Permissions != "" {
+			file["permissions"] = f.Permissions
+		}
+		if f.Encoding != "" {
+			file["encoding"] = f.Encoding
+		}
+		if f.Append {
+			file["append"] = true
+		}
+		if err := appendItem(doc, "write_files", file); err != nil {
+			return "", err
+		}
+	}
+
+	for _, cmd := range config.RunCmd {
+		if err := appendItem(doc, "runcmd", cmd); err != nil {
+			return "", err
+		}
+	}
+
+	for _, pkg := range config.Packages {
+		if err := appendItem(doc, "packages", pkg); err != nil {
+			return "", err
+		}
+	}
+
+	return marshal(doc)
+}
+
+func appendItem(doc map[string]interface{}, key string, item interface{}) error {
+	existing, ok := doc[key]
+	if !ok || existing == nil {
+		doc[key] = []interface{}{item}
+		return nil
+	}
+	list, ok := existing.([]interface{})
+	if !ok {
+		return fmt.Errorf("cloud_init key %q must be a list to be merged with cloud_config", key)
+	}
+	doc[key] = append(list, item)
+	return nil
+}
+
+func marshal(doc map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteString(Header + "\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Normalize returns canonical form of userdata: YAML and JSON mappings are re-encoded with
+// sorted keys and uniform formatting, other documents only lose trailing whitespace.
+// Documents with equal normalized forms are equivalent for cloud-init.
+func Normalize(userdata string) string {
+	doc := map[string]interface{}{}
+	if !strings.HasPrefix(strings.TrimSpace(userdata), "#!") && strings.TrimSpace(userdata) != "" {
+		if err := yaml.Unmarshal([]byte(userdata), &doc); err == nil && len(doc) > 0 {
+			if res, err := marshal(doc); err == nil {
+				return res
+			}
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(userdata), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
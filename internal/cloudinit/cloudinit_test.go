@@ -0,0 +1,204 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		config  Config
+		want    string
+		wantErr string
+	}{
+		{
+			name: "empty config keeps raw userdata",
+			raw:  "#!/bin/sh\necho hello\n",
+			want: "#!/bin/sh\necho hello\n",
+		},
+		{
+			name: "empty raw userdata",
+			config: Config{
+				Users: []User{{
+					Name:              "admin",
+					Groups:            []string{"wheel", "docker"},
+					Shell:             "/bin/bash",
+					Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+					LockPasswd:        true,
+					SSHAuthorizedKeys: []string{"ssh-ed25519 AAAA admin"},
+				}},
+				Packages: []string{"nginx"},
+			},
+			want: "#cloud-config\n" +
+				"packages:\n" +
+				"  - nginx\n" +
+				"users:\n" +
+				"  - groups: wheel, docker\n" +
+				"    lock_passwd: true\n" +
+				"    name: admin\n" +
+				"    shell: /bin/bash\n" +
+				"    ssh_authorized_keys:\n" +
+				"      - ssh-ed25519 AAAA admin\n" +
+				"    sudo: ALL=(ALL) NOPASSWD:ALL\n",
+		},
+		{
+			name: "lists are appended to raw document",
+			raw: "#cloud-config\n" +
+				"hostname: vm\n" +
+				"runcmd:\n" +
+				"  - echo first\n",
+			config: Config{
+				SSHAuthorizedKeys: []string{"ssh-rsa AAAA user"},
+				RunCmd:            []string{"echo second"},
+			},
+			want: "#cloud-config\n" +
+				"hostname: vm\n" +
+				"runcmd:\n" +
+				"  - echo first\n" +
+				"  - echo second\n" +
+				"ssh_authorized_keys:\n" +
+				"  - ssh-rsa AAAA user\n",
+		},
+		{
+			name: "write files",
+			config: Config{
+				WriteFiles: []WriteFile{
+					{Path: "/etc/motd", Content: "hello\n"},
+					{Path: "/etc/app.conf", Content: "a2V5", Owner: "root:root", Permissions: "0600", Encoding: "b64", Append: true},
+				},
+			},
+			want: "#cloud-config\n" +
+				"write_files:\n" +
+				"  - content: |\n" +
+				"      hello\n" +
+				"    path: /etc/motd\n" +
+				"  - append: true\n" +
+				"    content: a2V5\n" +
+				"    encoding: b64\n" +
+				"    owner: root:root\n" +
+				"    path: /etc/app.conf\n" +
+				"    permissions: \"0600\"\n",
+		},
+		{
+			name:    "script can't be merged",
+			raw:     "#!/bin/sh\necho hello\n",
+			config:  Config{RunCmd: []string{"echo second"}},
+			wantErr: "cloud_init script can't be merged",
+		},
+		{
+			name:    "invalid document",
+			raw:     "#cloud-config\nusers: [\n",
+			config:  Config{RunCmd: []string{"echo second"}},
+			wantErr: "cloud_init is not a valid #cloud-config document",
+		},
+		{
+			name:    "key is not a list",
+			raw:     "#cloud-config\nruncmd: echo first\n",
+			config:  Config{RunCmd: []string{"echo second"}},
+			wantErr: `cloud_init key "runcmd" must be a list`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.raw, tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Render() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{
+			name: "key order and indentation",
+			a:    "#cloud-config\nhostname: vm\npackages:\n- nginx\n",
+			b:    "#cloud-config\npackages:\n    - nginx\nhostname: vm\n",
+			same: true,
+		},
+		{
+			name: "json and yaml",
+			a:    `{"hostname": "vm", "packages": ["nginx"]}`,
+			b:    "#cloud-config\nhostname: vm\npackages:\n  - nginx\n",
+			same: true,
+		},
+		{
+			name: "rendered document",
+			a:    "#cloud-config\nruncmd:\n  - echo first\n",
+			b:    mustRender(t, "", Config{RunCmd: []string{"echo first"}}),
+			same: true,
+		},
+		{
+			name: "different values",
+			a:    "#cloud-config\nhostname: vm1\n",
+			b:    "#cloud-config\nhostname: vm2\n",
+			same: false,
+		},
+		{
+			name: "script trailing whitespace",
+			a:    "#!/bin/sh\r\necho hello  \r\n\n",
+			b:    "#!/bin/sh\necho hello",
+			same: true,
+		},
+		{
+			name: "script is not parsed",
+			a:    "#!/bin/sh\necho a: b\n",
+			b:    "#!/bin/sh\necho  a: b\n",
+			same: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Normalize(tt.a), Normalize(tt.b)
+			if (a == b) != tt.same {
+				t.Errorf("Normalize() =\n%s\nand\n%s\nsame = %t, want %t", a, b, a == b, tt.same)
+			}
+		})
+	}
+}
+
+func mustRender(t *testing.T, raw string, config Config) string {
+	t.Helper()
+	res, err := Render(raw, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
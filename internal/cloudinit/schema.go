@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// SchemaMake returns schema of cloud_config block of compute resources, which describes
+// cloud-init settings rendered into #cloud-config userdata together with raw cloud_init document
+func SchemaMake() map[string]*schema.Schema {
+	rets := map[string]*schema.Schema{
+		"users": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Name of the guest OS user.",
+					},
+					"groups": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Additional groups of the user.",
+					},
+					"shell": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Login shell of the user, e.g. /bin/bash.",
+					},
+					"sudo": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Sudo rule for the user, e.g. ALL=(ALL) NOPASSWD:ALL.",
+					},
+					"lock_passwd": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "Disable password login for the user.",
+					},
+					"ssh_authorized_keys": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "SSH public keys to authorize for the user.",
+					},
+				},
+			},
+			Description: "Guest OS users to create.",
+		},
+
+		"ssh_authorized_keys": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "SSH public keys to authorize for the default user of the image.",
+		},
+
+		"write_files": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"path": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Absolute path of the file.",
+					},
+					"content": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Content of the file.",
+					},
+					"owner": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Owner of the file in user:group form.",
+					},
+					"permissions": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Permissions of the file in octal form, e.g. 0644.",
+					},
+					"encoding": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"b64", "gzip", "gz+b64"}, false),
+						Description:  "Encoding of the content, one of b64, gzip or gz+b64. Plain text if not set.",
+					},
+					"append": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Append content to the existing file instead of overwriting it.",
+					},
+				},
+			},
+			Description: "Files to write on first boot.",
+		},
+
+		"runcmd": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Commands to run on first boot.",
+		},
+
+		"packages": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Packages to install on first boot.",
+		},
+	}
+	return rets
+}
+
+func expandStringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	res := make([]string, 0, len(list))
+	for _, item := range list {
+		s, _ := item.(string)
+		res = append(res, s)
+	}
+	return res
+}
+
+// Expand converts cloud_config block into Config
+func Expand(v interface{}) Config {
+	config := Config{}
+	blocks, _ := v.([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return config
+	}
+	block := blocks[0].(map[string]interface{})
+
+	for _, item := range block["users"].([]interface{}) {
+		user := item.(map[string]interface{})
+		config.Users = append(config.Users, User{
+			Name:              user["name"].(string),
+			Groups:            expandStringList(user["groups"]),
+			Shell:             user["shell"].(string),
+			Sudo:              user["sudo"].(string),
+			LockPasswd:        user["lock_passwd"].(bool),
+			SSHAuthorizedKeys: expandStringList(user["ssh_authorized_keys"]),
+		})
+	}
+
+	for _, item := range block["write_files"].([]interface{}) {
+		file := item.(map[string]interface{})
+		config.WriteFiles = append(config.WriteFiles, WriteFile{
+			Path:        file["path"].(string),
+			Content:     file["content"].(string),
+			Owner:       file["owner"].(string),
+			Permissions: file["permissions"].(string),
+			Encoding:    file["encoding"].(string),
+			Append:      file["append"].(bool),
+		})
+	}
+
+	config.SSHAuthorizedKeys = expandStringList(block["ssh_authorized_keys"])
+	config.RunCmd = expandStringList(block["runcmd"])
+	config.Packages = expandStringList(block["packages"])
+
+	return config
+}
+
+// Userdata renders cloud_init and cloud_config of compute resource into userdata passed to
+// compute create API. Empty string means no userdata.
+func Userdata(raw string, cloudConfig interface{}) (string, error) {
+	// "applied" is a reserved keyword, which marks cloud_init of existing computes in
+	// cloudbroker resource and is kept for compatibility with older configurations
+	if raw == "applied" {
+		raw = ""
+	}
+	return Render(raw, Expand(cloudConfig))
+}
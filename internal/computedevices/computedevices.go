@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package computedevices manages vGPUs and PCI devices of compute resources. It is shared by
+// compute resources of cloudapi and cloudbroker, which pass the compute API client they are
+// bound to.
+package computedevices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vgpu"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+)
+
+var log = logging.New("computedevices")
+
+// VGPUSchemaMake returns schema of vgpu block, vgpuIdDescription tells which vGPUs may be attached
+func VGPUSchemaMake(vgpuIdDescription string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vgpu_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: vgpuIdDescription,
+		},
+	}
+}
+
+// PCIDeviceSchemaMake returns schema of pci_device block
+func PCIDeviceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"device_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "ID of PCI device to pass through to this compute.",
+		},
+	}
+}
+
+// FlattenVGPUs returns vgpu blocks of the vGPUs attached to compute
+func FlattenVGPUs(vgpuIds []uint64) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(vgpuIds))
+	for _, vgpuId := range vgpuIds {
+		res = append(res, map[string]interface{}{"vgpu_id": vgpuId})
+	}
+	return res
+}
+
+// FlattenPCIDevices returns pci_device blocks of the PCI devices attached to compute
+func FlattenPCIDevices(devices compute.ListPCIDevices) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(devices))
+	for _, device := range devices {
+		res = append(res, map[string]interface{}{"device_id": device.ID})
+	}
+	return res
+}
+
+// IDs returns IDs held by the key of vgpu or pci_device blocks
+func IDs(set interface{}, key string) []uint64 {
+	res := make([]uint64, 0)
+	for _, item := range set.(*schema.Set).List() {
+		res = append(res, uint64(item.(map[string]interface{})[key].(int)))
+	}
+	return res
+}
+
+// idsDiff returns IDs of devices to be detached and attached
+func idsDiff(d *schema.ResourceData, blockKey, idKey string) ([]uint64, []uint64) {
+	oldSet, newSet := d.GetChange(blockKey)
+	return IDs(oldSet.(*schema.Set).Difference(newSet.(*schema.Set)), idKey),
+		IDs(newSet.(*schema.Set).Difference(oldSet.(*schema.Set)), idKey)
+}
+
+// PCIDevicesRead sets pci_device blocks from devices actually attached to compute. PCI devices
+// are listed by a separate call, which may be denied to users not managing devices, so that they
+// are only read back when pci_device blocks are held in state.
+func PCIDevicesRead(ctx context.Context, api *compute.Compute, d *schema.ResourceData, computeID uint64) error {
+	if d.Get("pci_device").(*schema.Set).Len() == 0 {
+		return nil
+	}
+	devices, err := api.PCIDeviceList(ctx, compute.IDRequest{ComputeID: computeID})
+	if err != nil {
+		return err
+	}
+	return d.Set("pci_device", FlattenPCIDevices(devices))
+}
+
+// Configure attaches and detaches vGPUs and PCI devices. The platform only changes devices of
+// stopped compute, so running or paused compute is stopped first and then brought back to its
+// power state, even if a change fails. Compute is stopped gracefully or forcibly as configured
+// by force_stop.
+func Configure(ctx context.Context, api *compute.Compute, d *schema.ResourceData, computeID uint64) error {
+	detachVGPUs, attachVGPUs := idsDiff(d, "vgpu", "vgpu_id")
+	detachDevices, attachDevices := idsDiff(d, "pci_device", "device_id")
+	if len(detachVGPUs)+len(attachVGPUs)+len(detachDevices)+len(attachDevices) == 0 {
+		return nil
+	}
+
+	_, err := powerstate.WithStopped(ctx, api, computeID, d.Get("force_stop").(bool), func() error {
+		for _, vgpuId := range detachVGPUs {
+			log.Debugf(ctx, "computedevices.Configure: detaching vGPU ID %d from Compute ID %d", vgpuId, computeID)
+			if _, err := api.DetachGPU(ctx, compute.GPURequest{ComputeID: computeID, VGPUID: vgpuId}); err != nil {
+				return err
+			}
+		}
+		for _, deviceId := range detachDevices {
+			log.Debugf(ctx, "computedevices.Configure: detaching PCI device ID %d from Compute ID %d", deviceId, computeID)
+			if _, err := api.DetachPCIDevice(ctx, compute.PCIDeviceRequest{ComputeID: computeID, DeviceID: deviceId}); err != nil {
+				return err
+			}
+		}
+		for _, vgpuId := range attachVGPUs {
+			log.Debugf(ctx, "computedevices.Configure: attaching vGPU ID %d to Compute ID %d", vgpuId, computeID)
+			if _, err := api.AttachGPU(ctx, compute.GPURequest{ComputeID: computeID, VGPUID: vgpuId}); err != nil {
+				return err
+			}
+		}
+		for _, deviceId := range attachDevices {
+			log.Debugf(ctx, "computedevices.Configure: attaching PCI device ID %d to Compute ID %d", deviceId, computeID)
+			if _, err := api.AttachPCIDevice(ctx, compute.PCIDeviceRequest{ComputeID: computeID, DeviceID: deviceId}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// AddedVGPUs returns IDs of vGPUs added to vgpu blocks
+func AddedVGPUs(d *schema.ResourceDiff) []uint64 {
+	oldSet, newSet := d.GetChange("vgpu")
+	return IDs(newSet.(*schema.Set).Difference(oldSet.(*schema.Set)), "vgpu_id")
+}
+
+// CheckVGPUs reports vGPUs, which are missing from vgpus, deleted or attached to another compute
+func CheckVGPUs(vgpus vgpu.ListVGPUs, vgpuIds []uint64, computeID uint64) error {
+	for _, vgpuId := range vgpuIds {
+		var found *vgpu.ItemVGPU
+		for i := range vgpus {
+			if vgpus[i].ID == vgpuId {
+				found = &vgpus[i]
+				break
+			}
+		}
+		switch {
+		case found == nil || found.Status == status.Deleted || found.Status == status.Destroyed:
+			return fmt.Errorf("vGPU ID %d not allowed or does not exist", vgpuId)
+		case found.VMID != 0 && found.VMID != computeID:
+			return fmt.Errorf("vGPU ID %d is already attached to compute ID %d", vgpuId, found.VMID)
+		}
+	}
+	return nil
+}
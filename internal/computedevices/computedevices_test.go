@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computedevices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vgpu"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+func resourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vgpu": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: VGPUSchemaMake("ID of vGPU.")},
+		},
+		"pci_device": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: PCIDeviceSchemaMake()},
+		},
+		"force_stop": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	}
+}
+
+// newCompute puts running compute into the fake controller
+func newCompute(t *testing.T) (*fake.Server, *compute.Compute, uint64) {
+	t.Helper()
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	id := s.NewID()
+	s.Put(fake.KindCompute, id, fake.Object{"id": id, "name": "vm", "techStatus": techstatus.Started})
+	return s, compute.New(s.Client(), "/restmachine/cloudapi"), uint64(id)
+}
+
+func TestConfigure(t *testing.T) {
+	s, api, id := newCompute(t)
+	deviceID := s.NewID()
+	s.Put(fake.KindPCIDevice, deviceID, fake.Object{"id": deviceID, "computeId": 0})
+
+	d := schema.TestResourceDataRaw(t, resourceSchema(), map[string]interface{}{
+		"pci_device": []interface{}{map[string]interface{}{"device_id": deviceID}},
+	})
+	if err := Configure(context.Background(), api, d, id); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	device, _ := s.Get(fake.KindPCIDevice, deviceID)
+	if device["computeId"] != int(id) {
+		t.Errorf("PCI device is attached to %v, want %d", device["computeId"], id)
+	}
+	if obj, _ := s.Get(fake.KindCompute, int(id)); obj["techStatus"] != techstatus.Started {
+		t.Errorf("tech status = %v, want %s", obj["techStatus"], techstatus.Started)
+	}
+}
+
+func TestConfigureRestoresPowerState(t *testing.T) {
+	s, api, id := newCompute(t)
+
+	// the PCI device does not exist, so that attaching it fails
+	d := schema.TestResourceDataRaw(t, resourceSchema(), map[string]interface{}{
+		"pci_device": []interface{}{map[string]interface{}{"device_id": 100500}},
+	})
+	if err := Configure(context.Background(), api, d, id); err == nil {
+		t.Fatal("Configure() error = nil, want error")
+	}
+	if obj, _ := s.Get(fake.KindCompute, int(id)); obj["techStatus"] != techstatus.Started {
+		t.Errorf("tech status = %v, want %s", obj["techStatus"], techstatus.Started)
+	}
+}
+
+func TestPCIDevicesRead(t *testing.T) {
+	s, api, id := newCompute(t)
+	const pciDeviceList = "/restmachine/cloudapi/compute/pciDeviceList"
+
+	d := schema.TestResourceDataRaw(t, resourceSchema(), map[string]interface{}{})
+	if err := PCIDevicesRead(context.Background(), api, d, id); err != nil {
+		t.Fatalf("PCIDevicesRead() error = %v", err)
+	}
+	if n := s.Calls(pciDeviceList); n != 0 {
+		t.Errorf("PCI devices are listed %d times without pci_device blocks, want 0", n)
+	}
+
+	// the device held in state has been detached outside of Terraform
+	d = schema.TestResourceDataRaw(t, resourceSchema(), map[string]interface{}{
+		"pci_device": []interface{}{map[string]interface{}{"device_id": 7}},
+	})
+	if err := PCIDevicesRead(context.Background(), api, d, id); err != nil {
+		t.Fatalf("PCIDevicesRead() error = %v", err)
+	}
+	if n := s.Calls(pciDeviceList); n != 1 {
+		t.Errorf("PCI devices are listed %d times, want 1", n)
+	}
+	if n := d.Get("pci_device").(*schema.Set).Len(); n != 0 {
+		t.Errorf("pci_device holds %d blocks, want 0", n)
+	}
+}
+
+func TestCheckVGPUs(t *testing.T) {
+	vgpus := vgpu.ListVGPUs{
+		{ID: 1, Status: "CREATED"},
+		{ID: 2, Status: "ALLOCATED", VMID: 10},
+		{ID: 3, Status: status.Deleted},
+	}
+	tests := []struct {
+		name    string
+		ids     []uint64
+		wantErr bool
+	}{
+		{name: "free", ids: []uint64{1}},
+		{name: "attached to this compute", ids: []uint64{2}},
+		{name: "missing", ids: []uint64{4}, wantErr: true},
+		{name: "deleted", ids: []uint64{3}, wantErr: true},
+		{name: "several", ids: []uint64{1, 2}},
+		{name: "several with missing", ids: []uint64{1, 4}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckVGPUs(vgpus, tt.ids, 10); (err != nil) != tt.wantErr {
+				t.Errorf("CheckVGPUs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := CheckVGPUs(vgpus, []uint64{2}, 11); err == nil {
+		t.Error("CheckVGPUs() error = nil for vGPU attached to another compute")
+	}
+}
@@ -0,0 +1,357 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computepfw
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+)
+
+func testAPI(m interface{}) *compute.Compute {
+	return compute.New(m.(controller.APICaller), "/restmachine/cloudapi")
+}
+
+func TestPfwRuleOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b pfwRule
+		want bool
+	}{
+		{name: "same port", a: pfwRule{PublicPortStart: 80, PublicPortEnd: 80, Proto: "tcp"}, b: pfwRule{PublicPortStart: 80, PublicPortEnd: 80, Proto: "tcp"}, want: true},
+		{name: "other protocol", a: pfwRule{PublicPortStart: 80, PublicPortEnd: 80, Proto: "tcp"}, b: pfwRule{PublicPortStart: 80, PublicPortEnd: 80, Proto: "udp"}},
+		{name: "adjacent ranges", a: pfwRule{PublicPortStart: 80, PublicPortEnd: 89, Proto: "tcp"}, b: pfwRule{PublicPortStart: 90, PublicPortEnd: 99, Proto: "tcp"}},
+		{name: "shared edge", a: pfwRule{PublicPortStart: 80, PublicPortEnd: 90, Proto: "tcp"}, b: pfwRule{PublicPortStart: 90, PublicPortEnd: 99, Proto: "tcp"}, want: true},
+		{name: "nested range", a: pfwRule{PublicPortStart: 80, PublicPortEnd: 99, Proto: "udp"}, b: pfwRule{PublicPortStart: 85, PublicPortEnd: 86, Proto: "udp"}, want: true},
+		{name: "local port ignored", a: pfwRule{PublicPortStart: 80, PublicPortEnd: 80, LocalBasePort: 8080, Proto: "tcp"}, b: pfwRule{PublicPortStart: 81, PublicPortEnd: 81, LocalBasePort: 8080, Proto: "tcp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.overlaps(tt.b); got != tt.want {
+				t.Errorf("%s.overlaps(%s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.b.overlaps(tt.a); got != tt.want {
+				t.Errorf("%s.overlaps(%s) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPfwRuleFromMap(t *testing.T) {
+	rule := pfwRuleFromMap(map[string]interface{}{"public_port_start": 80, "public_port_end": 0, "local_base_port": 8080, "proto": "tcp"})
+	if want := (pfwRule{PublicPortStart: 80, PublicPortEnd: 80, LocalBasePort: 8080, Proto: "tcp"}); rule != want {
+		t.Errorf("pfwRuleFromMap() = %s, want %s", rule, want)
+	}
+}
+
+func TestPfwId(t *testing.T) {
+	if id := makeId(12, 345); id != "12-345" {
+		t.Errorf("makeId() = %q, want %q", id, "12-345")
+	}
+
+	tests := []struct {
+		id            string
+		wantComputeId uint64
+		wantRuleId    uint64
+		wantErr       bool
+	}{
+		{id: "12-345", wantComputeId: 12, wantRuleId: 345},
+		{id: "12", wantErr: true},
+		{id: "12-", wantErr: true},
+		{id: "-345", wantErr: true},
+		{id: "12-345-6", wantErr: true},
+		{id: "vm-345", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			computeId, ruleId, err := parseId(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if computeId != tt.wantComputeId || ruleId != tt.wantRuleId {
+				t.Errorf("parseId() = %d, %d, want %d, %d", computeId, ruleId, tt.wantComputeId, tt.wantRuleId)
+			}
+		})
+	}
+}
+
+// TestPfwSetReconcile checks that rules of the compute are made to match rule blocks, leaving
+// rules not managed by the resource intact unless delete_unknown is set
+func TestPfwSetReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		deleteUnknown bool
+		want          []pfwRule
+	}{
+		{
+			name: "unknown kept",
+			want: []pfwRule{
+				{PublicPortStart: 22, PublicPortEnd: 22, LocalBasePort: 22, Proto: "tcp"},
+				{PublicPortStart: 80, PublicPortEnd: 80, LocalBasePort: 8080, Proto: "tcp"},
+				{PublicPortStart: 53, PublicPortEnd: 53, LocalBasePort: 53, Proto: "udp"},
+			},
+		},
+		{
+			name:          "unknown deleted",
+			deleteUnknown: true,
+			want: []pfwRule{
+				{PublicPortStart: 80, PublicPortEnd: 80, LocalBasePort: 8080, Proto: "tcp"},
+				{PublicPortStart: 53, PublicPortEnd: 53, LocalBasePort: 53, Proto: "udp"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			t.Cleanup(s.Close)
+			ctx := context.Background()
+			m := s.Client()
+
+			// the compute has an unknown rule, a managed rule, which is kept, and a managed rule, which is removed
+			id := s.NewID()
+			s.Put(fake.KindCompute, id, fake.Object{"id": id, "name": "vm", "techStatus": "STARTED"})
+			api := testAPI(m)
+			for _, rule := range []pfwRule{
+				{PublicPortStart: 22, LocalBasePort: 22, Proto: "tcp"},
+				{PublicPortStart: 80, LocalBasePort: 8080, Proto: "tcp"},
+				{PublicPortStart: 443, LocalBasePort: 8443, Proto: "tcp"},
+			} {
+				if _, err := api.PFWAdd(ctx, compute.PFWAddRequest{ComputeID: uint64(id), PublicPortStart: rule.PublicPortStart,
+					LocalBasePort: rule.LocalBasePort, Proto: rule.Proto}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			actual, _ := api.PFWList(ctx, compute.IDRequest{ComputeID: uint64(id)})
+
+			r := ResourcePfwSet(testAPI)
+			prior := r.Data(nil)
+			prior.SetId(strconv.Itoa(id))
+			prior.Set("compute_id", id)
+			prior.Set("rule", flattenPfwRules(actual[1:]))
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"compute_id":     id,
+				"delete_unknown": tt.deleteUnknown,
+				"rule": []interface{}{
+					map[string]interface{}{"public_port_start": 80, "local_base_port": 8080, "proto": "tcp"},
+					map[string]interface{}{"public_port_start": 53, "local_base_port": 53, "proto": "udp"},
+				},
+			})
+			state := prior.State()
+			diff, err := r.Diff(ctx, state, config, m)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			d, err := schema.InternalMap(r.Schema).Data(state, diff)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := (pfwSet{api: testAPI}).reconcile(ctx, d, m); err != nil {
+				t.Fatalf("reconcile() error = %v", err)
+			}
+
+			pfws, _ := api.PFWList(ctx, compute.IDRequest{ComputeID: uint64(id)})
+			got := make(map[pfwRule]bool)
+			for _, pfw := range pfws {
+				got[pfwRuleFromItem(pfw)] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("compute has %d rules, want %d", len(got), len(tt.want))
+			}
+			for _, rule := range tt.want {
+				if !got[rule] {
+					t.Errorf("rule %s is missing", rule)
+				}
+			}
+			if got[pfwRuleFromItem(actual[0])] && pfws[0].ID != actual[0].ID {
+				t.Errorf("unknown rule was re-added under ID %d", pfws[0].ID)
+			}
+		})
+	}
+}
+
+// TestPfwSetCheckOverlaps checks that configured rules are checked against each other and
+// against rules of the compute not managed by the resource
+func TestPfwSetCheckOverlaps(t *testing.T) {
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+	ctx := context.Background()
+	m := s.Client()
+
+	id := s.NewID()
+	s.Put(fake.KindCompute, id, fake.Object{"id": id, "name": "vm", "techStatus": "STARTED"})
+	if _, err := testAPI(m).PFWAdd(ctx, compute.PFWAddRequest{ComputeID: uint64(id), PublicPortStart: 22, LocalBasePort: 22, Proto: "tcp"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		rules         []interface{}
+		deleteUnknown bool
+		wantErr       bool
+	}{
+		{name: "distinct", rules: []interface{}{
+			map[string]interface{}{"public_port_start": 80, "local_base_port": 80, "proto": "tcp"},
+			map[string]interface{}{"public_port_start": 80, "local_base_port": 80, "proto": "udp"},
+		}},
+		{name: "overlapping each other", wantErr: true, rules: []interface{}{
+			map[string]interface{}{"public_port_start": 80, "public_port_end": 90, "local_base_port": 80, "proto": "tcp"},
+			map[string]interface{}{"public_port_start": 85, "local_base_port": 85, "proto": "tcp"},
+		}},
+		{name: "overlapping unknown", wantErr: true, rules: []interface{}{
+			map[string]interface{}{"public_port_start": 20, "public_port_end": 30, "local_base_port": 20, "proto": "tcp"},
+		}},
+		{name: "overlapping unknown to be deleted", deleteUnknown: true, rules: []interface{}{
+			map[string]interface{}{"public_port_start": 20, "public_port_end": 30, "local_base_port": 20, "proto": "tcp"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ResourcePfwSet(testAPI)
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"compute_id":     id,
+				"delete_unknown": tt.deleteUnknown,
+				"rule":           tt.rules,
+			})
+			if _, err := r.Diff(ctx, nil, config, m); (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// testRawConfig returns raw configuration of the resource with the given attributes set
+func testRawConfig(r *schema.Resource, raw map[string]interface{}) cty.Value {
+	attrs := make(map[string]cty.Value)
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		switch v := raw[name].(type) {
+		case int:
+			attrs[name] = cty.NumberIntVal(int64(v))
+		case string:
+			attrs[name] = cty.StringVal(v)
+		default:
+			attrs[name] = cty.NullVal(ty)
+		}
+	}
+	return cty.ObjectVal(attrs)
+}
+
+func TestPfwCustomizeDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "start raised above unchanged end", wantErr: true, config: map[string]interface{}{"public_port_start": 95, "public_port_end": 90}},
+		{name: "end lowered below unchanged start", wantErr: true, config: map[string]interface{}{"public_port_start": 80, "public_port_end": 70}},
+		{name: "range moved", config: map[string]interface{}{"public_port_start": 95, "public_port_end": 99}},
+		{name: "end follows start", config: map[string]interface{}{"public_port_start": 95}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			t.Cleanup(s.Close)
+
+			r := ResourcePfw(testAPI)
+			prior := r.Data(nil)
+			prior.SetId(makeId(1, 2))
+			for key, value := range map[string]interface{}{"compute_id": 1, "public_port_start": 80, "public_port_end": 90, "local_base_port": 8080, "proto": "tcp"} {
+				prior.Set(key, value)
+			}
+
+			config := map[string]interface{}{"compute_id": 1, "local_base_port": 8080, "proto": "tcp"}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+			// raw configuration is sent by Terraform along with the prior state
+			state := prior.State()
+			state.RawConfig = testRawConfig(r, config)
+			_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), s.Client())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPfwParentGone checks that rules of destroyed compute are removed from the state with
+// a warning on refresh and destroy, and those of deleted compute are kept until restore
+func TestPfwParentGone(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		exists   bool
+		wantGone bool
+	}{
+		{name: "not found", wantGone: true},
+		{name: "destroyed", status: "DESTROYED", exists: true, wantGone: true},
+		{name: "deleted", status: "DELETED", exists: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			t.Cleanup(s.Close)
+			ctx := context.Background()
+			m := s.Client()
+
+			id := s.NewID()
+			if tt.exists {
+				s.Put(fake.KindCompute, id, fake.Object{"id": id, "name": "vm", "status": tt.status, "techStatus": "STOPPED",
+					"pfws": []fake.Object{{"id": 7, "localIp": "192.168.0.10", "localPort": 22, "protocol": "tcp", "publicPortStart": 2222, "publicPortEnd": 2222, "vmId": id}}})
+			}
+
+			p, set := pfw{api: testAPI}, pfwSet{api: testAPI}
+			ops := map[string]struct {
+				schema map[string]*schema.Schema
+				id     string
+				op     func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+			}{
+				"rule read":   {resourcePfwSchemaMake(), makeId(uint64(id), 7), p.read},
+				"rule delete": {resourcePfwSchemaMake(), makeId(uint64(id), 7), p.delete},
+				"set read":    {resourcePfwSetSchemaMake(), strconv.Itoa(id), set.read},
+				"set delete":  {resourcePfwSetSchemaMake(), strconv.Itoa(id), set.delete},
+			}
+			for name, op := range ops {
+				d := schema.TestResourceDataRaw(t, op.schema, map[string]interface{}{"compute_id": id})
+				d.SetId(op.id)
+				diags := op.op(ctx, d, m)
+				if tt.wantGone {
+					if len(diags) != 1 || diags[0].Severity != diag.Warning || d.Id() != "" {
+						t.Errorf("%s: diags = %v, ID = %q, want a warning and empty ID", name, diags, d.Id())
+					}
+					continue
+				}
+				if diags.HasError() {
+					t.Errorf("%s: diags = %v, want no errors", name, diags)
+				}
+			}
+		})
+	}
+}
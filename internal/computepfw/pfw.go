@@ -0,0 +1,363 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package computepfw implements decort_pfw and decort_pfw_set resources, which manage port
+// forwarding rules of compute. It is shared by cloudapi and cloudbroker providers, which pass
+// the compute API client they are bound to.
+package computepfw
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+)
+
+var log = logging.New("computepfw")
+
+// API returns compute API client of the provider meta
+type API func(m interface{}) *compute.Compute
+
+// timeouts are shared by decort_pfw and decort_pfw_set of both providers
+func timeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create:  &constants.Timeout600s,
+		Read:    &constants.Timeout300s,
+		Update:  &constants.Timeout300s,
+		Delete:  &constants.Timeout300s,
+		Default: &constants.Timeout300s,
+	}
+}
+
+func makeId(computeId, ruleId uint64) string {
+	return fmt.Sprintf("%d-%d", computeId, ruleId)
+}
+
+// parseId parses ID of decort_pfw resource, which is <compute_id>-<rule_id>
+func parseId(id string) (uint64, uint64, error) {
+	computeIdS, ruleIdS, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid port forwarding rule ID %q, expected <compute_id>-<rule_id>", id)
+	}
+	computeId, err := strconv.ParseUint(computeIdS, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid compute ID in port forwarding rule ID %q: %w", id, err)
+	}
+	ruleId, err := strconv.ParseUint(ruleIdS, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rule ID in port forwarding rule ID %q: %w", id, err)
+	}
+	return computeId, ruleId, nil
+}
+
+// pfwList returns port forwarding rules of the compute. If the compute is gone, the resource
+// of the given kind is removed from the state and diagnostics explaining why are returned instead.
+func pfwList(ctx context.Context, api *compute.Compute, d *schema.ResourceData, m interface{}, kind string, computeId uint64) (compute.ListPFWs, diag.Diagnostics) {
+	c, err := api.Get(ctx, compute.GetRequest{ComputeID: computeId})
+	if err != nil {
+		if controller.IsNotFound(err) {
+			return nil, dc.ParentGone(d, kind, "compute", status.Destroyed)
+		}
+		return nil, diag.FromErr(err)
+	}
+
+	switch c.Status {
+	case status.Destroyed:
+		return nil, dc.ParentGone(d, kind, "compute", c.Status)
+	case status.Deleted:
+		// compute resource restores deleted compute with its rules, unless drift policy forbids it
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return nil, dc.ParentGone(d, kind, "compute", c.Status)
+		}
+	}
+
+	pfws, err := api.PFWList(ctx, compute.IDRequest{ComputeID: computeId})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return pfws, nil
+}
+
+// pfw implements decort_pfw resource on top of compute API returned by api
+type pfw struct {
+	api API
+}
+
+// checkPresence returns the rule identified by resource ID or nil, if compute does not have it
+// anymore. If the compute itself is gone, diagnostics of pfwList are returned instead.
+func (p pfw) checkPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*compute.ItemPFW, diag.Diagnostics) {
+	computeId, ruleId, err := parseId(d.Id())
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	pfws, diags := pfwList(ctx, p.api(m), d, m, "Port forwarding rule", computeId)
+	if diags != nil {
+		return nil, diags
+	}
+
+	for _, pfw := range pfws {
+		if pfw.ID == ruleId {
+			return &pfw, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (p pfw) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwCreate: called for compute %d", d.Get("compute_id").(int))
+
+	computeId := uint64(d.Get("compute_id").(int))
+	pfwId, err := p.api(m).PFWAdd(ctx, compute.PFWAddRequest{
+		ComputeID:       computeId,
+		PublicPortStart: uint64(d.Get("public_port_start").(int)),
+		PublicPortEnd:   uint64(d.Get("public_port_end").(int)),
+		LocalBasePort:   uint64(d.Get("local_base_port").(int)),
+		Proto:           d.Get("proto").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(makeId(computeId, pfwId))
+
+	pfw, diags := p.checkPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if pfw == nil {
+		return diag.Errorf("resourcePfwCreate: port forwarding rule %s is not found after creation", d.Id())
+	}
+
+	d.Set("local_ip", pfw.LocalIP)
+	if _, ok := d.GetOk("public_port_end"); !ok {
+		d.Set("public_port_end", pfw.PublicPortEnd)
+	}
+
+	return nil
+}
+
+func (p pfw) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwRead: called for compute %d, rule %s", d.Get("compute_id").(int), d.Id())
+
+	pfw, diags := p.checkPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if pfw == nil {
+		log.Warnf(ctx, "resourcePfwRead: port forwarding rule %s was removed outside of Terraform", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// compute ID is taken from resource ID, so that imported rules get it too
+	computeId, _, _ := parseId(d.Id())
+	d.Set("compute_id", computeId)
+	d.Set("public_port_start", pfw.PublicPortStart)
+	d.Set("public_port_end", pfw.PublicPortEnd)
+	d.Set("local_ip", pfw.LocalIP)
+	d.Set("local_base_port", pfw.LocalPort)
+	d.Set("proto", pfw.Protocol)
+
+	return nil
+}
+
+func (p pfw) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwUpdate: called for compute %d, rule %s", d.Get("compute_id").(int), d.Id())
+
+	computeId, ruleId, err := parseId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	api := p.api(m)
+
+	req := compute.PFWAddRequest{
+		ComputeID:       computeId,
+		PublicPortStart: uint64(d.Get("public_port_start").(int)),
+		PublicPortEnd:   uint64(d.Get("public_port_end").(int)),
+		LocalBasePort:   uint64(d.Get("local_base_port").(int)),
+		Proto:           d.Get("proto").(string),
+	}
+	if err := req.Validate(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// there is no API call to change a rule, so that it is deleted and added again under the
+	// same resource. The old rule is deleted first, as the new one may reuse its public ports.
+	_, err = api.PFWDel(ctx, compute.PFWDelRequest{ComputeID: computeId, RuleID: ruleId})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	newRuleId, err := api.PFWAdd(ctx, req)
+	if err != nil {
+		// put the old rule back, so that failed update does not leave ports unforwarded
+		d.Partial(true)
+		oldStart, _ := d.GetChange("public_port_start")
+		oldEnd, _ := d.GetChange("public_port_end")
+		oldLocalPort, _ := d.GetChange("local_base_port")
+		oldProto, _ := d.GetChange("proto")
+		restoredId, restoreErr := api.PFWAdd(ctx, compute.PFWAddRequest{
+			ComputeID:       computeId,
+			PublicPortStart: uint64(oldStart.(int)),
+			PublicPortEnd:   uint64(oldEnd.(int)),
+			LocalBasePort:   uint64(oldLocalPort.(int)),
+			Proto:           oldProto.(string),
+		})
+		if restoreErr != nil {
+			log.Errorf(ctx, "resourcePfwUpdate: cannot restore port forwarding rule %s: %v", d.Id(), restoreErr)
+			d.SetId("")
+		} else {
+			d.SetId(makeId(computeId, restoredId))
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId(makeId(computeId, newRuleId))
+
+	return p.read(ctx, d, m)
+}
+
+func (p pfw) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwDelete: called for compute %d, rule %s", d.Get("compute_id").(int), d.Id())
+
+	pfw, diags := p.checkPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if pfw == nil {
+		d.SetId("")
+		return nil
+	}
+
+	computeId, _, _ := parseId(d.Id())
+	_, err := p.api(m).PFWDel(ctx, compute.PFWDelRequest{
+		ComputeID: computeId,
+		RuleID:    pfw.ID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePfwCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// public_port_end is checked as configured, as the planned value may be the one of the
+	// previous rule, which follows public_port_start below
+	if d.HasChanges("public_port_start", "public_port_end") && d.NewValueKnown("public_port_start") &&
+		!d.GetRawConfig().IsNull() {
+		end := d.GetRawConfig().GetAttr("public_port_end")
+		if end.IsKnown() && !end.IsNull() {
+			endPort, _ := end.AsBigFloat().Int64()
+			if start := d.Get("public_port_start").(int); endPort != 0 && int(endPort) < start {
+				return fmt.Errorf("public_port_end %d must not be less than public_port_start %d", endPort, start)
+			}
+		}
+	}
+
+	// public_port_end follows public_port_start of the updated rule, unless it is set explicitly
+	if d.Id() != "" && d.HasChange("public_port_start") && !d.GetRawConfig().IsNull() &&
+		d.GetRawConfig().GetAttr("public_port_end").IsNull() {
+		return d.SetNewComputed("public_port_end")
+	}
+
+	return nil
+}
+
+func resourcePfwSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"compute_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of compute instance.",
+		},
+
+		"public_port_start": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+			Description:  "External start port number for the rule.",
+		},
+
+		"public_port_end": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+			Description:  "End port number (inclusive) for the ranged rule.",
+		},
+
+		"local_ip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "IP address of compute instance.",
+		},
+
+		"local_base_port": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+			Description:  "Internal base port number.",
+		},
+
+		"proto": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
+			Description:  "Network protocol, either 'tcp' or 'udp'.",
+		},
+	}
+}
+
+// ResourcePfw returns decort_pfw resource, which manages a single port forwarding rule of compute
+func ResourcePfw(api API) *schema.Resource {
+	p := pfw{api: api}
+	return &schema.Resource{
+		SchemaVersion: 1,
+
+		CreateContext: p.create,
+		ReadContext:   p.read,
+		UpdateContext: p.update,
+		DeleteContext: p.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: timeouts(),
+
+		CustomizeDiff: resourcePfwCustomizeDiff,
+
+		Schema: resourcePfwSchemaMake(),
+	}
+}
@@ -0,0 +1,423 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computepfw
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+)
+
+// pfwRule is a port forwarding rule as configured in rule block of decort_pfw_set
+type pfwRule struct {
+	PublicPortStart uint64
+	PublicPortEnd   uint64
+	LocalBasePort   uint64
+	Proto           string
+}
+
+func (r pfwRule) String() string {
+	return fmt.Sprintf("%s/%d-%d->%d", r.Proto, r.PublicPortStart, r.PublicPortEnd, r.LocalBasePort)
+}
+
+func (r pfwRule) overlaps(other pfwRule) bool {
+	return r.Proto == other.Proto &&
+		r.PublicPortStart <= other.PublicPortEnd && other.PublicPortStart <= r.PublicPortEnd
+}
+
+// pfwRuleFromMap makes rule of rule block element. Unset public_port_end means a single port.
+func pfwRuleFromMap(item map[string]interface{}) pfwRule {
+	rule := pfwRule{
+		PublicPortStart: uint64(item["public_port_start"].(int)),
+		PublicPortEnd:   uint64(item["public_port_end"].(int)),
+		LocalBasePort:   uint64(item["local_base_port"].(int)),
+		Proto:           item["proto"].(string),
+	}
+	if rule.PublicPortEnd == 0 {
+		rule.PublicPortEnd = rule.PublicPortStart
+	}
+	return rule
+}
+
+func pfwRuleFromItem(item compute.ItemPFW) pfwRule {
+	return pfwRule{
+		PublicPortStart: item.PublicPortStart,
+		PublicPortEnd:   item.PublicPortEnd,
+		LocalBasePort:   item.LocalPort,
+		Proto:           item.Protocol,
+	}
+}
+
+// hashPfwRule identifies rule by its configurable fields, as rule_id is only known after the
+// rule is added and public_port_end is computed when not set
+func hashPfwRule(v interface{}) int {
+	return schema.HashString(pfwRuleFromMap(v.(map[string]interface{})).String())
+}
+
+func flattenPfwRules(pfws compute.ListPFWs) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(pfws))
+	for _, pfw := range pfws {
+		res = append(res, map[string]interface{}{
+			"public_port_start": pfw.PublicPortStart,
+			"public_port_end":   pfw.PublicPortEnd,
+			"local_base_port":   pfw.LocalPort,
+			"proto":             pfw.Protocol,
+			"rule_id":           pfw.ID,
+			"local_ip":          pfw.LocalIP,
+		})
+	}
+	return res
+}
+
+// managedRuleIds returns IDs of the rules recorded in the state
+func managedRuleIds(rules *schema.Set) map[uint64]bool {
+	res := make(map[uint64]bool, rules.Len())
+	for _, item := range rules.List() {
+		if ruleId := item.(map[string]interface{})["rule_id"].(int); ruleId != 0 {
+			res[uint64(ruleId)] = true
+		}
+	}
+	return res
+}
+
+// pfwSet implements decort_pfw_set resource on top of compute API returned by api
+type pfwSet struct {
+	api API
+}
+
+// reconcile makes port forwarding rules of the compute match rule blocks. Rules
+// managed by the resource, and all other rules when delete_unknown is set, are kept if they
+// are still configured and deleted otherwise. Configured rules, which are not kept, are added.
+func (p pfwSet) reconcile(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := p.api(m)
+
+	actual, err := api.PFWList(ctx, compute.IDRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+
+	oldRules, newRules := d.GetChange("rule")
+	managedIds := managedRuleIds(oldRules.(*schema.Set))
+	deleteUnknown := d.Get("delete_unknown").(bool)
+
+	desired := make(map[pfwRule]bool)
+	for _, item := range newRules.(*schema.Set).List() {
+		desired[pfwRuleFromMap(item.(map[string]interface{}))] = true
+	}
+
+	kept := make(map[pfwRule]bool)
+	for _, pfw := range actual {
+		if !managedIds[pfw.ID] && !deleteUnknown {
+			continue
+		}
+		rule := pfwRuleFromItem(pfw)
+		if desired[rule] && !kept[rule] {
+			kept[rule] = true
+			continue
+		}
+		log.Debugf(ctx, "resourcePfwSetReconcile: deleting rule ID %d %s of compute ID %d", pfw.ID, rule, computeId)
+		if _, err := api.PFWDel(ctx, compute.PFWDelRequest{ComputeID: computeId, RuleID: pfw.ID}); err != nil {
+			return err
+		}
+	}
+
+	for rule := range desired {
+		if kept[rule] {
+			continue
+		}
+		log.Debugf(ctx, "resourcePfwSetReconcile: adding rule %s to compute ID %d", rule, computeId)
+		_, err := api.PFWAdd(ctx, compute.PFWAddRequest{
+			ComputeID:       computeId,
+			PublicPortStart: rule.PublicPortStart,
+			PublicPortEnd:   rule.PublicPortEnd,
+			LocalBasePort:   rule.LocalBasePort,
+			Proto:           rule.Proto,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkOverlaps reports rules with overlapping public port ranges, including the
+// rules of the compute not managed by the resource, which are left in place
+func (p pfwSet) checkOverlaps(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	rules := make([]pfwRule, 0)
+	for _, item := range d.Get("rule").(*schema.Set).List() {
+		rules = append(rules, pfwRuleFromMap(item.(map[string]interface{})))
+	}
+
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			if rules[i].overlaps(rules[j]) {
+				return fmt.Errorf("public ports of rules %s and %s overlap", rules[i], rules[j])
+			}
+		}
+	}
+
+	if d.Get("delete_unknown").(bool) || !d.NewValueKnown("compute_id") {
+		return nil
+	}
+
+	computeId := uint64(d.Get("compute_id").(int))
+	actual, err := p.api(m).PFWList(ctx, compute.IDRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+
+	oldRules, _ := d.GetChange("rule")
+	managedIds := managedRuleIds(oldRules.(*schema.Set))
+	for _, pfw := range actual {
+		if managedIds[pfw.ID] {
+			continue
+		}
+		unknown := pfwRuleFromItem(pfw)
+		for _, rule := range rules {
+			if rule.overlaps(unknown) {
+				return fmt.Errorf("public ports of rule %s overlap with rule ID %d %s of compute ID %d, which is not managed by this resource",
+					rule, pfw.ID, unknown, computeId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p pfwSet) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwSetCreate: called for compute %d", d.Get("compute_id").(int))
+
+	d.SetId(strconv.Itoa(d.Get("compute_id").(int)))
+	if err := p.reconcile(ctx, d, m); err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	return p.read(ctx, d, m)
+}
+
+func (p pfwSet) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwSetRead: called for compute %s", d.Id())
+
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pfws, diags := pfwList(ctx, p.api(m), d, m, "Port forwarding rule set", computeId)
+	if diags != nil {
+		return diags
+	}
+
+	// rules just added by Create or Update have no rule_id yet, so that they are matched by
+	// their fields. Rules not managed by the resource are shown only if they are to be deleted.
+	managedIds := managedRuleIds(d.Get("rule").(*schema.Set))
+	added := make(map[pfwRule]bool)
+	for _, item := range d.Get("rule").(*schema.Set).List() {
+		if item.(map[string]interface{})["rule_id"].(int) == 0 {
+			added[pfwRuleFromMap(item.(map[string]interface{}))] = true
+		}
+	}
+	deleteUnknown := d.Get("delete_unknown").(bool)
+	rules := make(compute.ListPFWs, 0, len(pfws))
+	for _, pfw := range pfws {
+		if rule := pfwRuleFromItem(pfw); added[rule] {
+			delete(added, rule)
+		} else if !deleteUnknown && !managedIds[pfw.ID] {
+			continue
+		}
+		rules = append(rules, pfw)
+	}
+
+	d.Set("compute_id", computeId)
+	d.Set("rule", flattenPfwRules(rules))
+
+	return nil
+}
+
+func (p pfwSet) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwSetUpdate: called for compute %s", d.Id())
+
+	if d.HasChanges("rule", "delete_unknown") {
+		if err := p.reconcile(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return p.read(ctx, d, m)
+}
+
+func (p pfwSet) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourcePfwSetDelete: called for compute %s", d.Id())
+
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	api := p.api(m)
+
+	pfws, diags := pfwList(ctx, api, d, m, "Port forwarding rule set", computeId)
+	if diags != nil {
+		return diags
+	}
+
+	managedIds := managedRuleIds(d.Get("rule").(*schema.Set))
+	for _, pfw := range pfws {
+		if !managedIds[pfw.ID] {
+			continue
+		}
+		if _, err := api.PFWDel(ctx, compute.PFWDelRequest{ComputeID: computeId, RuleID: pfw.ID}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// importState takes all rules of the compute under management of the resource
+func (p pfwSet) importState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	pfws, err := p.api(m).PFWList(ctx, compute.IDRequest{ComputeID: computeId})
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("compute_id", computeId)
+	d.Set("rule", flattenPfwRules(pfws))
+	d.Set("delete_unknown", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func (p pfwSet) customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("rule") || !d.NewValueKnown("delete_unknown") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("rule", "delete_unknown") {
+		return nil
+	}
+
+	return p.checkOverlaps(ctx, d, m)
+}
+
+func pfwSetRuleSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"public_port_start": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+			Description:  "External start port number for the rule.",
+		},
+		"public_port_end": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+			Description:  "End port number (inclusive) for the ranged rule. Equals to public_port_start if not set.",
+		},
+		"local_base_port": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+			Description:  "Internal base port number.",
+		},
+		"proto": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
+			Description:  "Network protocol, either 'tcp' or 'udp'.",
+		},
+		"rule_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "ID of the rule assigned by the platform.",
+		},
+		"local_ip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "IP address of compute instance.",
+		},
+	}
+}
+
+func resourcePfwSetSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"compute_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of compute instance.",
+		},
+		"rule": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Set:      hashPfwRule,
+			Elem: &schema.Resource{
+				Schema: pfwSetRuleSchemaMake(),
+			},
+			Description: "Port forwarding rules of the compute instance.",
+		},
+		"delete_unknown": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Also delete port forwarding rules of the compute instance, which were not created by this resource and are not listed in rule blocks. By default only rules created by this resource are deleted, when removed from rule blocks.",
+		},
+	}
+}
+
+// ResourcePfwSet returns decort_pfw_set resource, which manages all port forwarding rules of compute
+func ResourcePfwSet(api API) *schema.Resource {
+	p := pfwSet{api: api}
+	return &schema.Resource{
+		SchemaVersion: 1,
+
+		CreateContext: p.create,
+		ReadContext:   p.read,
+		UpdateContext: p.update,
+		DeleteContext: p.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: p.importState,
+		},
+
+		Timeouts: timeouts(),
+
+		CustomizeDiff: p.customizeDiff,
+
+		Schema: resourcePfwSetSchemaMake(),
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "sync"
+
+// Caches keeps values shared by all resources and data sources of a provider instance,
+// e.g. lookup caches. They live as long as the provider meta, which owns them.
+type Caches struct {
+	mu     sync.Mutex // guards values
+	values map[string]interface{}
+}
+
+// Get returns the value stored under the key, creating it with create on first use.
+// Nil Caches store nothing, so that create is called every time.
+func (c *Caches) Get(key string, create func() interface{}) interface{} {
+	if c == nil {
+		return create()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.values[key]; ok {
+		return v
+	}
+	if c.values == nil {
+		c.values = make(map[string]interface{})
+	}
+	v := create()
+	c.values[key] = v
+	return v
+}
+
+// Caches returns caches of this provider instance
+func (config *ControllerCfg) Caches() *Caches {
+	return &config.caches
+}
+
+// CachesOf returns caches of the provider meta or nil, if the meta does not keep any
+func CachesOf(m interface{}) *Caches {
+	if holder, ok := m.(interface{ Caches() *Caches }); ok {
+		return holder.Caches()
+	}
+	return nil
+}
@@ -24,23 +24,26 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	// "time"
-
-	log "github.com/sirupsen/logrus"
-
 	jwt "github.com/golang-jwt/jwt/v4"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
 )
 
+var log = logging.New("controller")
+
 // enumerated constants that define authentication modes
 const (
 	MODE_UNDEF  = iota // this is the invalid mode - it should never be seen
@@ -49,6 +52,9 @@ const (
 	MODE_JWT    = iota
 )
 
+// JWT obtained in oauth2 mode is refreshed when it is about to expire within this interval
+const jwtRefreshMargin = 5 * time.Minute
+
 type ControllerCfg struct {
 	controller_url  string       // always required
 	auth_mode_code  int          // always required
@@ -62,9 +68,44 @@ type ControllerCfg struct {
 	oauth2_url      string       // always required
 	decort_username string       // assigned to either legacy_user (legacy mode) or Oauth2 user (oauth2 mode) upon successful verification
 	cc_client       *http.Client // assigned when all initial checks successfully passed
+	retry_policy    RetryPolicy  // defines how transient API errors are retried
+	drift_policy    DriftPolicy  // defines how Read handlers react to objects deleted outside of Terraform
+	omit_secrets    bool         // if true, computed secrets are not stored in resource state
+	jwt_expires     time.Time    // expiration time of JWT obtained in oauth2 mode, zero if unknown
+	auth_mutex      sync.RWMutex // guards jwt, jwt_expires and legacy_sid, which may be renewed concurrently with API calls
+	caches          Caches       // values shared by resources of this provider instance, e.g. lookup caches
+}
+
+// APICaller is implemented by provider meta passed to all resources and data sources.
+// Resources should depend on this interface rather than on *ControllerCfg, so that
+// they can be exercised against a fake DECORT controller.
+type APICaller interface {
+	DecortAPICall(ctx context.Context, method string, api_name string, url_values *url.Values) (string, error)
+	GetDecortUsername() string
+}
+
+var _ APICaller = (*ControllerCfg)(nil)
+
+// APIError is returned by DecortAPICall when DECORT controller responds with unexpected status code
+type APIError struct {
+	StatusCode int
+	URL        string
+	Params     string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("decortAPICall: unexpected status code %d when calling API %q with request Body %q. Respone:\n%s",
+		e.StatusCode, e.URL, e.Params, e.Body)
 }
 
-func ControllerConfigure(d *schema.ResourceData) (*ControllerCfg, error) {
+// IsNotFound reports whether the API call failed, because the requested object does not exist
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func ControllerConfigure(ctx context.Context, d *schema.ResourceData) (*ControllerCfg, error) {
 	// This function first will check that all required provider parameters for the
 	// selected authenticator mode are set correctly and initialize ControllerCfg structure
 	// based on the provided parameters.
@@ -91,6 +132,10 @@ func ControllerConfigure(d *schema.ResourceData) (*ControllerCfg, error) {
 
 	allow_unverified_ssl := d.Get("allow_unverified_ssl").(bool)
 
+	ret_config.retry_policy = retryPolicyFromSchema(d)
+	ret_config.drift_policy = DriftPolicy(d.Get("drift_policy").(string))
+	ret_config.omit_secrets = d.Get("omit_secrets_from_state").(bool)
+
 	if ret_config.controller_url == "" {
 		return nil, fmt.Errorf("Empty DECORT cloud controller URL provided.")
 	}
@@ -130,33 +175,32 @@ func ControllerConfigure(d *schema.ResourceData) (*ControllerCfg, error) {
 		return nil, fmt.Errorf("Unknown authenticator mode %q provided.", ret_config.auth_mode_txt)
 	}
 
+	var transport http.RoundTripper = http.DefaultTransport
 	if allow_unverified_ssl {
-		log.Warn("ControllerConfigure: allow_unverified_ssl is set - will not check certificates!")
-		transCfg := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}} //nolint:gosec
-		ret_config.cc_client = &http.Client{
-			Transport: transCfg,
-		}
-	} else {
-		ret_config.cc_client = &http.Client{}
+		log.Warn(ctx, "ControllerConfigure: allow_unverified_ssl is set - will not check certificates!")
+		transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}} //nolint:gosec
+	}
+	ret_config.cc_client = &http.Client{
+		Transport: NewRetryTransport(transport, ret_config.retry_policy),
 	}
 
 	switch ret_config.auth_mode_code {
 	case MODE_LEGACY:
-		ok, err := ret_config.validateLegacyUser()
+		ok, err := ret_config.validateLegacyUser(ctx)
 		if !ok {
 			return nil, err
 		}
 		ret_config.decort_username = ret_config.legacy_user
 	case MODE_JWT:
 		//
-		ok, err := ret_config.validateJWT("")
+		ok, err := ret_config.validateJWT(ctx, "")
 		if !ok {
 			return nil, err
 		}
 	case MODE_OAUTH2:
 		// on success getOAuth2JWT will set config.jwt to the obtained JWT, so there is no
 		// need to set it once again here
-		_, err := ret_config.getOAuth2JWT()
+		_, err := ret_config.getOAuth2JWT(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -187,11 +231,36 @@ func ControllerConfigure(d *schema.ResourceData) (*ControllerCfg, error) {
 	return ret_config, nil
 }
 
+func retryPolicyFromSchema(d *schema.ResourceData) RetryPolicy {
+	policy := DefaultRetryPolicy()
+
+	if maxAttempts, ok := d.GetOk("retry_max_attempts"); ok {
+		policy.MaxAttempts = maxAttempts.(int)
+	}
+	if minBackoff, ok := d.GetOk("retry_min_backoff"); ok {
+		policy.MinBackoff = time.Duration(minBackoff.(int)) * time.Second
+	}
+	if maxBackoff, ok := d.GetOk("retry_max_backoff"); ok {
+		policy.MaxBackoff = time.Duration(maxBackoff.(int)) * time.Second
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		policy.MaxBackoff = policy.MinBackoff
+	}
+	if codes, ok := d.GetOk("retry_status_codes"); ok {
+		policy.StatusCodes = make([]int, 0, codes.(*schema.Set).Len())
+		for _, code := range codes.(*schema.Set).List() {
+			policy.StatusCodes = append(policy.StatusCodes, code.(int))
+		}
+	}
+
+	return policy
+}
+
 func (config *ControllerCfg) GetDecortUsername() string {
 	return config.decort_username
 }
 
-func (config *ControllerCfg) getOAuth2JWT() (string, error) {
+func (config *ControllerCfg) getOAuth2JWT(ctx context.Context) (string, error) {
 	// 	Obtain JWT from the Oauth2 provider using application ID and application secret provided in config.
 	if config.auth_mode_code == MODE_UNDEF {
 		return "", fmt.Errorf("getOAuth2JWT method called for undefined authorization mode.")
@@ -208,7 +277,7 @@ func (config *ControllerCfg) getOAuth2JWT() (string, error) {
 	params.Add("validity", "3600")
 	params_str := params.Encode()
 
-	req, err := http.NewRequest("POST", config.oauth2_url+"/v1/oauth/access_token", strings.NewReader(params_str))
+	req, err := http.NewRequestWithContext(ctx, "POST", config.oauth2_url+"/v1/oauth/access_token", strings.NewReader(params_str))
 	if err != nil {
 		return "", err
 	}
@@ -224,7 +293,7 @@ func (config *ControllerCfg) getOAuth2JWT() (string, error) {
 		// fmt.Println("response Headers:", resp.Header)
 		// fmt.Println("response Headers:", req.URL)
 		return "", fmt.Errorf("getOauth2JWT: unexpected status code %d when obtaining JWT from %q for APP_ID %q, request Body %q",
-			resp.StatusCode, req.URL, config.app_id, params_str)
+			resp.StatusCode, req.URL, config.app_id, logging.Mask(params_str))
 	}
 	defer resp.Body.Close()
 
@@ -235,11 +304,37 @@ func (config *ControllerCfg) getOAuth2JWT() (string, error) {
 
 	// validation successful - store JWT in the corresponding field of the ControllerCfg structure
 	config.jwt = strings.TrimSpace(string(responseData))
+	config.jwt_expires = jwtExpiry(ctx, config.jwt)
 
 	return config.jwt, nil
 }
 
-func (config *ControllerCfg) validateJWT(jwt string) (bool, error) {
+func jwtExpiry(ctx context.Context, token string) time.Time {
+	// Extract expiration time from "exp" claim of the token. Token is not verified here, as
+	// actual verification is done on the DECORT controller side. Zero time is returned if
+	// the claim is absent or the token cannot be parsed.
+	parser := jwt.Parser{}
+	parsed, _, err := parser.ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		log.Warnf(ctx, "jwtExpiry: failed to parse JWT: %v", err)
+		return time.Time{}
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return time.Time{}
+	}
+	switch exp := claims["exp"].(type) {
+	case float64:
+		return time.Unix(int64(exp), 0)
+	case json.Number:
+		if v, err := exp.Int64(); err == nil {
+			return time.Unix(v, 0)
+		}
+	}
+	return time.Time{}
+}
+
+func (config *ControllerCfg) validateJWT(ctx context.Context, jwt string) (bool, error) {
 	/*
 			Validate JWT against DECORT controller. JWT can be supplied as argument to this method. If empty string supplied as
 			argument, JWT will be taken from config attribute.
@@ -257,7 +352,7 @@ func (config *ControllerCfg) validateJWT(jwt string) (bool, error) {
 		return false, fmt.Errorf("validateJWT method called, but no OAuth2 URL provided.")
 	}
 
-	req, err := http.NewRequest("POST", config.controller_url+"/restmachine/cloudapi/account/list", nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", config.controller_url+"/restmachine/cloudapi/account/list", nil)
 	if err != nil {
 		return false, err
 	}
@@ -279,7 +374,7 @@ func (config *ControllerCfg) validateJWT(jwt string) (bool, error) {
 	return true, nil
 }
 
-func (config *ControllerCfg) validateLegacyUser() (bool, error) {
+func (config *ControllerCfg) validateLegacyUser(ctx context.Context) (bool, error) {
 	/*
 			Validate legacy user by obtaining a session key, which will be used for authenticating subsequent API calls
 		    to DECORT controller.
@@ -298,7 +393,7 @@ func (config *ControllerCfg) validateLegacyUser() (bool, error) {
 	params.Add("password", config.legacy_password)
 	params_str := params.Encode()
 
-	req, err := http.NewRequest("POST", config.controller_url+"/restmachine/cloudapi/user/authenticate", strings.NewReader(params_str))
+	req, err := http.NewRequestWithContext(ctx, "POST", config.controller_url+"/restmachine/cloudapi/user/authenticate", strings.NewReader(params_str))
 	if err != nil {
 		return false, err
 	}
@@ -318,7 +413,7 @@ func (config *ControllerCfg) validateLegacyUser() (bool, error) {
 
 	responseData, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Fatal(err)
+		return false, err
 	}
 
 	// validation successful - keep session ID for future use
@@ -327,6 +422,58 @@ func (config *ControllerCfg) validateLegacyUser() (bool, error) {
 	return true, nil
 }
 
+func (config *ControllerCfg) credential() string {
+	// returns credential which is currently used to authenticate API calls
+	config.auth_mutex.RLock()
+	defer config.auth_mutex.RUnlock()
+
+	if config.auth_mode_code == MODE_LEGACY {
+		return config.legacy_sid
+	}
+	return config.jwt
+}
+
+func (config *ControllerCfg) reauthenticate(ctx context.Context, stale string) error {
+	// Obtain new JWT (oauth2 mode) or session ID (legacy mode) instead of the stale one.
+	// Many resources may call DecortAPICall concurrently, so the first caller renews credential
+	// and the rest of them just pick it up once the lock is released.
+	config.auth_mutex.Lock()
+	defer config.auth_mutex.Unlock()
+
+	switch config.auth_mode_code {
+	case MODE_OAUTH2:
+		if config.jwt != stale {
+			return nil
+		}
+		log.Debugf(ctx, "reauthenticate: obtaining new JWT from %q", config.oauth2_url)
+		_, err := config.getOAuth2JWT(ctx)
+		return err
+	case MODE_LEGACY:
+		if config.legacy_sid != stale {
+			return nil
+		}
+		log.Debugf(ctx, "reauthenticate: obtaining new session ID for legacy user %q", config.legacy_user)
+		_, err := config.validateLegacyUser(ctx)
+		return err
+	default:
+		return fmt.Errorf("reauthenticate: cannot renew credentials in authorization mode %q.", config.auth_mode_txt)
+	}
+}
+
+func (config *ControllerCfg) refreshExpiringJWT(ctx context.Context) error {
+	// proactively renew JWT in oauth2 mode before it expires, so that long running operations
+	// do not fail with 401 in the middle of apply
+	config.auth_mutex.RLock()
+	current, expires := config.jwt, config.jwt_expires
+	config.auth_mutex.RUnlock()
+
+	if expires.IsZero() || time.Until(expires) > jwtRefreshMargin {
+		return nil
+	}
+	log.Debugf(ctx, "refreshExpiringJWT: JWT expires at %s, renewing", expires)
+	return config.reauthenticate(ctx, current)
+}
+
 func (config *ControllerCfg) DecortAPICall(ctx context.Context, method string, api_name string, url_values *url.Values) (json_resp string, err error) { //nolint:unparam
 	// This is a convenience wrapper around standard HTTP request methods that is aware of the
 	// authorization mode for which the provider was initialized and compiles request accordingly.
@@ -336,6 +483,35 @@ func (config *ControllerCfg) DecortAPICall(ctx context.Context, method string, a
 		return "", fmt.Errorf("decortAPICall method called with unconfigured DECORT cloud controller HTTP client.")
 	}
 
+	if config.auth_mode_code == MODE_UNDEF {
+		return "", fmt.Errorf("decortAPICall method called for unknown authorization mode.")
+	}
+
+	if config.auth_mode_code == MODE_OAUTH2 {
+		if err := config.refreshExpiringJWT(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	credential := config.credential()
+	body, err := config.doAPICall(ctx, method, api_name, url_values, credential)
+	if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusUnauthorized &&
+		(config.auth_mode_code == MODE_OAUTH2 || config.auth_mode_code == MODE_LEGACY) {
+		// credential may have expired or have been revoked - log in once again and repeat the call
+		log.Warnf(ctx, "decortAPICall: got 401 when calling API %q, trying to re-authenticate", api_name)
+		if err := config.reauthenticate(ctx, credential); err != nil {
+			return "", err
+		}
+		body, err = config.doAPICall(ctx, method, api_name, url_values, config.credential())
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return body, nil
+}
+
+func (config *ControllerCfg) doAPICall(ctx context.Context, method string, api_name string, url_values *url.Values, credential string) (string, error) {
 	// Example: to create api_params, one would generally do the following:
 	//
 	// data := []byte(`{"machineId": "2638"}`)
@@ -350,58 +526,56 @@ func (config *ControllerCfg) DecortAPICall(ctx context.Context, method string, a
 	// req, _ := http.NewRequest(method, url, strings.NewReader(params.Encode()))
 	//
 
-	if config.auth_mode_code == MODE_UNDEF {
-		return "", fmt.Errorf("decortAPICall method called for unknown authorization mode.")
+	// copy values, so that the caller's ones are not polluted with authkey, which
+	// may change between the calls
+	values := url.Values{}
+	for k, v := range *url_values {
+		values[k] = v
 	}
-
 	if config.auth_mode_code == MODE_LEGACY {
-		url_values.Add("authkey", config.legacy_sid)
+		values.Set("authkey", credential)
 	}
-	params_str := url_values.Encode()
+	params_str := values.Encode()
 
-	req, err := http.NewRequest(method, config.controller_url+api_name, strings.NewReader(params_str))
+	req, err := http.NewRequestWithContext(ctx, method, config.controller_url+api_name, strings.NewReader(params_str))
 	if err != nil {
 		return "", err
 	}
 
-	req = req.WithContext(ctx)
-
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Content-Length", strconv.Itoa(len(params_str)))
 	req.Header.Set("Accept", "application/json")
 
 	if config.auth_mode_code == MODE_OAUTH2 || config.auth_mode_code == MODE_JWT {
-		req.Header.Set("Authorization", fmt.Sprintf("bearer %s", config.jwt))
+		req.Header.Set("Authorization", fmt.Sprintf("bearer %s", credential))
 	}
 
-	var resp *http.Response
-	var body []byte
-	for i := 0; i < 5; i++ {
-		resp, err = config.cc_client.Do(req)
-		if err != nil {
-			return "", err
-		}
+	// transient errors are retried by the underlying RetryTransport according
+	// to the retry policy configured for the provider
+	resp, err := config.cc_client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
 
-		body, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return "", err
-		}
-		resp.Body.Close()
-		log.Debugf("decortAPICall: %s %s\n %s", method, api_name, body)
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	// bodies may contain credentials and other secrets, so they are masked and logged
+	// at TRACE level only
+	log.Debugf(ctx, "decortAPICall: %s %s -> %d", method, api_name, resp.StatusCode)
+	log.Tracef(ctx, "decortAPICall: %s %s request:\n%s\nresponse:\n%s",
+		method, api_name, logging.Mask(params_str), logging.Mask(string(body)))
 
-		if resp.StatusCode == http.StatusOK {
-			return string(body), nil
-		} else {
-			if resp.StatusCode == http.StatusInternalServerError {
-				log.Warnf("got 500, retrying %d/5", i+1)
-				time.Sleep(time.Second * 5)
-				continue
-			}
-			return "", fmt.Errorf("decortAPICall: unexpected status code %d when calling API %q with request Body %q. Respone:\n%s",
-				resp.StatusCode, req.URL, params_str, body)
+	if resp.StatusCode != http.StatusOK {
+		return "", &APIError{
+			StatusCode: resp.StatusCode,
+			URL:        req.URL.String(),
+			Params:     logging.Mask(params_str),
+			Body:       logging.Mask(string(body)),
 		}
 	}
 
-	return "", fmt.Errorf("decortAPICall: unexpected status code %d when calling API %q with request Body %q. Respone:\n%s",
-		resp.StatusCode, req.URL, params_str, body)
+	return string(body), nil
 }
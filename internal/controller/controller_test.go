@@ -0,0 +1,236 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// authServer is a DECORT controller and OAuth2 provider, which accepts only the credential it issued last
+type authServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	current string
+	expires time.Time
+	logins  int32
+	calls   int32
+}
+
+func newAuthServer(t *testing.T, validity time.Duration) *authServer {
+	s := &authServer{expires: time.Now().Add(validity)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&s.logins, 1)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"username": "user",
+			"iss":      "test",
+			"exp":      s.expires.Unix(),
+			"n":        n,
+		}).SignedString([]byte("secret"))
+		if err != nil {
+			t.Error(err)
+		}
+		s.issue(token)
+		fmt.Fprint(w, token)
+	})
+	mux.HandleFunc("/restmachine/cloudapi/user/authenticate", func(w http.ResponseWriter, r *http.Request) {
+		sid := fmt.Sprintf("sid-%d", atomic.AddInt32(&s.logins, 1))
+		s.issue(sid)
+		fmt.Fprint(w, sid)
+	})
+	mux.HandleFunc("/restmachine/cloudapi/compute/get", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.calls, 1)
+		credential := r.Header.Get("Authorization")
+		if credential == "" {
+			_ = r.ParseForm()
+			credential = r.PostForm.Get("authkey")
+		} else {
+			credential = credential[len("bearer "):]
+		}
+		if !s.valid(credential) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "{}")
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *authServer) issue(credential string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = credential
+}
+
+func (s *authServer) valid(credential string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return credential != "" && credential == s.current
+}
+
+func (s *authServer) config(mode int) *ControllerCfg {
+	config := &ControllerCfg{
+		controller_url: s.URL,
+		auth_mode_code: mode,
+		oauth2_url:     s.URL,
+		app_id:         "app",
+		app_secret:     "secret",
+		legacy_user:    "user",
+		cc_client:      s.Client(),
+	}
+	switch mode {
+	case MODE_OAUTH2:
+		config.auth_mode_txt = "oauth2"
+		config.jwt = "stale"
+	case MODE_LEGACY:
+		config.auth_mode_txt = "legacy"
+		config.legacy_sid = "stale"
+	}
+	return config
+}
+
+func TestDecortAPICallReauthenticates(t *testing.T) {
+	for _, mode := range []int{MODE_OAUTH2, MODE_LEGACY} {
+		s := newAuthServer(t, time.Hour)
+		config := s.config(mode)
+
+		if _, err := config.DecortAPICall(context.Background(), "POST", "/restmachine/cloudapi/compute/get", &url.Values{}); err != nil {
+			t.Fatalf("mode %s: unexpected error: %v", config.auth_mode_txt, err)
+		}
+		if atomic.LoadInt32(&s.logins) != 1 || atomic.LoadInt32(&s.calls) != 2 {
+			t.Errorf("mode %s: got %d logins and %d calls, want 1 login and 2 calls", config.auth_mode_txt, atomic.LoadInt32(&s.logins), atomic.LoadInt32(&s.calls))
+		}
+		if config.credential() == "stale" {
+			t.Errorf("mode %s: credential is not renewed", config.auth_mode_txt)
+		}
+	}
+}
+
+func TestDecortAPICallReauthenticatesOnce(t *testing.T) {
+	s := newAuthServer(t, time.Hour)
+	config := s.config(MODE_OAUTH2)
+
+	// the renewed token is rejected as well, so that the call fails rather than loops
+	s.Config.Handler.(*http.ServeMux).HandleFunc("/restmachine/cloudapi/compute/stop", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	_, err := config.DecortAPICall(context.Background(), "POST", "/restmachine/cloudapi/compute/stop", &url.Values{})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want APIError with status code 401", err)
+	}
+	if atomic.LoadInt32(&s.logins) != 1 || atomic.LoadInt32(&s.calls) != 2 {
+		t.Errorf("got %d logins and %d calls, want 1 login and 2 calls", atomic.LoadInt32(&s.logins), atomic.LoadInt32(&s.calls))
+	}
+}
+
+func TestDecortAPICallConcurrentReauthentication(t *testing.T) {
+	const callers = 20
+
+	tests := []struct {
+		name    string
+		prepare func(s *authServer, config *ControllerCfg)
+	}{
+		{
+			// all callers get 401 with the same stale token
+			name:    "unauthorized",
+			prepare: func(s *authServer, config *ControllerCfg) {},
+		},
+		{
+			// valid token is about to expire, so that all callers try to refresh it before the call
+			name: "expiring",
+			prepare: func(s *authServer, config *ControllerCfg) {
+				config.jwt = "expiring"
+				config.jwt_expires = time.Now().Add(time.Minute)
+				s.issue("expiring")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAuthServer(t, time.Hour)
+			config := s.config(MODE_OAUTH2)
+			tt.prepare(s, config)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, callers)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := config.DecortAPICall(context.Background(), "POST", "/restmachine/cloudapi/compute/get", &url.Values{})
+					errs <- err
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+			if got := atomic.LoadInt32(&s.logins); got != 1 {
+				t.Errorf("got %d logins, want 1", got)
+			}
+		})
+	}
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	withExp, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": exp.Unix()}).SignedString([]byte("secret"))
+	withoutExp, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user": "test"}).SignedString([]byte("secret"))
+
+	tests := []struct {
+		name  string
+		token string
+		want  time.Time
+	}{
+		{"exp claim", withExp, exp},
+		{"no exp claim", withoutExp, time.Time{}},
+		{"malformed", "not a token", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		if got := jwtExpiry(context.Background(), tt.token); !got.Equal(tt.want) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
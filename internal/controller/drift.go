@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// DriftPolicy defines how Read handlers react to objects, which were deleted or destroyed
+// outside of Terraform
+type DriftPolicy string
+
+const (
+	// DriftRecreate restores DELETED objects and recreates DESTROYED ones during refresh
+	DriftRecreate DriftPolicy = "recreate"
+	// DriftRestore restores DELETED objects during refresh, DESTROYED ones are removed
+	// from the state and created by the next apply
+	DriftRestore DriftPolicy = "restore"
+	// DriftReport never calls mutating APIs during refresh: DELETED and DESTROYED objects
+	// are removed from the state with a warning
+	DriftReport DriftPolicy = "report"
+)
+
+// DefaultDriftPolicy is used when drift_policy provider attribute is not set
+const DefaultDriftPolicy = DriftRecreate
+
+// DriftPolicies lists valid values of drift_policy provider attribute
+var DriftPolicies = []string{
+	string(DriftRecreate),
+	string(DriftRestore),
+	string(DriftReport),
+}
+
+// DriftPolicy returns drift policy configured for the provider
+func (config *ControllerCfg) DriftPolicy() DriftPolicy {
+	return config.drift_policy
+}
+
+// DriftPolicyOf returns drift policy of the provider meta. API callers, which are not
+// configured from the provider schema (e.g. fake controller client), use DefaultDriftPolicy.
+func DriftPolicyOf(m interface{}) DriftPolicy {
+	if holder, ok := m.(interface{ DriftPolicy() DriftPolicy }); ok && holder.DriftPolicy() != "" {
+		return holder.DriftPolicy()
+	}
+	return DefaultDriftPolicy
+}
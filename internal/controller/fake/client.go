@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+)
+
+// Client is a minimal controller.APICaller talking to the fake controller. It may be passed
+// as provider meta to CRUD handlers of resources and data sources.
+type Client struct {
+	server *Server
+	client *http.Client
+	caches controller.Caches
+}
+
+var _ controller.APICaller = (*Client)(nil)
+
+// Client returns API caller bound to this fake controller
+func (s *Server) Client() *Client {
+	return &Client{
+		server: s,
+		client: s.Server.Client(),
+	}
+}
+
+func (c *Client) DecortAPICall(ctx context.Context, method string, api_name string, url_values *url.Values) (string, error) {
+	params_str := url_values.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server.URL+api_name, strings.NewReader(params_str))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Content-Length", strconv.Itoa(len(params_str)))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &controller.APIError{
+			StatusCode: resp.StatusCode,
+			URL:        req.URL.String(),
+			Params:     params_str,
+			Body:       string(body),
+		}
+	}
+
+	return string(body), nil
+}
+
+// Caches returns caches shared by resources using this client, as ControllerCfg does
+func (c *Client) Caches() *controller.Caches {
+	return &c.caches
+}
+
+func (c *Client) GetDecortUsername() string {
+	return fmt.Sprintf("fake@%s", c.server.URL)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/url"
+	"strconv"
+)
+
+const (
+	cloudapi    = "/restmachine/cloudapi"
+	cloudbroker = "/restmachine/cloudbroker"
+)
+
+func registerCommonHandlers(s *Server) {
+	s.Handle(cloudapi+"/user/authenticate", func(s *Server, form url.Values) (interface{}, error) {
+		return Raw("fake-session-id"), nil
+	})
+
+	s.Handle(cloudapi+"/locations/list", func(s *Server, form url.Values) (interface{}, error) {
+		return []Object{{
+			"gid":          DefaultGridID,
+			"id":           DefaultGridID,
+			"locationCode": "fake",
+			"name":         "fake-location",
+			"flag":         "",
+		}}, nil
+	})
+
+	s.Handle(cloudapi+"/account/list", listObjects(KindAccount))
+	s.Handle(cloudapi+"/account/get", getObject(KindAccount, "accountId"))
+
+	s.Handle(cloudapi+"/image/list", listObjects(KindImage))
+	s.Handle(cloudapi+"/image/get", getObject(KindImage, "imageId"))
+
+	s.Handle(cloudapi+"/extnet/list", listObjects(KindExtNet))
+	s.Handle(cloudapi+"/extnet/get", getObject(KindExtNet, "net_id"))
+
+	s.Handle(cloudapi+"/k8ci/list", listObjects(KindK8CI))
+
+	// all asynchronous tasks of the fake controller complete immediately
+	s.Handle(cloudapi+"/tasks/get", func(s *Server, form url.Values) (interface{}, error) {
+		id, err := strconv.Atoi(form.Get("auditId"))
+		if err != nil {
+			return nil, errBadRequest("invalid auditId %q", form.Get("auditId"))
+		}
+		task, ok := s.Get(KindTask, id)
+		if !ok {
+			return nil, errNotFound(KindTask, id)
+		}
+		return task, nil
+	})
+}
+
+// newTask registers completed asynchronous task and returns its audit ID as the
+// corresponding async API does
+func (s *Server) newTask(result int) string {
+	id := s.NewID()
+	auditID := strconv.Itoa(id)
+	s.Put(KindTask, id, Object{
+		"auditId":   auditID,
+		"completed": true,
+		"error":     "",
+		"log":       []string{},
+		"result":    []interface{}{result},
+		"stage":     "Done",
+		"status":    "OK",
+	})
+	return auditID
+}
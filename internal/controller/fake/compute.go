@@ -0,0 +1,819 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/url"
+)
+
+func registerComputeHandlers(s *Server) {
+	s.Handle(cloudapi+"/kvmx86/create", computeCreate("KVM_X86"))
+	s.Handle(cloudapi+"/kvmppc/create", computeCreate("KVM_PPC"))
+	s.Handle(cloudapi+"/compute/get", computeGet)
+	s.Handle(cloudapi+"/compute/list", func(s *Server, form url.Values) (interface{}, error) {
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindCompute) {
+			if isDeleted(obj) && !formBool(form, "includedeleted") {
+				continue
+			}
+			res = append(res, s.computeView(obj))
+		}
+		return res, nil
+	})
+	s.Handle(cloudapi+"/rg/listComputes", func(s *Server, form url.Values) (interface{}, error) {
+		rgID, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindCompute) {
+			if obj["rgId"] == rgID && !isDeleted(obj) {
+				res = append(res, s.computeView(obj))
+			}
+		}
+		return res, nil
+	})
+	s.Handle(cloudapi+"/compute/clone", computeClone)
+	s.Handle(cloudapi+"/compute/delete", computeDelete)
+	s.Handle(cloudapi+"/compute/restore", setStatus(KindCompute, "computeId", "ENABLED"))
+	s.Handle(cloudapi+"/compute/enable", setStatus(KindCompute, "computeId", "ENABLED"))
+	s.Handle(cloudapi+"/compute/disable", setStatus(KindCompute, "computeId", "DISABLED"))
+	s.Handle(cloudapi+"/compute/start", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["techStatus"] = "STARTED"
+		// compute booted from alternative image gets it inserted as CD-ROM
+		if altBootId := formInt(form, "altBootId"); altBootId != 0 {
+			obj["cdImageId"] = altBootId
+		}
+	}))
+	s.Handle(cloudapi+"/compute/stop", setTechStatus("STOPPED"))
+	s.Handle(cloudapi+"/compute/pause", setTechStatus("PAUSED"))
+	s.Handle(cloudapi+"/compute/resume", setTechStatus("STARTED"))
+	s.Handle(cloudapi+"/compute/reset", setTechStatus("STARTED"))
+	s.Handle(cloudapi+"/compute/resize", computeUpdateFields(func(obj Object, form url.Values) {
+		if cpu := formInt(form, "cpu"); cpu > 0 {
+			obj["cpus"] = cpu
+		}
+		if ram := formInt(form, "ram"); ram > 0 {
+			obj["ram"] = ram
+		}
+	}))
+	s.Handle(cloudapi+"/compute/update", computeUpdateFields(func(obj Object, form url.Values) {
+		if name := form.Get("name"); name != "" {
+			obj["name"] = name
+		}
+		if _, ok := form["desc"]; ok {
+			obj["desc"] = form.Get("desc")
+		}
+	}))
+	s.Handle(cloudapi+"/compute/redeploy", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["imageId"] = formInt(form, "imageId")
+	}))
+	s.Handle(cloudapi+"/compute/affinityLabelSet", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["affinityLabel"] = form.Get("affinityLabel")
+	}))
+	s.Handle(cloudapi+"/compute/affinityLabelRemove", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["affinityLabel"] = ""
+	}))
+	s.Handle(cloudapi+"/compute/tagAdd", computeUpdateFields(func(obj Object, form url.Values) {
+		tags, _ := obj["tags"].(map[string]string)
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[form.Get("key")] = form.Get("value")
+		obj["tags"] = tags
+	}))
+	s.Handle(cloudapi+"/compute/tagRemove", computeUpdateFields(func(obj Object, form url.Values) {
+		if tags, ok := obj["tags"].(map[string]string); ok {
+			delete(tags, form.Get("key"))
+		}
+	}))
+	s.Handle(cloudapi+"/compute/pinToStack", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["pinned"] = true
+	}))
+	s.Handle(cloudapi+"/compute/unpinFromStack", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["pinned"] = false
+	}))
+	s.Handle(cloudapi+"/compute/bootOrderSet", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["bootOrder"] = form["order"]
+	}))
+	s.Handle(cloudapi+"/compute/cdInsert", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["cdImageId"] = formInt(form, "cdromId")
+	}))
+	s.Handle(cloudapi+"/compute/cdEject", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["cdImageId"] = 0
+	}))
+	s.Handle(cloudapi+"/compute/moveToRg", computeMoveToRG)
+	s.Handle(cloudapi+"/compute/netAttach", computeNetAttach)
+	s.Handle(cloudapi+"/compute/netDetach", computeNetDetach)
+	s.Handle(cloudapi+"/compute/changeIp", computeChangeIP)
+	s.Handle(cloudapi+"/compute/diskAdd", computeDiskAdd)
+	s.Handle(cloudapi+"/compute/diskDel", computeDiskDel)
+	s.Handle(cloudapi+"/compute/diskAttach", computeDiskAttach)
+	s.Handle(cloudapi+"/compute/diskDetach", computeDiskDetach)
+	s.Handle(cloudapi+"/compute/pfwList", computePfwList)
+	s.Handle(cloudapi+"/compute/userList", func(s *Server, form url.Values) (interface{}, error) {
+		return Object{"accountAcl": []Object{}, "computeAcl": []Object{}, "rgAcl": []Object{}}, nil
+	})
+	s.Handle(cloudapi+"/compute/affinityRuleAdd", computeRuleAdd("affinityRules"))
+	s.Handle(cloudapi+"/compute/affinityRuleRemove", computeRuleRemove("affinityRules"))
+	s.Handle(cloudapi+"/compute/affinityRulesClear", computeUpdateFields(func(obj Object, form url.Values) {
+		delete(obj, "affinityRules")
+	}))
+	s.Handle(cloudapi+"/compute/antiAffinityRuleAdd", computeRuleAdd("antiAffinityRules"))
+	s.Handle(cloudapi+"/compute/antiAffinityRuleRemove", computeRuleRemove("antiAffinityRules"))
+	s.Handle(cloudapi+"/compute/antiAffinityRulesClear", computeUpdateFields(func(obj Object, form url.Values) {
+		delete(obj, "antiAffinityRules")
+	}))
+	s.Handle(cloudapi+"/compute/pfwAdd", computePfwAdd)
+	s.Handle(cloudapi+"/compute/pfwDel", computePfwDel)
+	s.Handle(cloudapi+"/compute/userGrant", computeUpdateFields(func(obj Object, form url.Values) {
+		acl := computeACL(obj, form.Get("userName"))
+		obj["ACL"] = Object{"computeAcl": append(acl, Object{
+			"explicit":    true,
+			"right":       form.Get("accesstype"),
+			"status":      "CONFIRMED",
+			"type":        "U",
+			"userGroupId": form.Get("userName"),
+		})}
+	}))
+	s.Handle(cloudapi+"/compute/userRevoke", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["ACL"] = Object{"computeAcl": computeACL(obj, form.Get("userName"))}
+	}))
+	s.Handle(cloudapi+"/compute/snapshotCreate", computeSnapshotCreate)
+	s.Handle(cloudapi+"/compute/snapshotDelete", computeUpdateFields(func(obj Object, form url.Values) {
+		snapSets, _ := obj["snapSets"].([]Object)
+		kept := make([]Object, 0, len(snapSets))
+		for _, snapSet := range snapSets {
+			if snapSet["label"] != form.Get("label") {
+				kept = append(kept, snapSet)
+			}
+		}
+		obj["snapSets"] = kept
+	}))
+	s.Handle(cloudapi+"/compute/snapshotRollback", computeNoop)
+}
+
+// registerCloudbrokerComputeHandlers mirrors cloudapi handlers, as cloudbroker compute API
+// accepts the same parameters, and adds admin-only calls. It must be called after all
+// cloudapi handlers are registered.
+func registerCloudbrokerComputeHandlers(s *Server) {
+	for _, api := range []string{"/kvmx86/create", "/kvmppc/create", "/rg/listComputes", "/disks/resize2"} {
+		s.Handle(cloudbroker+api, s.handler(cloudapi+api))
+	}
+	for path, h := range s.handlersWithPrefix(cloudapi + "/compute/") {
+		s.Handle(cloudbroker+path[len(cloudapi):], h)
+	}
+	s.Handle(cloudbroker+"/compute/migrate", computeUpdateFields(func(obj Object, form url.Values) {
+		obj["stackId"] = formInt(form, "targetStackId")
+	}))
+}
+
+// computeNoop accepts calls, which change compute settings not reported by compute/get API
+func computeNoop(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.Get(KindCompute, id); !ok {
+		return nil, errNotFound(KindCompute, id)
+	}
+	return true, nil
+}
+
+// computeSnapshotCreate records snapshot in snapSets of compute and responds with GUID of
+// the snapshot like the platform does
+func computeSnapshotCreate(s *Server, form url.Values) (interface{}, error) {
+	guid := fmt.Sprintf("00000000-0000-0000-0000-%012d", s.NewID())
+	_, err := computeUpdateFields(func(obj Object, form url.Values) {
+		snapSets, _ := obj["snapSets"].([]Object)
+		obj["snapSets"] = append(snapSets, Object{"guid": guid, "label": form.Get("label"), "disks": []int{}})
+	})(s, form)
+	if err != nil {
+		return nil, err
+	}
+	return guid, nil
+}
+
+// computeRuleAdd appends affinity or anti-affinity rule to the list under key
+func computeRuleAdd(key string) HandlerFunc {
+	return computeUpdateFields(func(obj Object, form url.Values) {
+		rules, _ := obj[key].([]Object)
+		obj[key] = append(rules, Object{
+			"topology": form.Get("topology"),
+			"policy":   form.Get("policy"),
+			"mode":     form.Get("mode"),
+			"key":      form.Get("key"),
+			"value":    form.Get("value"),
+		})
+	})
+}
+
+// computeRuleRemove removes affinity or anti-affinity rule matching all its fields from the list under key
+func computeRuleRemove(key string) HandlerFunc {
+	return computeUpdateFields(func(obj Object, form url.Values) {
+		rules, _ := obj[key].([]Object)
+		kept := make([]Object, 0, len(rules))
+		for _, rule := range rules {
+			match := true
+			for _, field := range []string{"topology", "policy", "mode", "key", "value"} {
+				if rule[field] != form.Get(field) {
+					match = false
+				}
+			}
+			if !match {
+				kept = append(kept, rule)
+			}
+		}
+		obj[key] = kept
+	})
+}
+
+// computeACL returns compute ACL entries of other users than userName
+func computeACL(obj Object, userName string) []Object {
+	acl, _ := obj["ACL"].(Object)
+	entries, _ := acl["computeAcl"].([]Object)
+	kept := make([]Object, 0, len(entries))
+	for _, entry := range entries {
+		if entry["userGroupId"] != userName {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+func computeCreate(driver string) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		rgID, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		rg, ok := s.Get(KindRG, rgID)
+		if !ok || isDeleted(rg) {
+			return nil, errNotFound(KindRG, rgID)
+		}
+		imageID, err := requireInt(form, "imageId")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.Get(KindImage, imageID); !ok {
+			return nil, errNotFound(KindImage, imageID)
+		}
+
+		id := s.NewID()
+		techStatus := "STOPPED"
+		if start := form.Get("start"); start != "0" && start != "false" {
+			techStatus = "STARTED"
+		}
+		compute := Object{
+			"id":         id,
+			"name":       form.Get("name"),
+			"rgId":       rgID,
+			"rgName":     rg["name"],
+			"accountId":  rg["accountId"],
+			"gid":        DefaultGridID,
+			"cpus":       formInt(form, "cpu"),
+			"ram":        formInt(form, "ram"),
+			"imageId":    imageID,
+			"driver":     driver,
+			"arch":       driver[len("KVM_"):],
+			"desc":       form.Get("desc"),
+			"status":     "ENABLED",
+			"techStatus": techStatus,
+			"bootOrder":  []string{"hd", "cdrom"},
+			"interfaces": []Object{},
+			"tags":       map[string]string{},
+			"userdata":   Object{},
+			"stackId":    1,
+		}
+		s.Put(KindCompute, id, compute)
+
+		bootID := s.NewID()
+		s.Put(KindDisk, bootID, Object{
+			"id":        bootID,
+			"name":      "bootdisk",
+			"type":      "B",
+			"sizeMax":   formInt(form, "bootDisk"),
+			"sepId":     formInt(form, "sepId"),
+			"pool":      form.Get("pool"),
+			"accountId": rg["accountId"],
+			"gid":       DefaultGridID,
+			"imageId":   imageID,
+			"status":    "ASSIGNED",
+			"computeId": id,
+		})
+
+		if netType := form.Get("netType"); netType != "" && netType != "NONE" {
+			attach := url.Values{}
+			attach.Set("computeId", fmt.Sprint(id))
+			attach.Set("netType", netType)
+			attach.Set("netId", form.Get("netId"))
+			attach.Set("ipAddr", form.Get("ipAddr"))
+			if _, err := computeNetAttach(s, attach); err != nil {
+				return nil, err
+			}
+		}
+
+		return id, nil
+	}
+}
+
+// computeView returns compute as it is reported by compute/get API, i.e. with attached disks
+// and vGPUs
+func (s *Server) computeView(obj Object) Object {
+	view := Object{}
+	for k, v := range obj {
+		view[k] = v
+	}
+	disks := make([]Object, 0)
+	for _, disk := range s.List(KindDisk) {
+		if disk["computeId"] == obj["id"] {
+			disks = append(disks, disk)
+			if disk["type"] == "B" {
+				view["bootdiskSize"] = disk["sizeMax"]
+			}
+		}
+	}
+	view["disks"] = disks
+	vgpus := make([]int, 0)
+	for _, vgpu := range s.List(KindVGPU) {
+		if vgpu["vmid"] == obj["id"] {
+			vgpus = append(vgpus, vgpu["id"].(int))
+		}
+	}
+	view["vgpus"] = vgpus
+	return view
+}
+
+func computeGet(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := s.Get(KindCompute, id)
+	if !ok {
+		return nil, errNotFound(KindCompute, id)
+	}
+	return s.computeView(obj), nil
+}
+
+// computeClone copies the source compute with its boot disk into a new stopped compute
+// without network interfaces
+func computeClone(s *Server, form url.Values) (interface{}, error) {
+	srcID, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	src, ok := s.Get(KindCompute, srcID)
+	if !ok || isDeleted(src) {
+		return nil, errNotFound(KindCompute, srcID)
+	}
+	if form.Get("name") == "" {
+		return nil, errBadRequest("parameter %q is required", "name")
+	}
+	if src["techStatus"] == "STARTED" && !formBool(form, "force") {
+		return nil, errBadRequest("compute %d is running, use force to clone it", srcID)
+	}
+
+	id := s.NewID()
+	clone := Object{}
+	for k, v := range src {
+		clone[k] = v
+	}
+	clone["id"] = id
+	clone["name"] = form.Get("name")
+	clone["status"] = "ENABLED"
+	clone["techStatus"] = "STOPPED"
+	clone["interfaces"] = []Object{}
+	clone["cloneReference"] = srcID
+	clone["clones"] = []int{}
+	s.Put(KindCompute, id, clone)
+
+	for _, disk := range s.List(KindDisk) {
+		if disk["computeId"] != srcID || disk["type"] != "B" {
+			continue
+		}
+		diskID := s.NewID()
+		copied := Object{}
+		for k, v := range disk {
+			copied[k] = v
+		}
+		copied["id"] = diskID
+		copied["computeId"] = id
+		s.Put(KindDisk, diskID, copied)
+	}
+
+	_ = s.Update(KindCompute, srcID, func(obj Object) {
+		clones, _ := obj["clones"].([]int)
+		obj["clones"] = append(clones, id)
+	})
+	return id, nil
+}
+
+func computeDelete(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.Get(KindCompute, id); !ok {
+		return nil, errNotFound(KindCompute, id)
+	}
+	detach := formBool(form, "detachDisks")
+	for _, disk := range s.List(KindDisk) {
+		if disk["computeId"] != id {
+			continue
+		}
+		diskID := disk["id"].(int)
+		if disk["type"] == "B" || !detach {
+			s.Delete(KindDisk, diskID)
+			continue
+		}
+		_ = s.Update(KindDisk, diskID, func(obj Object) { delete(obj, "computeId") })
+	}
+	if formBool(form, "permanently") {
+		s.Delete(KindCompute, id)
+		return true, nil
+	}
+	return setStatus(KindCompute, "computeId", "DELETED")(s, form)
+}
+
+func setTechStatus(techStatus string) HandlerFunc {
+	return computeUpdateFields(func(obj Object, form url.Values) {
+		obj["techStatus"] = techStatus
+	})
+}
+
+func computeUpdateFields(fn func(obj Object, form url.Values)) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "computeId")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Update(KindCompute, id, func(obj Object) { fn(obj, form) }); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+}
+
+// computeMoveToRG moves compute to another resource group. Running compute is stopped for the
+// move and started again only if autostart is set.
+func computeMoveToRG(s *Server, form url.Values) (interface{}, error) {
+	rgID, err := requireInt(form, "rgId")
+	if err != nil {
+		return nil, err
+	}
+	rg, ok := s.Get(KindRG, rgID)
+	if !ok || isDeleted(rg) {
+		return nil, errNotFound(KindRG, rgID)
+	}
+	return computeUpdateFields(func(obj Object, form url.Values) {
+		obj["rgId"] = rgID
+		obj["rgName"] = rg["name"]
+		if name := form.Get("name"); name != "" {
+			obj["name"] = name
+		}
+		obj["techStatus"] = "STOPPED"
+		if formBool(form, "autostart") {
+			obj["techStatus"] = "STARTED"
+		}
+	})(s, form)
+}
+
+func computeNetAttach(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	netID, err := requireInt(form, "netId")
+	if err != nil {
+		return nil, err
+	}
+	netType := form.Get("netType")
+	switch netType {
+	case "VINS":
+		if _, ok := s.Get(KindVins, netID); !ok {
+			return nil, errNotFound(KindVins, netID)
+		}
+	case "EXTNET":
+		if _, ok := s.Get(KindExtNet, netID); !ok {
+			return nil, errNotFound(KindExtNet, netID)
+		}
+	default:
+		return nil, errBadRequest("unsupported netType %q", netType)
+	}
+
+	// MAC is derived from unique ID, so that it is never reused after detach
+	macID := s.NewID()
+	var iface Object
+	err = s.Update(KindCompute, id, func(obj Object) {
+		ifaces, _ := obj["interfaces"].([]Object)
+		n := len(ifaces) + 1
+		ipAddr := form.Get("ipAddr")
+		if ipAddr == "" {
+			ipAddr = fmt.Sprintf("10.%d.%d.%d", netID%256, id%256, n)
+		}
+		iface = Object{
+			"netId":     netID,
+			"netType":   netType,
+			"ipAddress": ipAddr,
+			"mac":       fmt.Sprintf("52:54:00:%02x:%02x:%02x", (macID>>16)%256, (macID>>8)%256, macID%256),
+			"name":      fmt.Sprintf("eth%d", n-1),
+			"pciSlot":   n + 2,
+			"type":      "bridge",
+		}
+		obj["interfaces"] = append(ifaces, iface)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return iface, nil
+}
+
+func computeNetDetach(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	err = s.Update(KindCompute, id, func(obj Object) {
+		ifaces, _ := obj["interfaces"].([]Object)
+		rest := make([]Object, 0, len(ifaces))
+		for _, iface := range ifaces {
+			if (form.Get("mac") != "" && iface["mac"] == form.Get("mac")) ||
+				(form.Get("mac") == "" && iface["ipAddress"] == form.Get("ipAddr")) {
+				found = true
+				continue
+			}
+			rest = append(rest, iface)
+		}
+		obj["interfaces"] = rest
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errBadRequest("compute %d has no interface with MAC %q / IP %q", id, form.Get("mac"), form.Get("ipAddr"))
+	}
+	return true, nil
+}
+
+func computeDiskAdd(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	compute, ok := s.Get(KindCompute, id)
+	if !ok {
+		return nil, errNotFound(KindCompute, id)
+	}
+	diskType := form.Get("diskType")
+	if diskType == "" {
+		diskType = "D"
+	}
+	diskID := s.NewID()
+	s.Put(KindDisk, diskID, Object{
+		"id":        diskID,
+		"name":      form.Get("diskName"),
+		"type":      diskType,
+		"sizeMax":   formInt(form, "size"),
+		"sepId":     formInt(form, "sepId"),
+		"pool":      form.Get("pool"),
+		"desc":      form.Get("desc"),
+		"imageId":   formInt(form, "imageId"),
+		"accountId": compute["accountId"],
+		"gid":       DefaultGridID,
+		"status":    "ASSIGNED",
+		"computeId": id,
+	})
+	return diskID, nil
+}
+
+func computeDiskDel(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	diskID, err := requireInt(form, "diskId")
+	if err != nil {
+		return nil, err
+	}
+	disk, ok := s.Get(KindDisk, diskID)
+	if !ok || disk["computeId"] != id {
+		return nil, errNotFound(KindDisk, diskID)
+	}
+	if formBool(form, "permanently") {
+		s.Delete(KindDisk, diskID)
+		return true, nil
+	}
+	_ = s.Update(KindDisk, diskID, func(obj Object) {
+		delete(obj, "computeId")
+		obj["status"] = "DELETED"
+	})
+	return true, nil
+}
+
+func computeDiskAttach(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.Get(KindCompute, id); !ok {
+		return nil, errNotFound(KindCompute, id)
+	}
+	diskID, err := requireInt(form, "diskId")
+	if err != nil {
+		return nil, err
+	}
+	disk, ok := s.Get(KindDisk, diskID)
+	if !ok {
+		return nil, errNotFound(KindDisk, diskID)
+	}
+	if owner, attached := disk["computeId"]; attached && owner != id {
+		return nil, errBadRequest("disk %d is already attached to compute %v", diskID, owner)
+	}
+	// data disks occupy PCI slots after the boot disk in order of attachment
+	pciSlot := 6
+	for _, other := range s.List(KindDisk) {
+		if other["computeId"] == id {
+			pciSlot++
+		}
+	}
+	_ = s.Update(KindDisk, diskID, func(obj Object) {
+		obj["computeId"] = id
+		obj["status"] = "ASSIGNED"
+		obj["pciSlot"] = pciSlot
+	})
+	return true, nil
+}
+
+func computeDiskDetach(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	diskID, err := requireInt(form, "diskId")
+	if err != nil {
+		return nil, err
+	}
+	disk, ok := s.Get(KindDisk, diskID)
+	if !ok || disk["computeId"] != id {
+		return nil, errBadRequest("disk %d is not attached to compute %d", diskID, id)
+	}
+	_ = s.Update(KindDisk, diskID, func(obj Object) {
+		delete(obj, "computeId")
+		obj["status"] = "CREATED"
+	})
+	return true, nil
+}
+
+func computeChangeIP(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	netID, err := requireInt(form, "netId")
+	if err != nil {
+		return nil, err
+	}
+	ipAddr := form.Get("ipAddr")
+	if ipAddr == "" {
+		return nil, errBadRequest("parameter %q is required", "ipAddr")
+	}
+	found := false
+	err = s.Update(KindCompute, id, func(obj Object) {
+		ifaces, _ := obj["interfaces"].([]Object)
+		for _, iface := range ifaces {
+			if iface["netType"] == form.Get("netType") && iface["netId"] == netID {
+				iface["ipAddress"] = ipAddr
+				found = true
+				return
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errBadRequest("compute %d has no interface in %s %d", id, form.Get("netType"), netID)
+	}
+	return true, nil
+}
+
+func computePfwList(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := s.Get(KindCompute, id)
+	if !ok {
+		return nil, errNotFound(KindCompute, id)
+	}
+	pfws, _ := obj["pfws"].([]Object)
+	if pfws == nil {
+		pfws = []Object{}
+	}
+	return pfws, nil
+}
+
+func computePfwAdd(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range []string{"publicPortStart", "localBasePort", "proto"} {
+		if form.Get(param) == "" {
+			return nil, errBadRequest("parameter %q is required", param)
+		}
+	}
+	start := formInt(form, "publicPortStart")
+	end := formIntDefault(form, "publicPortEnd", start)
+	proto := form.Get("proto")
+	ruleID := s.NewID()
+	var conflict error
+	err = s.Update(KindCompute, id, func(obj Object) {
+		pfws, _ := obj["pfws"].([]Object)
+		for _, pfw := range pfws {
+			if pfw["protocol"] == proto && pfw["publicPortStart"].(int) <= end && start <= pfw["publicPortEnd"].(int) {
+				conflict = errBadRequest("public ports %d-%d/%s overlap with rule %d", start, end, proto, pfw["id"])
+				return
+			}
+		}
+		obj["pfws"] = append(pfws, Object{
+			"id":              ruleID,
+			"localIp":         fmt.Sprintf("192.168.0.%d", id%250+2),
+			"localPort":       formInt(form, "localBasePort"),
+			"protocol":        proto,
+			"publicPortStart": start,
+			"publicPortEnd":   end,
+			"vmId":            id,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if conflict != nil {
+		return nil, conflict
+	}
+	return ruleID, nil
+}
+
+func computePfwDel(s *Server, form url.Values) (interface{}, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return nil, err
+	}
+	// rule is identified either by its ID or by its ports and protocol
+	ruleID := formInt(form, "ruleId")
+	if ruleID == 0 && (form.Get("publicPortStart") == "" || form.Get("localBasePort") == "" || form.Get("proto") == "") {
+		return nil, errBadRequest("either %q or ports and protocol of the rule are required", "ruleId")
+	}
+	start := formInt(form, "publicPortStart")
+	matches := func(pfw Object) bool {
+		if ruleID != 0 {
+			return pfw["id"] == ruleID
+		}
+		return pfw["publicPortStart"] == start &&
+			pfw["publicPortEnd"] == formIntDefault(form, "publicPortEnd", start) &&
+			pfw["localPort"] == formInt(form, "localBasePort") &&
+			pfw["protocol"] == form.Get("proto")
+	}
+	found := false
+	err = s.Update(KindCompute, id, func(obj Object) {
+		pfws, _ := obj["pfws"].([]Object)
+		res := make([]Object, 0, len(pfws))
+		for _, pfw := range pfws {
+			if matches(pfw) {
+				found = true
+				continue
+			}
+			res = append(res, pfw)
+		}
+		obj["pfws"] = res
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errBadRequest("compute %d has no such port forwarding rule", id)
+	}
+	return true, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import "net/url"
+
+func registerDeviceHandlers(s *Server) {
+	s.Handle(cloudbroker+"/vgpu/list", func(s *Server, form url.Values) (interface{}, error) {
+		// all vGPUs fit into the first page
+		if page := formInt(form, "page"); page > 1 {
+			return []Object{}, nil
+		}
+		return listObjects(KindVGPU)(s, form)
+	})
+	s.Handle(cloudapi+"/account/listVGPU", func(s *Server, form url.Values) (interface{}, error) {
+		accountID, err := requireInt(form, "accountId")
+		if err != nil {
+			return nil, err
+		}
+		res := make([]Object, 0)
+		for _, vgpu := range s.List(KindVGPU) {
+			if vgpu["accountId"] == accountID {
+				res = append(res, vgpu)
+			}
+		}
+		return res, nil
+	})
+
+	s.Handle(cloudapi+"/compute/attachGpu", func(s *Server, form url.Values) (interface{}, error) {
+		id, vgpuID, err := requireStoppedCompute(s, form, "vgpuId")
+		if err != nil {
+			return nil, err
+		}
+		vgpu, ok := s.Get(KindVGPU, vgpuID)
+		if !ok {
+			return nil, errNotFound(KindVGPU, vgpuID)
+		}
+		if vmID := vgpu["vmid"]; vmID != 0 && vmID != id {
+			return nil, errBadRequest("vgpu %d is already attached to compute %v", vgpuID, vmID)
+		}
+		_ = s.Update(KindVGPU, vgpuID, func(obj Object) {
+			obj["vmid"] = id
+			obj["status"] = "ALLOCATED"
+		})
+		return vgpuID, nil
+	})
+	s.Handle(cloudapi+"/compute/detachGpu", func(s *Server, form url.Values) (interface{}, error) {
+		id, vgpuID, err := requireStoppedCompute(s, form, "vgpuId")
+		if err != nil {
+			return nil, err
+		}
+		vgpu, ok := s.Get(KindVGPU, vgpuID)
+		if !ok || vgpu["vmid"] != id {
+			return nil, errBadRequest("vgpu %d is not attached to compute %d", vgpuID, id)
+		}
+		_ = s.Update(KindVGPU, vgpuID, func(obj Object) {
+			obj["vmid"] = 0
+			obj["status"] = "CREATED"
+		})
+		return true, nil
+	})
+
+	s.Handle(cloudapi+"/compute/attachPciDevice", func(s *Server, form url.Values) (interface{}, error) {
+		id, deviceID, err := requireStoppedCompute(s, form, "deviceId")
+		if err != nil {
+			return nil, err
+		}
+		device, ok := s.Get(KindPCIDevice, deviceID)
+		if !ok {
+			return nil, errNotFound(KindPCIDevice, deviceID)
+		}
+		if computeID := device["computeId"]; computeID != 0 && computeID != id {
+			return nil, errBadRequest("pci device %d is already attached to compute %v", deviceID, computeID)
+		}
+		_ = s.Update(KindPCIDevice, deviceID, func(obj Object) { obj["computeId"] = id })
+		return true, nil
+	})
+	s.Handle(cloudapi+"/compute/detachPciDevice", func(s *Server, form url.Values) (interface{}, error) {
+		id, deviceID, err := requireStoppedCompute(s, form, "deviceId")
+		if err != nil {
+			return nil, err
+		}
+		device, ok := s.Get(KindPCIDevice, deviceID)
+		if !ok || device["computeId"] != id {
+			return nil, errBadRequest("pci device %d is not attached to compute %d", deviceID, id)
+		}
+		_ = s.Update(KindPCIDevice, deviceID, func(obj Object) { obj["computeId"] = 0 })
+		return true, nil
+	})
+	s.Handle(cloudapi+"/compute/pciDeviceList", func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "computeId")
+		if err != nil {
+			return nil, err
+		}
+		res := make([]Object, 0)
+		for _, device := range s.List(KindPCIDevice) {
+			if device["computeId"] == id {
+				res = append(res, device)
+			}
+		}
+		return res, nil
+	})
+}
+
+// requireStoppedCompute checks that devices are attached to or detached from stopped compute,
+// as the platform does, and returns IDs of the compute and the device
+func requireStoppedCompute(s *Server, form url.Values, deviceKey string) (int, int, error) {
+	id, err := requireInt(form, "computeId")
+	if err != nil {
+		return 0, 0, err
+	}
+	compute, ok := s.Get(KindCompute, id)
+	if !ok {
+		return 0, 0, errNotFound(KindCompute, id)
+	}
+	if compute["techStatus"] != "STOPPED" {
+		return 0, 0, errBadRequest("compute %d must be stopped, its tech status is %v", id, compute["techStatus"])
+	}
+	deviceID, err := requireInt(form, deviceKey)
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, deviceID, nil
+}
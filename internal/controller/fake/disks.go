@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/url"
+)
+
+func registerDiskHandlers(s *Server) {
+	s.Handle(cloudapi+"/disks/create", func(s *Server, form url.Values) (interface{}, error) {
+		accountID, err := requireInt(form, "accountId")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.Get(KindAccount, accountID); !ok {
+			return nil, errNotFound(KindAccount, accountID)
+		}
+		diskType := form.Get("type")
+		if diskType == "" {
+			diskType = "D"
+		}
+		id := s.NewID()
+		s.Put(KindDisk, id, Object{
+			"id":        id,
+			"name":      form.Get("name"),
+			"desc":      form.Get("description"),
+			"accountId": accountID,
+			"gid":       formInt(form, "gid"),
+			"type":      diskType,
+			"sizeMax":   formInt(form, "size"),
+			"sepId":     formInt(form, "sep_id"),
+			"pool":      form.Get("pool"),
+			"status":    "CREATED",
+		})
+		return id, nil
+	})
+	s.Handle(cloudapi+"/disks/get", getObject(KindDisk, "diskId"))
+	s.Handle(cloudapi+"/disks/list", listObjects(KindDisk))
+	s.Handle(cloudapi+"/disks/listUnattached", func(s *Server, form url.Values) (interface{}, error) {
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindDisk) {
+			if _, attached := obj["computeId"]; !attached && !isDeleted(obj) {
+				res = append(res, obj)
+			}
+		}
+		return res, nil
+	})
+	s.Handle(cloudapi+"/disks/resize2", updateDisk(func(obj Object, form url.Values) error {
+		size := formInt(form, "size")
+		if cur, _ := obj["sizeMax"].(int); size < cur {
+			return errBadRequest("disk size cannot be reduced from %d to %d", cur, size)
+		}
+		obj["sizeMax"] = size
+		return nil
+	}))
+	s.Handle(cloudapi+"/disks/rename", updateDisk(func(obj Object, form url.Values) error {
+		obj["name"] = form.Get("name")
+		return nil
+	}))
+	s.Handle(cloudapi+"/disks/limitIO", updateDisk(func(obj Object, form url.Values) error {
+		obj["iotune"] = Object{
+			"total_iops_sec": formInt(form, "iops"),
+		}
+		return nil
+	}))
+	s.Handle(cloudapi+"/disks/restore", updateDisk(func(obj Object, form url.Values) error {
+		obj["status"] = "CREATED"
+		return nil
+	}))
+	s.Handle(cloudapi+"/disks/delete", func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "diskId")
+		if err != nil {
+			return nil, err
+		}
+		disk, ok := s.Get(KindDisk, id)
+		if !ok {
+			return nil, errNotFound(KindDisk, id)
+		}
+		if _, attached := disk["computeId"]; attached && !formBool(form, "detach") {
+			return nil, errBadRequest("disk %d is attached to compute %v", id, disk["computeId"])
+		}
+		_ = s.Update(KindDisk, id, func(obj Object) { delete(obj, "computeId") })
+		return deleteObject(KindDisk, "diskId")(s, form)
+	})
+}
+
+func updateDisk(fn func(obj Object, form url.Values) error) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "diskId")
+		if err != nil {
+			return nil, err
+		}
+		var fnErr error
+		if err := s.Update(KindDisk, id, func(obj Object) { fnErr = fn(obj, form) }); err != nil {
+			return nil, err
+		}
+		if fnErr != nil {
+			return nil, fnErr
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/url"
+)
+
+func registerK8sHandlers(s *Server) {
+	s.Handle(cloudapi+"/k8s/create", func(s *Server, form url.Values) (interface{}, error) {
+		rgID, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		rg, ok := s.Get(KindRG, rgID)
+		if !ok || isDeleted(rg) {
+			return nil, errNotFound(KindRG, rgID)
+		}
+		k8ciID, err := requireInt(form, "k8ciId")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.Get(KindK8CI, k8ciID); !ok {
+			return nil, errNotFound(KindK8CI, k8ciID)
+		}
+
+		id := s.NewID()
+		name := form.Get("name")
+		masterNum := formIntDefault(form, "masterNum", 1)
+		masters := Object{
+			"id":           s.NewID(),
+			"name":         name + "-master",
+			"num":          masterNum,
+			"cpu":          formInt(form, "masterCpu"),
+			"ram":          formInt(form, "masterRam"),
+			"disk":         formInt(form, "masterDisk"),
+			"detailedInfo": s.newK8sNodes(rg, name+"-master", masterNum, form, "master"),
+		}
+		workers := s.newK8sWorkersGroup(rg, form.Get("workerGroupName"), form, "worker")
+
+		lbID := 0
+		if formBool(form, "withLB") || form.Get("withLB") == "" {
+			lbID = s.NewID()
+			s.Put(KindLB, lbID, Object{
+				"id":       lbID,
+				"name":     name + "-lb",
+				"rgId":     rgID,
+				"extnetId": formInt(form, "extnetId"),
+				"status":   "ENABLED",
+				"primaryNode": Object{
+					"frontendIp": fmt.Sprintf("10.0.0.%d", lbID%250+2),
+				},
+			})
+		}
+
+		s.Put(KindK8s, id, Object{
+			"id":          id,
+			"name":        name,
+			"rgId":        rgID,
+			"rgName":      rg["name"],
+			"accountId":   rg["accountId"],
+			"ciId":        k8ciID,
+			"lbId":        lbID,
+			"desc":        form.Get("desc"),
+			"status":      "ENABLED",
+			"techStatus":  "STARTED",
+			"k8sGroups":   Object{"masters": masters, "workers": []Object{workers}},
+			"labels":      form["labels"],
+			"taints":      form["taints"],
+			"annotations": form["annotations"],
+		})
+
+		return s.newTask(id), nil
+	})
+	s.Handle(cloudapi+"/k8s/get", getObject(KindK8s, "k8sId"))
+	s.Handle(cloudapi+"/k8s/list", listObjects(KindK8s))
+	s.Handle(cloudapi+"/k8s/listDeleted", func(s *Server, form url.Values) (interface{}, error) {
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindK8s) {
+			if isDeleted(obj) {
+				res = append(res, obj)
+			}
+		}
+		return res, nil
+	})
+	s.Handle(cloudapi+"/k8s/update", updateK8s(func(s *Server, obj Object, form url.Values) (interface{}, error) {
+		if name := form.Get("name"); name != "" {
+			obj["name"] = name
+		}
+		if _, ok := form["desc"]; ok {
+			obj["desc"] = form.Get("desc")
+		}
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/k8s/delete", deleteObject(KindK8s, "k8sId"))
+	s.Handle(cloudapi+"/k8s/restore", setStatus(KindK8s, "k8sId", "DISABLED"))
+	s.Handle(cloudapi+"/k8s/enable", setStatus(KindK8s, "k8sId", "ENABLED"))
+	s.Handle(cloudapi+"/k8s/getConfig", func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "k8sId")
+		if err != nil {
+			return nil, err
+		}
+		k8s, ok := s.Get(KindK8s, id)
+		if !ok {
+			return nil, errNotFound(KindK8s, id)
+		}
+		return Raw(fmt.Sprintf("apiVersion: v1\nkind: Config\nclusters:\n- name: %s\n", k8s["name"])), nil
+	})
+
+	s.Handle(cloudapi+"/k8s/workersGroupAdd", updateK8s(func(s *Server, obj Object, form url.Values) (interface{}, error) {
+		rg, _ := s.Get(KindRG, obj["rgId"].(int))
+		wg := s.newK8sWorkersGroup(rg, form.Get("name"), form, "worker")
+		groups := obj["k8sGroups"].(Object)
+		groups["workers"] = append(groups["workers"].([]Object), wg)
+		return fmt.Sprint(wg["id"]), nil
+	}))
+	s.Handle(cloudapi+"/k8s/workersGroupDelete", updateK8s(func(s *Server, obj Object, form url.Values) (interface{}, error) {
+		groups := obj["k8sGroups"].(Object)
+		wgID := formInt(form, "workersGroupId")
+		res := make([]Object, 0)
+		for _, wg := range groups["workers"].([]Object) {
+			if wg["id"] == wgID {
+				for _, node := range wg["detailedInfo"].([]Object) {
+					s.Delete(KindCompute, node["id"].(int))
+				}
+				continue
+			}
+			res = append(res, wg)
+		}
+		groups["workers"] = res
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/k8s/workerAdd", updateK8sWorkersGroup(func(s *Server, obj, wg Object, form url.Values) (interface{}, error) {
+		rg, _ := s.Get(KindRG, obj["rgId"].(int))
+		num := formInt(form, "num")
+		nodeForm := url.Values{}
+		nodeForm.Set("workerCpu", fmt.Sprint(wg["cpu"]))
+		nodeForm.Set("workerRam", fmt.Sprint(wg["ram"]))
+		nodeForm.Set("workerDisk", fmt.Sprint(wg["disk"]))
+		wg["detailedInfo"] = append(wg["detailedInfo"].([]Object), s.newK8sNodes(rg, fmt.Sprint(wg["name"]), num, nodeForm, "worker")...)
+		wg["num"] = wg["num"].(int) + num
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/k8s/deleteWorkerFromGroup", updateK8sWorkersGroup(func(s *Server, obj, wg Object, form url.Values) (interface{}, error) {
+		workerID := formInt(form, "workerId")
+		res := make([]Object, 0)
+		for _, node := range wg["detailedInfo"].([]Object) {
+			if node["id"] == workerID {
+				s.Delete(KindCompute, workerID)
+				continue
+			}
+			res = append(res, node)
+		}
+		if len(res) == len(wg["detailedInfo"].([]Object)) {
+			return nil, errNotFound(KindCompute, workerID)
+		}
+		wg["detailedInfo"] = res
+		wg["num"] = len(res)
+		return true, nil
+	}))
+}
+
+// newK8sNodes creates computes backing k8s nodes. Node parameters are taken from
+// <prefix>Cpu, <prefix>Ram and <prefix>Disk form values.
+func (s *Server) newK8sNodes(rg Object, name string, num int, form url.Values, prefix string) []Object {
+	nodes := make([]Object, 0, num)
+	for i := 0; i < num; i++ {
+		id := s.NewID()
+		nodeName := fmt.Sprintf("%s-%d", name, id)
+		s.Put(KindCompute, id, Object{
+			"id":           id,
+			"name":         nodeName,
+			"rgId":         rg["id"],
+			"rgName":       rg["name"],
+			"accountId":    rg["accountId"],
+			"gid":          DefaultGridID,
+			"cpus":         formInt(form, prefix+"Cpu"),
+			"ram":          formInt(form, prefix+"Ram"),
+			"bootdiskSize": formInt(form, prefix+"Disk"),
+			"driver":       "KVM_X86",
+			"arch":         "X86_64",
+			"status":       "ENABLED",
+			"techStatus":   "STARTED",
+			"interfaces":   []Object{},
+			"tags":         map[string]string{},
+		})
+		nodes = append(nodes, Object{"id": id, "name": nodeName, "status": "ENABLED", "techStatus": "STARTED"})
+	}
+	return nodes
+}
+
+func (s *Server) newK8sWorkersGroup(rg Object, name string, form url.Values, prefix string) Object {
+	num := formIntDefault(form, prefix+"Num", 1)
+	return Object{
+		"id":           s.NewID(),
+		"name":         name,
+		"num":          num,
+		"cpu":          formInt(form, prefix+"Cpu"),
+		"ram":          formInt(form, prefix+"Ram"),
+		"disk":         formInt(form, prefix+"Disk"),
+		"labels":       []string{},
+		"taints":       []string{},
+		"annotations":  []string{},
+		"detailedInfo": s.newK8sNodes(rg, name, num, form, prefix),
+	}
+}
+
+func updateK8s(fn func(s *Server, obj Object, form url.Values) (interface{}, error)) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "k8sId")
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := s.Get(KindK8s, id)
+		if !ok {
+			return nil, errNotFound(KindK8s, id)
+		}
+		// API calls are serialized by the server, so the object may be modified in place
+		return fn(s, obj, form)
+	}
+}
+
+func updateK8sWorkersGroup(fn func(s *Server, obj, wg Object, form url.Values) (interface{}, error)) HandlerFunc {
+	return updateK8s(func(s *Server, obj Object, form url.Values) (interface{}, error) {
+		wgID, err := requireInt(form, "workersGroupId")
+		if err != nil {
+			return nil, err
+		}
+		for _, wg := range obj["k8sGroups"].(Object)["workers"].([]Object) {
+			if wg["id"] == wgID {
+				return fn(s, obj, wg, form)
+			}
+		}
+		return nil, errNotFound("workers group", wgID)
+	})
+}
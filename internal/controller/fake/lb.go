@@ -0,0 +1,292 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// settings of backend servers, which are kept as they are sent
+var lbServerSettings = []string{"inter", "downinter", "rise", "fall", "slowstart", "maxconn", "maxqueue", "weight"}
+
+func registerLBHandlers(s *Server) {
+	s.Handle(cloudapi+"/lb/create", func(s *Server, form url.Values) (interface{}, error) {
+		rgID, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		rg, ok := s.Get(KindRG, rgID)
+		if !ok {
+			return nil, errNotFound(KindRG, rgID)
+		}
+		techStatus := "STOPPED"
+		if formBool(form, "start") {
+			techStatus = "STARTED"
+		}
+		id := s.NewID()
+		s.Put(KindLB, id, Object{
+			"id":         id,
+			"name":       form.Get("name"),
+			"rgId":       rgID,
+			"rgName":     rg["name"],
+			"extnetId":   formInt(form, "extnetId"),
+			"vinsId":     formInt(form, "vinsId"),
+			"desc":       form.Get("desc"),
+			"status":     "ENABLED",
+			"techStatus": techStatus,
+			"backends":   []Object{},
+			"frontends":  []Object{},
+			"primaryNode": Object{
+				"frontendIp": "10.0.0." + strconv.Itoa(id%250+2),
+			},
+		})
+		return id, nil
+	})
+	s.Handle(cloudapi+"/lb/get", getObject(KindLB, "lbId"))
+	s.Handle(cloudapi+"/lb/list", listObjects(KindLB))
+	s.Handle(cloudapi+"/lb/listDeleted", func(s *Server, form url.Values) (interface{}, error) {
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindLB) {
+			if isDeleted(obj) {
+				res = append(res, obj)
+			}
+		}
+		return res, nil
+	})
+	s.Handle(cloudapi+"/lb/update", updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		obj["desc"] = form.Get("desc")
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/delete", deleteObject(KindLB, "lbId"))
+	s.Handle(cloudapi+"/lb/restore", setStatus(KindLB, "lbId", "ENABLED"))
+	s.Handle(cloudapi+"/lb/enable", setStatus(KindLB, "lbId", "ENABLED"))
+	s.Handle(cloudapi+"/lb/disable", setStatus(KindLB, "lbId", "DISABLED"))
+	s.Handle(cloudapi+"/lb/start", setLBTechStatus("STARTED"))
+	s.Handle(cloudapi+"/lb/stop", setLBTechStatus("STOPPED"))
+	s.Handle(cloudapi+"/lb/restart", setLBTechStatus("STARTED"))
+	s.Handle(cloudapi+"/lb/configReset", updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		obj["backends"] = []Object{}
+		obj["frontends"] = []Object{}
+		return true, nil
+	}))
+
+	s.Handle(cloudapi+"/lb/backendCreate", updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		name := form.Get("backendName")
+		if lbFind(obj, "backends", name) != nil {
+			return nil, errBadRequest("backend %s already exists", name)
+		}
+		algorithm := form.Get("algorithm")
+		if algorithm == "" {
+			algorithm = "roundrobin"
+		}
+		settings := Object{"inter": 5000, "downinter": 5000, "rise": 2, "fall": 3, "slowstart": 60000, "maxconn": 250, "maxqueue": 256, "weight": 100}
+		lbApplyServerSettings(settings, form)
+		obj["backends"] = append(obj["backends"].([]Object), Object{
+			"name":                  name,
+			"guid":                  name,
+			"algorithm":             algorithm,
+			"serverDefaultSettings": settings,
+			"servers":               []Object{},
+		})
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/backendUpdate", updateLBBackend(func(backend Object, form url.Values) (interface{}, error) {
+		if algorithm := form.Get("algorithm"); algorithm != "" {
+			backend["algorithm"] = algorithm
+		}
+		lbApplyServerSettings(backend["serverDefaultSettings"].(Object), form)
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/backendDelete", updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		return lbRemove(obj, "backends", form.Get("backendName"))
+	}))
+
+	s.Handle(cloudapi+"/lb/backendServerAdd", updateLBBackend(func(backend Object, form url.Values) (interface{}, error) {
+		name := form.Get("serverName")
+		if lbFind(backend, "servers", name) != nil {
+			return nil, errBadRequest("server %s already exists", name)
+		}
+		check := form.Get("check")
+		if check == "" {
+			check = "enabled"
+		}
+		// servers inherit default settings of the backend
+		settings := Object{}
+		for key, value := range backend["serverDefaultSettings"].(Object) {
+			settings[key] = value
+		}
+		lbApplyServerSettings(settings, form)
+		backend["servers"] = append(backend["servers"].([]Object), Object{
+			"name":           name,
+			"guid":           name,
+			"address":        form.Get("address"),
+			"port":           formInt(form, "port"),
+			"check":          check,
+			"serverSettings": settings,
+		})
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/backendServerUpdate", updateLBBackend(func(backend Object, form url.Values) (interface{}, error) {
+		name := form.Get("serverName")
+		server := lbFind(backend, "servers", name)
+		if server == nil {
+			return nil, errBadRequest("server %s not found", name)
+		}
+		server["address"] = form.Get("address")
+		server["port"] = formInt(form, "port")
+		if check := form.Get("check"); check != "" {
+			server["check"] = check
+		}
+		lbApplyServerSettings(server["serverSettings"].(Object), form)
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/backendServerDelete", updateLBBackend(func(backend Object, form url.Values) (interface{}, error) {
+		return lbRemove(backend, "servers", form.Get("serverName"))
+	}))
+
+	s.Handle(cloudapi+"/lb/frontendCreate", updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		name := form.Get("frontendName")
+		if lbFind(obj, "frontends", name) != nil {
+			return nil, errBadRequest("frontend %s already exists", name)
+		}
+		backend := form.Get("backendName")
+		if lbFind(obj, "backends", backend) == nil {
+			return nil, errBadRequest("backend %s not found", backend)
+		}
+		obj["frontends"] = append(obj["frontends"].([]Object), Object{
+			"name":     name,
+			"guid":     name,
+			"backend":  backend,
+			"bindings": []Object{},
+		})
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/frontendDelete", updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		return lbRemove(obj, "frontends", form.Get("frontendName"))
+	}))
+
+	s.Handle(cloudapi+"/lb/frontendBind", updateLBFrontend(func(frontend Object, form url.Values) (interface{}, error) {
+		name := form.Get("bindingName")
+		if lbFind(frontend, "bindings", name) != nil {
+			return nil, errBadRequest("binding %s already exists", name)
+		}
+		binding := Object{
+			"name":    name,
+			"guid":    name,
+			"address": form.Get("bindingAddress"),
+			"port":    formInt(form, "bindingPort"),
+		}
+		frontend["bindings"] = append(frontend["bindings"].([]Object), binding)
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/frontendBindingUpdate", updateLBFrontend(func(frontend Object, form url.Values) (interface{}, error) {
+		name := form.Get("bindingName")
+		binding := lbFind(frontend, "bindings", name)
+		if binding == nil {
+			return nil, errBadRequest("binding %s not found", name)
+		}
+		if address := form.Get("bindingAddress"); address != "" {
+			binding["address"] = address
+		}
+		if port := formInt(form, "bindingPort"); port != 0 {
+			binding["port"] = port
+		}
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/lb/frontendBindDelete", updateLBFrontend(func(frontend Object, form url.Values) (interface{}, error) {
+		return lbRemove(frontend, "bindings", form.Get("bindingName"))
+	}))
+}
+
+// lbApplyServerSettings updates server settings with the ones sent
+func lbApplyServerSettings(settings Object, form url.Values) {
+	for _, key := range lbServerSettings {
+		if _, ok := form[key]; ok {
+			settings[key] = formInt(form, key)
+		}
+	}
+}
+
+// lbFind returns the item of the named list of the object, e.g. backend of load balancer
+func lbFind(obj Object, list, name string) Object {
+	for _, item := range obj[list].([]Object) {
+		if item["name"] == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func lbRemove(obj Object, list, name string) (interface{}, error) {
+	items := obj[list].([]Object)
+	for i, item := range items {
+		if item["name"] == name {
+			obj[list] = append(items[:i:i], items[i+1:]...)
+			return true, nil
+		}
+	}
+	return nil, errBadRequest("%s %s not found", list, name)
+}
+
+func updateLB(fn func(obj Object, form url.Values) (interface{}, error)) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "lbId")
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := s.Get(KindLB, id)
+		if !ok {
+			return nil, errNotFound(KindLB, id)
+		}
+		// API calls are serialized by the server, so the object may be modified in place
+		return fn(obj, form)
+	}
+}
+
+func setLBTechStatus(techStatus string) HandlerFunc {
+	return updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		obj["techStatus"] = techStatus
+		return true, nil
+	})
+}
+
+func updateLBBackend(fn func(backend Object, form url.Values) (interface{}, error)) HandlerFunc {
+	return updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		name := form.Get("backendName")
+		backend := lbFind(obj, "backends", name)
+		if backend == nil {
+			return nil, errBadRequest("backend %s not found", name)
+		}
+		return fn(backend, form)
+	})
+}
+
+func updateLBFrontend(fn func(frontend Object, form url.Values) (interface{}, error)) HandlerFunc {
+	return updateLB(func(obj Object, form url.Values) (interface{}, error) {
+		name := form.Get("frontendName")
+		frontend := lbFind(obj, "frontends", name)
+		if frontend == nil {
+			return nil, errBadRequest("frontend %s not found", name)
+		}
+		return fn(frontend, form)
+	})
+}
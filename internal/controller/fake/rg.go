@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/url"
+)
+
+func registerRGHandlers(s *Server) {
+	s.Handle(cloudapi+"/rg/create", func(s *Server, form url.Values) (interface{}, error) {
+		accountID, err := requireInt(form, "accountId")
+		if err != nil {
+			return nil, err
+		}
+		account, ok := s.Get(KindAccount, accountID)
+		if !ok {
+			return nil, errNotFound(KindAccount, accountID)
+		}
+		id := s.NewID()
+		defNetType := form.Get("def_net")
+		if defNetType == "" {
+			defNetType = "PRIVATE"
+		}
+		s.Put(KindRG, id, Object{
+			"id":          id,
+			"name":        form.Get("name"),
+			"accountId":   accountID,
+			"accountName": account["name"],
+			"gid":         formIntDefault(form, "gid", DefaultGridID),
+			"desc":        form.Get("desc"),
+			"defNetType":  defNetType,
+			"status":      "CREATED",
+			"resourceLimits": Object{
+				"CU_C":      formIntDefault(form, "maxCPUCapacity", -1),
+				"CU_M":      formIntDefault(form, "maxMemoryCapacity", -1),
+				"CU_D":      formIntDefault(form, "maxVDiskCapacity", -1),
+				"CU_I":      formIntDefault(form, "maxNumPublicIP", -1),
+				"CU_NP":     formIntDefault(form, "maxNetworkPeerTransfer", -1),
+				"gpu_units": -1,
+			},
+		})
+		return id, nil
+	})
+	s.Handle(cloudapi+"/rg/get", getObject(KindRG, "rgId"))
+	s.Handle(cloudapi+"/rg/list", listObjects(KindRG))
+	s.Handle(cloudapi+"/rg/update", func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		err = s.Update(KindRG, id, func(obj Object) {
+			if name := form.Get("name"); name != "" {
+				obj["name"] = name
+			}
+			if _, ok := form["desc"]; ok {
+				obj["desc"] = form.Get("desc")
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return true, nil
+	})
+	s.Handle(cloudapi+"/rg/delete", deleteObject(KindRG, "rgId"))
+	s.Handle(cloudapi+"/rg/restore", setStatus(KindRG, "rgId", "CREATED"))
+	s.Handle(cloudapi+"/rg/enable", setStatus(KindRG, "rgId", "ENABLED"))
+	s.Handle(cloudapi+"/rg/disable", setStatus(KindRG, "rgId", "DISABLED"))
+	s.Handle(cloudapi+"/rg/listVins", func(s *Server, form url.Values) (interface{}, error) {
+		rgID, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindVins) {
+			if obj["rgId"] == rgID && !isDeleted(obj) {
+				res = append(res, obj)
+			}
+		}
+		return res, nil
+	})
+}
+
+func formIntDefault(form url.Values, key string, def int) int {
+	if _, ok := form[key]; !ok {
+		return def
+	}
+	return formInt(form, key)
+}
@@ -0,0 +1,376 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake implements in-memory DECORT controller served with net/http/httptest.
+// It supports the subset of /restmachine/cloudapi endpoints used by kvmvm (including vGPU
+// and PCI device attachment), rg, disks, vins, k8s and lb resources and /restmachine/cloudbroker
+// endpoints used by kvmvm resource, so that their CRUD handlers can be exercised without
+// a live cloud, either directly via Client or with resource.UnitTest via ProviderConfig.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Object is a single DECORT entity as it is returned by .../get API, keyed by JSON field names
+type Object map[string]interface{}
+
+// Raw is returned by handlers, which respond with non-JSON body (e.g. kubeconfig)
+type Raw string
+
+// HandlerFunc handles a single API call. It receives request form values and returns
+// either a value to be serialized as JSON response or an error.
+type HandlerFunc func(s *Server, form url.Values) (interface{}, error)
+
+// Error is returned by handlers to respond with a specific HTTP status code
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errNotFound(kind string, id int) error {
+	return &Error{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("%s with id %d not found", kind, id)}
+}
+
+func errBadRequest(format string, args ...interface{}) error {
+	return &Error{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(format, args...)}
+}
+
+// Server is a fake DECORT controller. All registered objects are kept in memory
+// and are accessible to tests through Get/Put methods.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex // guards the fields below
+	serve    sync.Mutex // serializes API calls, so that handlers may update objects freely
+	nextID   int
+	objects  map[string]map[int]Object
+	handlers map[string]HandlerFunc
+	calls    map[string]int
+}
+
+// Default IDs of objects, which are seeded into every new fake controller
+const (
+	DefaultGridID    = 1
+	DefaultAccountID = 1
+	DefaultImageID   = 1
+	DefaultExtNetID  = 1
+	DefaultK8CIID    = 1
+)
+
+// Kinds of objects kept by the fake controller
+const (
+	KindAccount   = "account"
+	KindRG        = "rg"
+	KindCompute   = "compute"
+	KindDisk      = "disk"
+	KindImage     = "image"
+	KindExtNet    = "extnet"
+	KindVins      = "vins"
+	KindK8s       = "k8s"
+	KindK8CI      = "k8ci"
+	KindLB        = "lb"
+	KindVGPU      = "vgpu"
+	KindPCIDevice = "pcidevice"
+	KindTask      = "task"
+)
+
+// NewServer starts new fake DECORT controller. Caller should Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		nextID:   100,
+		objects:  make(map[string]map[int]Object),
+		handlers: make(map[string]HandlerFunc),
+		calls:    make(map[string]int),
+	}
+
+	s.seed()
+	registerCommonHandlers(s)
+	registerComputeHandlers(s)
+	registerRGHandlers(s)
+	registerDiskHandlers(s)
+	registerVinsHandlers(s)
+	registerK8sHandlers(s)
+	registerLBHandlers(s)
+	registerDeviceHandlers(s)
+	registerCloudbrokerComputeHandlers(s)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) seed() {
+	s.Put(KindAccount, DefaultAccountID, Object{"id": DefaultAccountID, "name": "fake-account", "status": "CONFIRMED"})
+	s.Put(KindImage, DefaultImageID, Object{"id": DefaultImageID, "name": "fake-image", "status": "CREATED", "hotResize": true})
+	s.Put(KindExtNet, DefaultExtNetID, Object{"id": DefaultExtNetID, "name": "fake-extnet", "status": "ENABLED", "ipcidr": "10.0.0.0/24"})
+	s.Put(KindK8CI, DefaultK8CIID, Object{"id": DefaultK8CIID, "name": "fake-k8ci", "status": "ENABLED"})
+}
+
+// Handle registers handler for the API path, e.g. "/restmachine/cloudapi/compute/get".
+// It may be used to override default behaviour or to add endpoints missing in the fake.
+func (s *Server) Handle(path string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[path] = h
+}
+
+func (s *Server) handler(path string) HandlerFunc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.handlers[path]
+}
+
+func (s *Server) handlersWithPrefix(prefix string) map[string]HandlerFunc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make(map[string]HandlerFunc)
+	for path, h := range s.handlers {
+		if strings.HasPrefix(path, prefix) {
+			res[path] = h
+		}
+	}
+	return res
+}
+
+// Calls returns number of times the API path was called
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+// NewID allocates unique ID for a new object
+func (s *Server) NewID() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	return s.nextID
+}
+
+// Put stores object of the specified kind
+func (s *Server) Put(kind string, id int, obj Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.objects[kind] == nil {
+		s.objects[kind] = make(map[int]Object)
+	}
+	s.objects[kind][id] = obj
+}
+
+// Get returns object of the specified kind, if any
+func (s *Server) Get(kind string, id int) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[kind][id]
+	return obj, ok
+}
+
+// Delete removes object of the specified kind
+func (s *Server) Delete(kind string, id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects[kind], id)
+}
+
+// List returns all objects of the specified kind ordered by ID
+func (s *Server) List(kind string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]int, 0, len(s.objects[kind]))
+	for id := range s.objects[kind] {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	res := make([]Object, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, s.objects[kind][id])
+	}
+	return res
+}
+
+// Update applies fn to the stored object under the server lock
+func (s *Server) Update(kind string, id int, fn func(obj Object)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		return errNotFound(kind, id)
+	}
+	fn(obj)
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.calls[r.URL.Path]++
+	h, ok := s.handlers[r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("fake DECORT controller: API %q is not implemented", r.URL.Path), http.StatusNotImplemented)
+		return
+	}
+
+	s.serve.Lock()
+	defer s.serve.Unlock()
+
+	resp, err := h(s, r.PostForm)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if ferr, ok := err.(*Error); ok {
+			code = ferr.StatusCode
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	if raw, ok := resp.(Raw); ok {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(raw))
+		return
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// ProviderConfig returns provider block pointing to the fake controller, which can be
+// prepended to resource configurations in resource.UnitTest steps
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "decort" {
+  authenticator  = "jwt"
+  jwt            = "fake-jwt"
+  oauth2_url     = %[1]q
+  controller_url = %[1]q
+}
+`, s.URL)
+}
+
+// helpers to extract typed values from request form
+
+func formInt(form url.Values, key string) int {
+	v, _ := strconv.Atoi(form.Get(key))
+	return v
+}
+
+func formBool(form url.Values, key string) bool {
+	v, _ := strconv.ParseBool(form.Get(key))
+	return v
+}
+
+func requireInt(form url.Values, key string) (int, error) {
+	v, err := strconv.Atoi(form.Get(key))
+	if err != nil {
+		return 0, errBadRequest("parameter %q is required and must be an integer", key)
+	}
+	return v, nil
+}
+
+// getObject is a common implementation of .../get API
+func getObject(kind, idKey string) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, idKey)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := s.Get(kind, id)
+		if !ok {
+			return nil, errNotFound(kind, id)
+		}
+		return obj, nil
+	}
+}
+
+// listObjects is a common implementation of .../list API, which hides deleted objects
+// unless includeDeleted is requested
+func listObjects(kind string) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		includeDeleted := formBool(form, "includeDeleted") || formBool(form, "includedeleted")
+		res := make([]Object, 0)
+		for _, obj := range s.List(kind) {
+			if !includeDeleted && isDeleted(obj) {
+				continue
+			}
+			res = append(res, obj)
+		}
+		return res, nil
+	}
+}
+
+// setStatus is a common implementation of APIs, which only change object status
+func setStatus(kind, idKey, status string) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, idKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Update(kind, id, func(obj Object) { obj["status"] = status }); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+}
+
+// deleteObject is a common implementation of .../delete API. Objects deleted permanently
+// are removed from the store, others are moved to DELETED status.
+func deleteObject(kind, idKey string) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, idKey)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.Get(kind, id); !ok {
+			return nil, errNotFound(kind, id)
+		}
+		if formBool(form, "permanently") {
+			s.Delete(kind, id)
+			return true, nil
+		}
+		return setStatus(kind, idKey, "DELETED")(s, form)
+	}
+}
+
+func isDeleted(obj Object) bool {
+	status, _ := obj["status"].(string)
+	return strings.EqualFold(status, "DELETED") || strings.EqualFold(status, "DESTROYED")
+}
@@ -0,0 +1,297 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"net/url"
+	"strconv"
+)
+
+func registerVinsHandlers(s *Server) {
+	s.Handle(cloudapi+"/vins/createInRG", func(s *Server, form url.Values) (interface{}, error) {
+		rgID, err := requireInt(form, "rgId")
+		if err != nil {
+			return nil, err
+		}
+		rg, ok := s.Get(KindRG, rgID)
+		if !ok {
+			return nil, errNotFound(KindRG, rgID)
+		}
+		id := s.newVins(form, rg["accountId"], rg["gid"])
+		obj, _ := s.Get(KindVins, id)
+		obj["rgId"] = rgID
+		obj["rgName"] = rg["name"]
+		// extNetId -1 stands for no external network
+		if _, ok := form["extNetId"]; ok && formInt(form, "extNetId") >= 0 {
+			vinsConnectExtNet(obj, formInt(form, "extNetId"), form.Get("extIp"))
+		}
+		return id, nil
+	})
+	s.Handle(cloudapi+"/vins/createInAccount", func(s *Server, form url.Values) (interface{}, error) {
+		accountID, err := requireInt(form, "accountId")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.Get(KindAccount, accountID); !ok {
+			return nil, errNotFound(KindAccount, accountID)
+		}
+		gid := formInt(form, "gid")
+		if gid == 0 {
+			gid = DefaultGridID
+		}
+		return s.newVins(form, accountID, gid), nil
+	})
+	s.Handle(cloudapi+"/vins/get", getObject(KindVins, "vinsId"))
+	s.Handle(cloudapi+"/vins/list", listObjects(KindVins))
+	s.Handle(cloudapi+"/vins/listDeleted", func(s *Server, form url.Values) (interface{}, error) {
+		res := make([]Object, 0)
+		for _, obj := range s.List(KindVins) {
+			if isDeleted(obj) {
+				res = append(res, obj)
+			}
+		}
+		return res, nil
+	})
+	s.Handle(cloudapi+"/vins/delete", deleteObject(KindVins, "vinsId"))
+	s.Handle(cloudapi+"/vins/restore", setStatus(KindVins, "vinsId", "ENABLED"))
+	s.Handle(cloudapi+"/vins/enable", setStatus(KindVins, "vinsId", "ENABLED"))
+	s.Handle(cloudapi+"/vins/disable", setStatus(KindVins, "vinsId", "DISABLED"))
+
+	s.Handle(cloudapi+"/vins/extNetConnect", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		if connected := vinsConfig(obj, "GW")["ext_net_id"]; connected != 0 {
+			return nil, errBadRequest("vins %v is already connected to extnet %v", obj["id"], connected)
+		}
+		vinsConnectExtNet(obj, formInt(form, "netId"), form.Get("Ip"))
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/extNetDisconnect", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		vinsConnectExtNet(obj, 0, "")
+		return true, nil
+	}))
+
+	s.Handle(cloudapi+"/vins/ipList", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		return vinsConfig(obj, "DHCP")["reservations"], nil
+	}))
+	s.Handle(cloudapi+"/vins/ipReserve", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		cfg := vinsConfig(obj, "DHCP")
+		reservations := cfg["reservations"].([]Object)
+		ip := form.Get("ipAddr")
+		if ip == "" {
+			ip = "192.168.0." + strconv.Itoa(len(reservations)+10)
+		}
+		for _, r := range reservations {
+			if r["ip"] == ip {
+				return nil, errBadRequest("ip %s is already reserved", ip)
+			}
+		}
+		cfg["reservations"] = append(reservations, Object{
+			"ip":   ip,
+			"mac":  form.Get("mac"),
+			"type": form.Get("type"),
+			"vmId": formInt(form, "computeId"),
+		})
+		return ip, nil
+	}))
+	s.Handle(cloudapi+"/vins/ipRelease", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		cfg := vinsConfig(obj, "DHCP")
+		ip, mac := form.Get("ipAddr"), form.Get("mac")
+		res := make([]Object, 0)
+		for _, r := range cfg["reservations"].([]Object) {
+			if (ip == "" && mac == "") || (ip != "" && r["ip"] == ip) || (mac != "" && r["mac"] == mac) {
+				continue
+			}
+			res = append(res, r)
+		}
+		cfg["reservations"] = res
+		return true, nil
+	}))
+
+	s.Handle(cloudapi+"/vins/dnsApply", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		dns := form["dnsList"]
+		if dns == nil {
+			dns = []string{}
+		}
+		vinsConfig(obj, "DHCP")["dns"] = dns
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/dhcpConfigure", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		cfg := vinsConfig(obj, "DHCP")
+		for key, param := range map[string]string{"ip_start": "ipStart", "ip_end": "ipEnd", "default_gw": "defaultGw", "domain": "domain"} {
+			// options sent empty are reset
+			if _, ok := form[param]; ok {
+				cfg[key] = form.Get(param)
+			}
+		}
+		if _, ok := form["lease"]; ok {
+			cfg["lease"] = formInt(form, "lease")
+		}
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/vnfdevRestart", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/vnfdevRedeploy", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/netQos", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		qos, _ := obj["defaultQos"].(Object)
+		if qos == nil {
+			qos = Object{"inRate": 0, "inBurst": 0, "eRate": 0}
+			obj["defaultQos"] = qos
+		}
+		// limits missing from the request are left intact
+		for key, param := range map[string]string{"inRate": "ingress_rate", "inBurst": "ingress_birst", "eRate": "egress_rate"} {
+			if _, ok := form[param]; ok {
+				qos[key] = formInt(form, param)
+			}
+		}
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/staticRouteList", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		return vinsRoutes(obj), nil
+	}))
+	s.Handle(cloudapi+"/vins/staticRouteAdd", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		for _, key := range []string{"destination", "netmask", "gateway"} {
+			if form.Get(key) == "" {
+				return nil, errBadRequest("%s is required", key)
+			}
+		}
+		obj["routes"] = append(vinsRoutes(obj), Object{
+			"id":          s.NewID(),
+			"destination": form.Get("destination"),
+			"netmask":     form.Get("netmask"),
+			"gateway":     form.Get("gateway"),
+			"computeIds":  []int{},
+		})
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/staticRouteDel", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		routeID := formInt(form, "routeId")
+		res := make([]Object, 0)
+		for _, r := range vinsRoutes(obj) {
+			if r["id"] != routeID {
+				res = append(res, r)
+			}
+		}
+		if len(res) == len(vinsRoutes(obj)) {
+			return nil, errBadRequest("route %d not found", routeID)
+		}
+		obj["routes"] = res
+		return true, nil
+	}))
+	s.Handle(cloudapi+"/vins/natRuleList", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		return vinsConfig(obj, "NAT")["rules"], nil
+	}))
+	s.Handle(cloudapi+"/vins/natRuleAdd", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		cfg := vinsConfig(obj, "NAT")
+		portStart := formInt(form, "extPortStart")
+		portEnd := formIntDefault(form, "extPortEnd", portStart)
+		proto := form.Get("proto")
+		if proto == "" {
+			proto = "tcp"
+		}
+		id := s.NewID()
+		cfg["rules"] = append(cfg["rules"].([]Object), Object{
+			"id":              id,
+			"localIp":         form.Get("intIp"),
+			"localPort":       formInt(form, "intPort"),
+			"protocol":        proto,
+			"publicPortStart": portStart,
+			"publicPortEnd":   portEnd,
+			"vmId":            0,
+			"vmName":          "",
+		})
+		return id, nil
+	}))
+	s.Handle(cloudapi+"/vins/natRuleDel", updateVins(func(obj Object, form url.Values) (interface{}, error) {
+		cfg := vinsConfig(obj, "NAT")
+		ruleID := formInt(form, "ruleId")
+		res := make([]Object, 0)
+		for _, r := range cfg["rules"].([]Object) {
+			// ruleId -1 removes all rules
+			if ruleID == -1 || r["id"] == ruleID {
+				continue
+			}
+			res = append(res, r)
+		}
+		cfg["rules"] = res
+		return true, nil
+	}))
+}
+
+func (s *Server) newVins(form url.Values, accountID, gid interface{}) int {
+	id := s.NewID()
+	network := form.Get("ipcidr")
+	if network == "" {
+		network = "192.168.0.0/24"
+	}
+	s.Put(KindVins, id, Object{
+		"id":                 id,
+		"name":               form.Get("name"),
+		"accountId":          accountID,
+		"gid":                gid,
+		"rgId":               0,
+		"network":            network,
+		"desc":               form.Get("desc"),
+		"preReservationsNum": formInt(form, "preReservationsNum"),
+		"status":             "ENABLED",
+		"vnfs": Object{
+			"DHCP": Object{"config": Object{"network": network, "reservations": []Object{}}},
+			"GW":   Object{"config": Object{"ext_net_id": 0, "ext_net_ip": ""}},
+			"NAT":  Object{"config": Object{"network": network, "rules": []Object{}}},
+		},
+	})
+	return id
+}
+
+// vinsConfig returns config of the VINS virtual network function (DHCP, GW or NAT)
+func vinsConfig(obj Object, vnf string) Object {
+	return obj["vnfs"].(Object)[vnf].(Object)["config"].(Object)
+}
+
+func vinsRoutes(obj Object) []Object {
+	routes, _ := obj["routes"].([]Object)
+	return routes
+}
+
+func vinsConnectExtNet(obj Object, extNetID int, extIP string) {
+	cfg := vinsConfig(obj, "GW")
+	cfg["ext_net_id"] = extNetID
+	if extNetID != 0 && extIP == "" {
+		extIP = "10.0.0." + strconv.Itoa(obj["id"].(int)%250+2)
+	}
+	cfg["ext_net_ip"] = extIP
+}
+
+func updateVins(fn func(obj Object, form url.Values) (interface{}, error)) HandlerFunc {
+	return func(s *Server, form url.Values) (interface{}, error) {
+		id, err := requireInt(form, "vinsId")
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := s.Get(KindVins, id)
+		if !ok {
+			return nil, errNotFound(KindVins, id)
+		}
+		// API calls are serialized by the server, so the object may be modified in place
+		return fn(obj, form)
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+)
+
+// Default values of the retry policy, used when the corresponding provider
+// attributes are not set
+const (
+	DefaultRetryMaxAttempts = 5
+	DefaultRetryMinBackoff  = time.Second
+	DefaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// DefaultRetryStatusCodes lists HTTP status codes that are considered transient
+// and are retried by default
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy defines how failed requests to DECORT controller are retried
+type RetryPolicy struct {
+	MaxAttempts int           // total number of attempts, including the first one
+	MinBackoff  time.Duration // delay before the first retry
+	MaxBackoff  time.Duration // upper bound for a single delay between attempts
+	StatusCodes []int         // HTTP status codes which trigger a retry
+}
+
+// DefaultRetryPolicy returns retry policy with default settings
+func DefaultRetryPolicy() RetryPolicy {
+	codes := make([]int, len(DefaultRetryStatusCodes))
+	copy(codes, DefaultRetryStatusCodes)
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		MinBackoff:  DefaultRetryMinBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+		StatusCodes: codes,
+	}
+}
+
+func (p RetryPolicy) isRetryableStatus(code int) bool {
+	for _, c := range p.StatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns delay before the attempt following the given one (counting from 0).
+// Delay grows exponentially and is randomized in [d/2, d) to avoid thundering herd.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 0; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1)) //nolint:gosec
+}
+
+// retryAfter parses Retry-After header, which is either a number of seconds or an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	val := resp.Header.Get("Retry-After")
+	if val == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(val); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleepCtx waits for the specified duration or until context is done, whichever comes first
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// nonIdempotentWords are words of API names, which create or attach objects. A failed call of such
+// API may have been applied by the platform anyway, so that it is only repeated if the request
+// surely has not been processed: the connection was refused or the platform asked to come back later.
+var nonIdempotentWords = map[string]bool{
+	"create":  true,
+	"clone":   true,
+	"add":     true,
+	"attach":  true,
+	"reserve": true,
+}
+
+// isIdempotentAPI splits the last element of API path into camel case words, e.g.
+// "/restmachine/cloudapi/compute/diskAdd" into "disk" and "add", and looks them up
+// in nonIdempotentWords
+func isIdempotentAPI(path string) bool {
+	name := path[strings.LastIndex(path, "/")+1:]
+	start := 0
+	for i := 1; i <= len(name); i++ {
+		if i < len(name) && !unicode.IsUpper(rune(name[i])) {
+			continue
+		}
+		if nonIdempotentWords[strings.ToLower(name[start:i])] {
+			return false
+		}
+		start = i
+	}
+	return true
+}
+
+// isRetryableError reports whether request, which failed with the network error, may be repeated
+func isRetryableError(err error, idempotent bool) bool {
+	return idempotent || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isRetryableResponse reports whether request, which got response with the status code, may be repeated
+func (p RetryPolicy) isRetryableResponse(code int, idempotent bool) bool {
+	if !p.isRetryableStatus(code) {
+		return false
+	}
+	return idempotent || code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
+
+// RetryTransport is an http.RoundTripper which retries requests that failed
+// with a network error or with one of the retryable HTTP status codes.
+// Requests of APIs which create objects are only retried, if they have not reached the platform,
+// see isIdempotentAPI. All delays between attempts are aborted once request context is cancelled.
+type RetryTransport struct {
+	Next   http.RoundTripper
+	Policy RetryPolicy
+}
+
+// NewRetryTransport wraps next round tripper with the specified retry policy.
+// If next is nil, http.DefaultTransport is used.
+func NewRetryTransport(next http.RoundTripper, policy RetryPolicy) *RetryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RetryTransport{
+		Next:   next,
+		Policy: policy,
+	}
+}
+
+// RoundTrip sends a clone of the request on every attempt, so that the caller's request
+// is never modified, as required by http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	idempotent := isIdempotentAPI(req.URL.Path)
+
+	attempts := t.Policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for i := 0; ; i++ {
+		attemptReq := req.Clone(ctx)
+		if i > 0 && req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("RetryTransport: cannot retry %s %q, request body is not rewindable", req.Method, req.URL)
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := t.Next.RoundTrip(attemptReq)
+		last := i+1 >= attempts
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || last || !isRetryableError(err, idempotent) {
+				return nil, err
+			}
+			delay = t.Policy.backoff(i)
+		case !last && t.Policy.isRetryableResponse(resp.StatusCode, idempotent):
+			delay = t.Policy.backoff(i)
+			if ra, ok := retryAfter(resp); ok {
+				delay = ra
+			}
+			if delay > t.Policy.MaxBackoff {
+				delay = t.Policy.MaxBackoff
+			}
+		default:
+			return resp, err
+		}
+
+		// there is no point in waiting, if the context expires before the next attempt
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		if err != nil {
+			log.Warnf(ctx, "RetryTransport: %s %q failed: %v, retrying in %s (%d/%d)",
+				req.Method, req.URL, err, delay, i+1, attempts)
+		} else {
+			log.Warnf(ctx, "RetryTransport: %s %q got status code %d, retrying in %s (%d/%d)",
+				req.Method, req.URL, resp.StatusCode, delay, i+1, attempts)
+			// drain the body so that underlying connection can be reused
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepCtx(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 10 * time.Millisecond
+	return policy
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		api          string
+		statuses     []int // statuses returned by consecutive attempts, the last one is repeated
+		retryAfter   string
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{
+			name:         "success",
+			api:          "/restmachine/cloudapi/compute/get",
+			statuses:     []int{http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		{
+			name:         "transient errors",
+			api:          "/restmachine/cloudapi/compute/get",
+			statuses:     []int{http.StatusBadGateway, http.StatusInternalServerError, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "attempts exhausted",
+			api:          "/restmachine/cloudapi/compute/get",
+			statuses:     []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: DefaultRetryMaxAttempts,
+		},
+		{
+			name:         "not retryable status",
+			api:          "/restmachine/cloudapi/compute/get",
+			statuses:     []int{http.StatusNotFound},
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+		{
+			name:         "create is not retried on internal error",
+			api:          "/restmachine/cloudapi/kvmx86/create",
+			statuses:     []int{http.StatusInternalServerError, http.StatusOK},
+			wantStatus:   http.StatusInternalServerError,
+			wantAttempts: 1,
+		},
+		{
+			name:         "create is retried on service unavailable",
+			api:          "/restmachine/cloudapi/vins/createInRG",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "retry after is capped by max backoff",
+			api:          "/restmachine/cloudapi/compute/get",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "3600",
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&attempts, 1))
+				if n > len(tt.statuses) {
+					n = len(tt.statuses)
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: NewRetryTransport(nil, testRetryPolicy())}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			req, _ := http.NewRequestWithContext(ctx, "POST", server.URL+tt.api, strings.NewReader("id=1"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxBackoff = 5 * time.Second
+	client := &http.Client{Transport: NewRetryTransport(nil, policy)}
+
+	started := time.Now()
+	resp, err := client.Post(server.URL+"/restmachine/cloudapi/compute/get", "application/x-www-form-urlencoded", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("Retry-After of 1s is not honored, retried in %s", elapsed)
+	}
+}
+
+func TestRetryTransportContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MinBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	client := &http.Client{Transport: NewRetryTransport(nil, policy)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	started := time.Now()
+	req, _ := http.NewRequestWithContext(ctx, "POST", server.URL+"/restmachine/cloudapi/compute/get", nil)
+	_, err := client.Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(started); elapsed > 10*time.Second {
+		t.Errorf("delay between attempts is not aborted by cancelled context, returned in %s", elapsed)
+	}
+}
+
+func TestRetryTransportContextDeadline(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MinBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	client := &http.Client{Transport: NewRetryTransport(nil, policy)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", server.URL+"/restmachine/cloudapi/compute/get", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || atomic.LoadInt32(&attempts) != 1 {
+		t.Errorf("got status %d after %d attempts, want the first response, as the delay exceeds the deadline",
+			resp.StatusCode, atomic.LoadInt32(&attempts))
+	}
+}
+
+func TestRetryTransportBodyRewind(t *testing.T) {
+	const body = "computeId=42&name=test"
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := ioutil.ReadAll(r.Body)
+		if string(got) != body {
+			t.Errorf("attempt %d got body %q, want %q", atomic.LoadInt32(&attempts)+1, got, body)
+		}
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewRetryTransport(nil, testRetryPolicy())
+	req, _ := http.NewRequest("POST", server.URL+"/restmachine/cloudapi/compute/update", strings.NewReader(body))
+	origBody := req.Body
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if atomic.LoadInt32(&attempts) != 3 {
+		t.Errorf("got %d attempts, want 3", atomic.LoadInt32(&attempts))
+	}
+	if req.Body != origBody {
+		t.Error("body of the caller's request is replaced")
+	}
+}
+
+func TestRetryTransportNetworkError(t *testing.T) {
+	// listener is closed, so that connections to its address are refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	var attempts int32
+	counting := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	for _, api := range []string{"/restmachine/cloudapi/compute/get", "/restmachine/cloudapi/kvmx86/create"} {
+		atomic.StoreInt32(&attempts, 0)
+		transport := NewRetryTransport(counting, testRetryPolicy())
+		req, _ := http.NewRequest("POST", "http://"+addr+api, nil)
+		if _, err := transport.RoundTrip(req); err == nil {
+			t.Fatalf("%s: expected error", api)
+		}
+		// refused connection surely has not created anything, so that it is retried for create as well
+		if got := atomic.LoadInt32(&attempts); got != DefaultRetryMaxAttempts {
+			t.Errorf("%s: got %d attempts, want %d", api, got, DefaultRetryMaxAttempts)
+		}
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestIsIdempotentAPI(t *testing.T) {
+	tests := []struct {
+		api  string
+		want bool
+	}{
+		{"/restmachine/cloudapi/compute/get", true},
+		{"/restmachine/cloudapi/compute/update", true},
+		{"/restmachine/cloudapi/compute/diskDetach", true},
+		{"/restmachine/cloudapi/lb/frontendBind", true},
+		{"/restmachine/cloudapi/kvmx86/create", false},
+		{"/restmachine/cloudapi/vins/createInRG", false},
+		{"/restmachine/cloudapi/compute/diskAdd", false},
+		{"/restmachine/cloudapi/compute/netAttach", false},
+		{"/restmachine/cloudapi/compute/clone", false},
+		{"/restmachine/cloudapi/vins/ipReserve", false},
+		{"/restmachine/cloudapi/lb/backendServerAdd", false},
+		{"/restmachine/cloudbroker/compute/snapshotCreate", false},
+	}
+
+	for _, tt := range tests {
+		if got := isIdempotentAPI(tt.api); got != tt.want {
+			t.Errorf("isIdempotentAPI(%q) = %v, want %v", tt.api, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			if got := policy.backoff(attempt); got < want/2 || got > want {
+				t.Errorf("backoff(%d) = %s, want in [%s, %s]", attempt, got, want/2, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// OmitSecrets reports whether computed secrets (e.g. kubeconfig or guest OS user passwords)
+// should be left out of resource state. Data sources, which exist to read these secrets
+// (e.g. decort_k8s_kubeconfig), always store them in state, so they are not a way around it.
+func (config *ControllerCfg) OmitSecrets() bool {
+	return config.omit_secrets
+}
+
+// OmitSecretsOf reports whether computed secrets should be left out of state for the provider
+// meta. API callers, which are not configured from the provider schema, keep secrets in state.
+func OmitSecretsOf(m interface{}) bool {
+	if holder, ok := m.(interface{ OmitSecrets() bool }); ok {
+		return holder.OmitSecrets()
+	}
+	return false
+}
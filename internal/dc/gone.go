@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Gone removes resource, whose object was deleted outside of Terraform, from the state
+// and returns a warning explaining why. It is used by Read handlers instead of restoring
+// or recreating the object, when drift policy does not allow that.
+func Gone(d *schema.ResourceData, kind string, status string) diag.Diagnostics {
+	id := d.Id()
+	d.SetId("")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s ID %s is in status %s and has been removed from the state", kind, id, status),
+		Detail:   "The object was deleted outside of Terraform. It is not restored during refresh because of drift_policy provider setting.",
+	}}
+}
+
+// ParentGone removes resource, whose parent object was deleted outside of Terraform, from the
+// state and returns a warning explaining why. It is used by Read and Delete handlers of child
+// objects (e.g. network interfaces of compute), which do not exist without their parent.
+func ParentGone(d *schema.ResourceData, kind string, parentKind string, status string) diag.Diagnostics {
+	id := d.Id()
+	d.SetId("")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s ID %s has been removed from the state, because its %s is in status %s", kind, id, parentKind, status),
+		Detail:   "The parent object was deleted outside of Terraform.",
+	}}
+}
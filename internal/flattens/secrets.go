@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flattens
+
+// SecretKeys lists keys of flattened values, which hold secrets
+var SecretKeys = []string{"password", "passwd"}
+
+// OmitSecrets returns copy of flattened value (e.g. d.Get("os_users")) with values of
+// SecretKeys replaced by empty strings at any depth
+func OmitSecrets(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, item := range val {
+			res[i] = OmitSecrets(item)
+		}
+		return res
+	case []map[string]interface{}:
+		res := make([]interface{}, len(val))
+		for i, item := range val {
+			res[i] = OmitSecrets(item)
+		}
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if isSecretKey(k) {
+				res[k] = ""
+				continue
+			}
+			res[k] = OmitSecrets(item)
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+func isSecretKey(key string) bool {
+	for _, k := range SecretKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -20,4 +20,8 @@ limitations under the License.
 
 package location
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const LocationsListAPI = "/restmachine/cloudapi/locations/list" // Returns list of GridRecord on success
+
+var log = logging.New("location")
@@ -26,18 +26,17 @@ import (
 	"fmt"
 	"net/url"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 var DefaultGridID int
 
 func UtilityLocationGetDefaultGridID(ctx context.Context, m interface{}) (int, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 
-	log.Debug("utilityLocationGetDefaultGridID: retrieving locations list")
+	log.Debug(ctx, "utilityLocationGetDefaultGridID: retrieving locations list")
 	apiResp, err := c.DecortAPICall(ctx, "POST", LocationsListAPI, urlValues)
 	if err != nil {
 		return 0, err
@@ -55,7 +54,7 @@ func UtilityLocationGetDefaultGridID(ctx context.Context, m interface{}) (int, e
 	}
 
 	DefaultGridID = locList[0].GridID
-	log.Debugf("utilityLocationGetDefaultGridID: default location GridID %d, name %s", DefaultGridID, locList[0].Name)
+	log.Debugf(ctx, "utilityLocationGetDefaultGridID: default location GridID %d, name %s", DefaultGridID, locList[0].Name)
 
 	return DefaultGridID, nil
 }
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging writes provider logs with tflog. Every package of the provider logs to
+// its own subsystem (e.g. "controller" or "kvmvm"), so that verbosity is driven by TF_LOG
+// and TF_LOG_PROVIDER, and may be tuned per subsystem with TF_LOG_PROVIDER_DECORT_<NAME>,
+// e.g. TF_LOG_PROVIDER_DECORT_KVMVM=TRACE.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// EnvLogPrefix is a prefix of environment variables setting levels of the subsystems
+const EnvLogPrefix = "TF_LOG_PROVIDER_DECORT"
+
+// Logger writes printf-style messages to a single subsystem of the provider log
+type Logger struct {
+	subsystem string
+}
+
+// New returns logger of the subsystem
+func New(subsystem string) Logger {
+	return Logger{subsystem: subsystem}
+}
+
+func (l Logger) context(ctx context.Context) context.Context {
+	// subsystem is created on every call, as handlers may receive context from SDK,
+	// which knows nothing about provider subsystems. Offset skips the Logger method
+	// itself, so that @caller points to the code which logs the message.
+	return tflog.NewSubsystem(ctx, l.subsystem,
+		tflog.WithLevelFromEnv(EnvLogPrefix, l.subsystem),
+		tflog.WithAdditionalLocationOffset(2),
+	)
+}
+
+func (l Logger) Tracef(ctx context.Context, format string, args ...interface{}) {
+	tflog.SubsystemTrace(l.context(ctx), l.subsystem, fmt.Sprintf(format, args...))
+}
+
+func (l Logger) Debugf(ctx context.Context, format string, args ...interface{}) {
+	tflog.SubsystemDebug(l.context(ctx), l.subsystem, fmt.Sprintf(format, args...))
+}
+
+func (l Logger) Infof(ctx context.Context, format string, args ...interface{}) {
+	tflog.SubsystemInfo(l.context(ctx), l.subsystem, fmt.Sprintf(format, args...))
+}
+
+func (l Logger) Warnf(ctx context.Context, format string, args ...interface{}) {
+	tflog.SubsystemWarn(l.context(ctx), l.subsystem, fmt.Sprintf(format, args...))
+}
+
+func (l Logger) Errorf(ctx context.Context, format string, args ...interface{}) {
+	tflog.SubsystemError(l.context(ctx), l.subsystem, fmt.Sprintf(format, args...))
+}
+
+func (l Logger) Debug(ctx context.Context, args ...interface{}) {
+	tflog.SubsystemDebug(l.context(ctx), l.subsystem, fmt.Sprint(args...))
+}
+
+func (l Logger) Warn(ctx context.Context, args ...interface{}) {
+	tflog.SubsystemWarn(l.context(ctx), l.subsystem, fmt.Sprint(args...))
+}
+
+func (l Logger) Error(ctx context.Context, args ...interface{}) {
+	tflog.SubsystemError(l.context(ctx), l.subsystem, fmt.Sprint(args...))
+}
+
+// SensitiveFields lists names of request parameters and response fields, which values
+// are never written to the log
+var SensitiveFields = []string{
+	"authkey",
+	"password",
+	"passwd",
+	"kubeconfig",
+	"jwt",
+	"app_secret",
+	"client_secret",
+	"token",
+}
+
+const masked = "***"
+
+var (
+	// password=secret&...
+	formFieldRe = regexp.MustCompile(`(?i)\b(` + strings.Join(SensitiveFields, "|") + `)=[^&\s]*`)
+	// "password": "secret"
+	jsonFieldRe = regexp.MustCompile(`(?i)"(` + strings.Join(SensitiveFields, "|") + `)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// client-key-data: ... in kubeconfig returned as plain text
+	kubeconfigRe = regexp.MustCompile(`(?m)((?:client-key-data|client-certificate-data|certificate-authority-data|token|password):[ \t]*)\S+`)
+)
+
+// Mask replaces values of SensitiveFields found in URL-encoded request parameters, JSON
+// or kubeconfig with asterisks, so that the result may be written to the log
+func Mask(s string) string {
+	s = formFieldRe.ReplaceAllString(s, "${1}="+masked)
+	s = jsonFieldRe.ReplaceAllString(s, `"${1}"${2}"`+masked+`"`)
+	s = kubeconfigRe.ReplaceAllString(s, "${1}"+masked)
+	return s
+}
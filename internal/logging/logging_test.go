@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import "testing"
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "form",
+			in:   "username=admin&password=s3cr%26t&computeId=1",
+			want: "username=admin&password=***&computeId=1",
+		},
+		{
+			name: "form case insensitive",
+			in:   "Password=s3cret&authkey=abc",
+			want: "Password=***&authkey=***",
+		},
+		{
+			name: "form oauth2",
+			in:   "grant_type=client_credentials&client_id=app&client_secret=s3cret&response_type=id_token",
+			want: "grant_type=client_credentials&client_id=app&client_secret=***&response_type=id_token",
+		},
+		{
+			name: "form empty value",
+			in:   "password=&name=vm",
+			want: "password=***&name=vm",
+		},
+		{
+			name: "json",
+			in:   `{"name": "vm", "password": "s3cret", "login": "user"}`,
+			want: `{"name": "vm", "password": "***", "login": "user"}`,
+		},
+		{
+			name: "json escaped quotes",
+			in:   `{"passwd":"s3\"cr\\et","id":1}`,
+			want: `{"passwd":"***","id":1}`,
+		},
+		{
+			name: "json list of users",
+			in:   `[{"login":"root","password":"a"},{"login":"user","password":"b"}]`,
+			want: `[{"login":"root","password":"***"},{"login":"user","password":"***"}]`,
+		},
+		{
+			name: "json kubeconfig string",
+			in:   `{"kubeconfig": "apiVersion: v1\nusers:\n- user:\n    token: abc\n"}`,
+			want: `{"kubeconfig": "***"}`,
+		},
+		{
+			name: "json non-string values are kept",
+			in:   `{"token": null, "jwt": 0}`,
+			want: `{"token": null, "jwt": 0}`,
+		},
+		{
+			name: "kubeconfig",
+			in: "apiVersion: v1\n" +
+				"clusters:\n" +
+				"- cluster:\n" +
+				"    certificate-authority-data: LS0tLS1CRUdJTg==\n" +
+				"    server: https://10.0.0.1:6443\n" +
+				"users:\n" +
+				"- name: admin\n" +
+				"  user:\n" +
+				"    client-certificate-data: LS0tLS1DRVJU\n" +
+				"    client-key-data: LS0tLS1LRVk=\n" +
+				"    token:\tabc.def\n",
+			want: "apiVersion: v1\n" +
+				"clusters:\n" +
+				"- cluster:\n" +
+				"    certificate-authority-data: ***\n" +
+				"    server: https://10.0.0.1:6443\n" +
+				"users:\n" +
+				"- name: admin\n" +
+				"  user:\n" +
+				"    client-certificate-data: ***\n" +
+				"    client-key-data: ***\n" +
+				"    token:\t***\n",
+		},
+		{
+			name: "nothing sensitive",
+			in:   "computeId=1&name=token-vm&desc=password%20is%20not%20here",
+			want: "computeId=1&name=token-vm&desc=password%20is%20not%20here",
+		},
+		{
+			name: "empty",
+			in:   "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mask(tt.in); got != tt.want {
+				t.Errorf("Mask(%q)\n got %q\nwant %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
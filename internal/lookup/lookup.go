@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lookup implements cached existence checks of DECORT objects referenced by
+// resources, e.g. rg_id or image_id. Every .../list API is called at most once per TTL
+// for each provider instance, instead of once per resource being planned or created.
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+)
+
+var log = logging.New("lookup")
+
+// DefaultTTL is how long a fetched list of IDs is considered fresh
+const DefaultTTL = 60 * time.Second
+
+// Cache keeps lists of IDs of DECORT objects fetched with a single API caller
+type Cache struct {
+	caller controller.APICaller
+	TTL    time.Duration
+
+	mu    sync.Mutex // guards lists
+	lists map[string]*idList
+}
+
+type idList struct {
+	mu      sync.Mutex // serializes fetches, so that concurrent lookups share a single API call
+	ids     map[int]struct{}
+	fetched time.Time
+}
+
+// For returns the cache of the provider instance, which owns the API caller. The cache is
+// kept in controller.Caches of the caller and is dropped together with it. Callers, which
+// keep no caches, get a new empty cache every time.
+func For(caller controller.APICaller) *Cache {
+	return controller.CachesOf(caller).Get("lookup", func() interface{} {
+		return New(caller)
+	}).(*Cache)
+}
+
+// New creates empty cache bound to the API caller
+func New(caller controller.APICaller) *Cache {
+	return &Cache{
+		caller: caller,
+		TTL:    DefaultTTL,
+		lists:  make(map[string]*idList),
+	}
+}
+
+// RG reports whether resource group with the ID exists
+func (c *Cache) RG(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/rg/list", &url.Values{}, "id", id)
+}
+
+// Image reports whether image with the ID exists
+func (c *Cache) Image(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/image/list", &url.Values{}, "id", id)
+}
+
+// Vins reports whether ViNS with the ID exists
+func (c *Cache) Vins(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/vins/list", &url.Values{}, "id", id)
+}
+
+// ExtNet reports whether external network with the ID exists
+func (c *Cache) ExtNet(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/extnet/list", &url.Values{}, "id", id)
+}
+
+// ExtNetForAccount reports whether external network with the ID is available to the account
+func (c *Cache) ExtNetForAccount(ctx context.Context, accountID int, id int) (bool, error) {
+	urlValues := &url.Values{}
+	urlValues.Add("accountId", strconv.Itoa(accountID))
+	return c.exists(ctx, "/restmachine/cloudapi/extnet/list", urlValues, "id", id)
+}
+
+// Account reports whether account with the ID exists
+func (c *Cache) Account(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/account/list", &url.Values{}, "id", id)
+}
+
+// GID reports whether grid (location) with the ID exists
+func (c *Cache) GID(ctx context.Context, gid int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/locations/list", &url.Values{}, "gid", gid)
+}
+
+// K8s reports whether k8s cluster with the ID exists
+func (c *Cache) K8s(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/k8s/list", &url.Values{}, "id", id)
+}
+
+// K8CI reports whether k8s catalog item with the ID exists
+func (c *Cache) K8CI(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/k8ci/list", &url.Values{}, "id", id)
+}
+
+// LB reports whether load balancer with the ID exists
+func (c *Cache) LB(ctx context.Context, id int) (bool, error) {
+	return c.exists(ctx, "/restmachine/cloudapi/lb/list", &url.Values{}, "id", id)
+}
+
+func (c *Cache) exists(ctx context.Context, api string, urlValues *url.Values, field string, id int) (bool, error) {
+	key := api + "?" + urlValues.Encode()
+
+	c.mu.Lock()
+	list, ok := c.lists[key]
+	if !ok {
+		list = &idList{}
+		c.lists[key] = list
+	}
+	c.mu.Unlock()
+
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	started := time.Now()
+	if list.ids == nil || time.Since(list.fetched) > c.TTL {
+		if err := c.fetch(ctx, list, api, urlValues, field); err != nil {
+			return false, err
+		}
+	}
+	if _, ok := list.ids[id]; ok {
+		return true, nil
+	}
+
+	// the object may have been created after the list was fetched, e.g. by another
+	// resource of the same apply, so a miss is always confirmed with a fresh list
+	if list.fetched.Before(started) {
+		if err := c.fetch(ctx, list, api, urlValues, field); err != nil {
+			return false, err
+		}
+	}
+	_, ok = list.ids[id]
+	return ok, nil
+}
+
+func (c *Cache) fetch(ctx context.Context, list *idList, api string, urlValues *url.Values, field string) error {
+	log.Debugf(ctx, "lookup: fetching %s", api)
+
+	listRaw, err := c.caller.DecortAPICall(ctx, "POST", api, urlValues)
+	if err != nil {
+		return err
+	}
+
+	items := []map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(listRaw), &items); err != nil {
+		return fmt.Errorf("cannot parse response of %s: %w", api, err)
+	}
+
+	ids := make(map[int]struct{}, len(items))
+	for _, item := range items {
+		var id int
+		if err := json.Unmarshal(item[field], &id); err != nil {
+			return fmt.Errorf("cannot parse %q of an item returned by %s: %w", field, api, err)
+		}
+		ids[id] = struct{}{}
+	}
+
+	list.ids = ids
+	list.fetched = time.Now()
+	return nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+)
+
+// listCaller serves lists of objects with the given IDs and counts list calls
+type listCaller struct {
+	mu    sync.Mutex
+	ids   map[string][]int
+	calls map[string]int
+	err   error
+}
+
+func newListCaller() *listCaller {
+	return &listCaller{ids: map[string][]int{}, calls: map[string]int{}}
+}
+
+func (c *listCaller) set(api string, ids ...int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids[api] = ids
+}
+
+func (c *listCaller) count(api string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[api]
+}
+
+func (c *listCaller) DecortAPICall(ctx context.Context, method string, api string, values *url.Values) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[api]++
+	if c.err != nil {
+		return "", c.err
+	}
+	items := []map[string]int{}
+	for _, id := range c.ids[api] {
+		items = append(items, map[string]int{"id": id, "gid": id})
+	}
+	b, _ := json.Marshal(items)
+	return string(b), nil
+}
+
+func (c *listCaller) GetDecortUsername() string {
+	return "user"
+}
+
+// cachingCaller keeps caches as provider meta does
+type cachingCaller struct {
+	*listCaller
+	caches controller.Caches
+}
+
+func (c *cachingCaller) Caches() *controller.Caches {
+	return &c.caches
+}
+
+const rgListAPI = "/restmachine/cloudapi/rg/list"
+
+func TestCacheExists(t *testing.T) {
+	tests := []struct {
+		name string
+		// ids listed by the first and by all later list calls
+		before, after []int
+		ttl           time.Duration
+		sleep         time.Duration
+		lookups       []int
+		want          []bool
+		wantCalls     int
+	}{
+		{
+			name:      "hits share one list call",
+			before:    []int{1, 2},
+			after:     []int{1, 2},
+			ttl:       time.Minute,
+			lookups:   []int{1, 2, 1},
+			want:      []bool{true, true, true},
+			wantCalls: 1,
+		},
+		{
+			name:      "miss is confirmed with a fresh list",
+			before:    []int{1},
+			after:     []int{1, 2},
+			ttl:       time.Minute,
+			lookups:   []int{1, 2},
+			want:      []bool{true, true},
+			wantCalls: 2,
+		},
+		{
+			name:      "missing object",
+			before:    []int{1},
+			after:     []int{1},
+			ttl:       time.Minute,
+			lookups:   []int{1, 3},
+			want:      []bool{true, false},
+			wantCalls: 2,
+		},
+		{
+			name:      "list fetched by the lookup is not fetched again on miss",
+			before:    []int{1},
+			after:     []int{1},
+			ttl:       time.Minute,
+			lookups:   []int{3, 1},
+			want:      []bool{false, true},
+			wantCalls: 1,
+		},
+		{
+			name:      "stale list is fetched again",
+			before:    []int{1},
+			after:     []int{1},
+			ttl:       time.Millisecond,
+			sleep:     5 * time.Millisecond,
+			lookups:   []int{1, 1},
+			want:      []bool{true, true},
+			wantCalls: 2,
+		},
+		{
+			name:      "deleted object is not found after TTL",
+			before:    []int{1},
+			after:     []int{},
+			ttl:       time.Millisecond,
+			sleep:     5 * time.Millisecond,
+			lookups:   []int{1, 1},
+			want:      []bool{true, false},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller := newListCaller()
+			caller.set(rgListAPI, tt.before...)
+			cache := New(caller)
+			cache.TTL = tt.ttl
+
+			for i, id := range tt.lookups {
+				if i > 0 {
+					time.Sleep(tt.sleep)
+				}
+				got, err := cache.RG(context.Background(), id)
+				if err != nil {
+					t.Fatalf("RG(%d) error = %v", id, err)
+				}
+				if got != tt.want[i] {
+					t.Errorf("RG(%d) = %t, want %t", id, got, tt.want[i])
+				}
+				caller.set(rgListAPI, tt.after...)
+			}
+
+			if got := caller.count(rgListAPI); got != tt.wantCalls {
+				t.Errorf("%s called %d times, want %d", rgListAPI, got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCacheKeys(t *testing.T) {
+	caller := newListCaller()
+	caller.set(rgListAPI, 1)
+	caller.set("/restmachine/cloudapi/locations/list", 7)
+	caller.set("/restmachine/cloudapi/extnet/list", 3)
+	cache := New(caller)
+	ctx := context.Background()
+
+	if ok, _ := cache.RG(ctx, 7); ok {
+		t.Error("RG(7) found ID of another object kind")
+	}
+	if ok, _ := cache.GID(ctx, 7); !ok {
+		t.Error("GID(7) = false, want true")
+	}
+
+	// lists filtered by account are cached separately from unfiltered ones
+	cache.ExtNet(ctx, 3)
+	cache.ExtNetForAccount(ctx, 1, 3)
+	cache.ExtNetForAccount(ctx, 1, 3)
+	if got := caller.count("/restmachine/cloudapi/extnet/list"); got != 2 {
+		t.Errorf("extnet/list called %d times, want 2", got)
+	}
+}
+
+func TestCacheError(t *testing.T) {
+	caller := newListCaller()
+	caller.err = errors.New("connection refused")
+	if _, err := New(caller).RG(context.Background(), 1); !errors.Is(err, caller.err) {
+		t.Errorf("RG() error = %v, want %v", err, caller.err)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	caller := newListCaller()
+	caller.set(rgListAPI, 1)
+	cache := New(caller)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := cache.RG(context.Background(), 1); !ok || err != nil {
+				t.Errorf("RG(1) = %t, %v", ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := caller.count(rgListAPI); got != 1 {
+		t.Errorf("%s called %d times by concurrent lookups, want 1", rgListAPI, got)
+	}
+}
+
+func TestFor(t *testing.T) {
+	first := &cachingCaller{listCaller: newListCaller()}
+	second := &cachingCaller{listCaller: newListCaller()}
+
+	if For(first) != For(first) {
+		t.Error("For() returned different caches for the same provider meta")
+	}
+	if For(first) == For(second) {
+		t.Error("For() shared cache between provider metas")
+	}
+
+	plain := newListCaller()
+	if For(plain) == For(plain) {
+		t.Error("For() kept cache for a caller without caches")
+	}
+}
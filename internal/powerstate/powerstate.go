@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package powerstate brings computes to the running, stopped or paused power state and waits
+// for their tech status to settle after every transition. It is shared by compute resources of
+// cloudapi and cloudbroker, which pass the compute API client they are bound to.
+package powerstate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+var log = logging.New("powerstate")
+
+// Power states of compute, reported by power_state attribute
+const (
+	Running = "running"
+	Stopped = "stopped"
+	Paused  = "paused"
+)
+
+// Of maps compute tech status to power state. Transitional tech statuses
+// (e.g. STARTING or MIGRATING) have no power state.
+func Of(techStatus string) string {
+	switch techStatus {
+	case techstatus.Started:
+		return Running
+	case techstatus.Stopped:
+		return Stopped
+	case techstatus.Paused:
+		return Paused
+	}
+	return ""
+}
+
+// Target returns power state compute resource should be brought to, or empty string if power
+// state is not changed. power_state takes precedence over deprecated started and pause attributes.
+// New compute is brought to fallback power state, unless configured otherwise.
+func Target(d *schema.ResourceData, isNew bool, fallback string) string {
+	if powerState := d.Get("power_state").(string); powerState != "" && (isNew || d.HasChange("power_state")) {
+		return powerState
+	}
+
+	if isNew {
+		if d.Get("pause").(bool) {
+			return Paused
+		}
+		if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() {
+			if started := config.GetAttr("started"); started.IsKnown() && !started.IsNull() {
+				if started.True() {
+					return Running
+				}
+				return Stopped
+			}
+		}
+		return fallback
+	}
+
+	if d.HasChange("pause") {
+		if d.Get("pause").(bool) {
+			return Paused
+		}
+		return Running
+	}
+	if d.HasChange("started") {
+		if d.Get("started").(bool) {
+			return Running
+		}
+		return Stopped
+	}
+	return ""
+}
+
+// Configured returns power state set in configuration of compute resource, either directly by
+// power_state or by deprecated pause and started attributes, or empty string if it is not set.
+// power_state and started are computed, so that their planned values may come from state.
+func Configured(d *schema.ResourceDiff) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+
+	if powerState := config.GetAttr("power_state"); powerState.IsKnown() && !powerState.IsNull() {
+		return powerState.AsString()
+	}
+	if pause := config.GetAttr("pause"); pause.IsKnown() && !pause.IsNull() && pause.True() {
+		return Paused
+	}
+	if started := config.GetAttr("started"); started.IsKnown() && !started.IsNull() {
+		if started.True() {
+			return Running
+		}
+		return Stopped
+	}
+	return ""
+}
+
+// Set brings compute to the target power state step by step, e.g. stopped compute is started
+// and then paused. After every step it waits for compute tech status to settle, so that dependent
+// resources are not configured against a half-started compute. Waiting is bound by the resource
+// timeout carried by ctx. With force running compute is powered off rather than shut down.
+// Power state compute had before is returned.
+func Set(ctx context.Context, api *compute.Compute, computeID uint64, target string, force bool) (string, error) {
+	// compute may be in transition already, so wait for it to settle first
+	rec, err := api.WaitTechStatus(ctx, computeID, techstatus.Started, techstatus.Stopped, techstatus.Paused)
+	if err != nil {
+		return "", err
+	}
+	previous := Of(rec.TechStatus)
+	current := previous
+
+	for current != target {
+		log.Debugf(ctx, "powerstate.Set: compute %d power state %s, target %s", computeID, current, target)
+
+		var want string
+		switch {
+		case current == Stopped:
+			_, err = api.Start(ctx, compute.StartRequest{ComputeID: computeID})
+			want = techstatus.Started
+		case current == Paused:
+			_, err = api.Resume(ctx, compute.IDRequest{ComputeID: computeID})
+			want = techstatus.Started
+		case target == Stopped:
+			_, err = api.Stop(ctx, compute.StopRequest{ComputeID: computeID, Force: force})
+			want = techstatus.Stopped
+		case target == Paused:
+			_, err = api.Pause(ctx, compute.IDRequest{ComputeID: computeID})
+			want = techstatus.Paused
+		default:
+			return previous, fmt.Errorf("unknown power state %q", target)
+		}
+		if err != nil {
+			return previous, err
+		}
+
+		rec, err = api.WaitTechStatus(ctx, computeID, want)
+		if err != nil {
+			return previous, err
+		}
+		current = Of(rec.TechStatus)
+	}
+
+	return previous, nil
+}
+
+// WithStopped runs op against stopped compute. Compute is stopped for op, if needed, and
+// brought back to its previous power state afterwards, even if op fails, so that a failed
+// update does not leave running compute stopped. Previous power state is returned, so that
+// callers may report a restart of running compute.
+func WithStopped(ctx context.Context, api *compute.Compute, computeID uint64, force bool, op func() error) (string, error) {
+	previous, err := Set(ctx, api, computeID, Stopped, force)
+	if err != nil {
+		return previous, err
+	}
+
+	opErr := op()
+
+	if previous != Stopped {
+		log.Debugf(ctx, "powerstate.WithStopped: bringing compute %d back to power state %s", computeID, previous)
+		if _, err := Set(ctx, api, computeID, previous, force); err != nil {
+			if opErr != nil {
+				return previous, fmt.Errorf("%w; failed to bring compute %d back to power state %s: %v", opErr, computeID, previous, err)
+			}
+			return previous, err
+		}
+	}
+	return previous, opErr
+}
+
+// Reset resets running compute, as if it was powered off and on, and waits for it to be
+// running again. Compute in other power states cannot be reset.
+func Reset(ctx context.Context, api *compute.Compute, computeID uint64) error {
+	rec, err := api.WaitTechStatus(ctx, computeID, techstatus.Started, techstatus.Stopped, techstatus.Paused)
+	if err != nil {
+		return err
+	}
+	if powerState := Of(rec.TechStatus); powerState != Running {
+		return fmt.Errorf("can't reset compute %d in power state %s, it must be %s", computeID, powerState, Running)
+	}
+
+	log.Debugf(ctx, "powerstate.Reset: resetting compute %d", computeID)
+	if _, err := api.Reset(ctx, compute.IDRequest{ComputeID: computeID}); err != nil {
+		return err
+	}
+	_, err = api.WaitTechStatus(ctx, computeID, techstatus.Started)
+	return err
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package powerstate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+const prefix = "/restmachine/cloudapi/compute/"
+
+// newCompute puts compute in the given tech status into the fake controller
+func newCompute(t *testing.T, techStatus string) (*fake.Server, *compute.Compute, uint64) {
+	t.Helper()
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	id := s.NewID()
+	s.Put(fake.KindCompute, id, fake.Object{"id": id, "name": "vm", "techStatus": techStatus})
+	return s, compute.New(s.Client(), "/restmachine/cloudapi"), uint64(id)
+}
+
+func techStatusOf(t *testing.T, s *fake.Server, id uint64) string {
+	t.Helper()
+	obj, ok := s.Get(fake.KindCompute, int(id))
+	if !ok {
+		t.Fatalf("compute %d not found", id)
+	}
+	return obj["techStatus"].(string)
+}
+
+// calls returns the number of calls of every compute power endpoint
+func calls(s *fake.Server) map[string]int {
+	res := make(map[string]int)
+	for _, api := range []string{"start", "stop", "pause", "resume", "reset"} {
+		if n := s.Calls(prefix + api); n != 0 {
+			res[api] = n
+		}
+	}
+	return res
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		target   string
+		want     string
+		wantCall map[string]int
+	}{
+		{name: "start", from: techstatus.Stopped, target: Running, want: techstatus.Started, wantCall: map[string]int{"start": 1}},
+		{name: "stop", from: techstatus.Started, target: Stopped, want: techstatus.Stopped, wantCall: map[string]int{"stop": 1}},
+		{name: "pause stopped", from: techstatus.Stopped, target: Paused, want: techstatus.Paused, wantCall: map[string]int{"start": 1, "pause": 1}},
+		{name: "stop paused", from: techstatus.Paused, target: Stopped, want: techstatus.Stopped, wantCall: map[string]int{"resume": 1, "stop": 1}},
+		{name: "resume", from: techstatus.Paused, target: Running, want: techstatus.Started, wantCall: map[string]int{"resume": 1}},
+		{name: "unchanged", from: techstatus.Started, target: Running, want: techstatus.Started, wantCall: map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, api, id := newCompute(t, tt.from)
+
+			previous, err := Set(context.Background(), api, id, tt.target, false)
+			if err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if previous != Of(tt.from) {
+				t.Errorf("Set() previous power state = %q, want %q", previous, Of(tt.from))
+			}
+			if got := techStatusOf(t, s, id); got != tt.want {
+				t.Errorf("tech status = %q, want %q", got, tt.want)
+			}
+			got := calls(s)
+			if len(got) != len(tt.wantCall) {
+				t.Fatalf("Set() called %v, want %v", got, tt.wantCall)
+			}
+			for api, n := range tt.wantCall {
+				if got[api] != n {
+					t.Errorf("Set() called %v, want %v", got, tt.wantCall)
+				}
+			}
+		})
+	}
+}
+
+func TestSetUnknown(t *testing.T) {
+	_, api, id := newCompute(t, techstatus.Started)
+	if _, err := Set(context.Background(), api, id, "hibernated", false); err == nil {
+		t.Fatal("Set() succeeded for unknown power state, want error")
+	}
+}
+
+func TestWithStopped(t *testing.T) {
+	opErr := errors.New("rollback failed")
+
+	tests := []struct {
+		name  string
+		from  string
+		opErr error
+	}{
+		{name: "running", from: techstatus.Started},
+		{name: "paused", from: techstatus.Paused},
+		{name: "stopped", from: techstatus.Stopped},
+		{name: "running op failed", from: techstatus.Started, opErr: opErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, api, id := newCompute(t, tt.from)
+
+			opStatus := ""
+			previous, err := WithStopped(context.Background(), api, id, false, func() error {
+				opStatus = techStatusOf(t, s, id)
+				return tt.opErr
+			})
+			if !errors.Is(err, tt.opErr) {
+				t.Errorf("WithStopped() error = %v, want %v", err, tt.opErr)
+			}
+			if previous != Of(tt.from) {
+				t.Errorf("WithStopped() previous power state = %q, want %q", previous, Of(tt.from))
+			}
+			if opStatus != techstatus.Stopped {
+				t.Errorf("op ran against compute in %q, want %q", opStatus, techstatus.Stopped)
+			}
+			if got := techStatusOf(t, s, id); got != tt.from {
+				t.Errorf("tech status after WithStopped() = %q, want %q", got, tt.from)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	s, api, id := newCompute(t, techstatus.Started)
+	if err := Reset(context.Background(), api, id); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if n := s.Calls(prefix + "reset"); n != 1 {
+		t.Errorf("Reset() called compute/reset %d times, want 1", n)
+	}
+
+	s, api, id = newCompute(t, techstatus.Stopped)
+	if err := Reset(context.Background(), api, id); err == nil {
+		t.Fatal("Reset() of stopped compute succeeded, want error")
+	}
+	if n := s.Calls(prefix + "reset"); n != 0 {
+		t.Errorf("Reset() of stopped compute called compute/reset %d times, want 0", n)
+	}
+}
@@ -49,11 +49,13 @@ func NewDataSourcesMap() map[string]*schema.Resource {
 		"decort_kvmvm_pfw_list":                 kvmvm.DataSourceComputePfwList(),
 		"decort_kvmvm_user_list":                kvmvm.DataSourceComputeUserList(),
 		"decort_kvmvm_snapshot_usage":           kvmvm.DataSourceComputeSnapshotUsage(),
+		"decort_kvmvm_os_users":                 kvmvm.DataSourceComputeOsUsers(),
 		"decort_k8s":                            k8s.DataSourceK8s(),
 		"decort_k8s_list":                       k8s.DataSourceK8sList(),
 		"decort_k8s_list_deleted":               k8s.DataSourceK8sListDeleted(),
 		"decort_k8s_wg":                         k8s.DataSourceK8sWg(),
 		"decort_k8s_wg_list":                    k8s.DataSourceK8sWgList(),
+		"decort_k8s_kubeconfig":                 k8s.DataSourceK8sKubeconfig(),
 		"decort_vins":                           vins.DataSourceVins(),
 		"decort_vins_list":                      vins.DataSourceVinsList(),
 		"decort_vins_audits":                    vins.DataSourceVinsAudits(),
@@ -37,24 +37,29 @@ import (
 
 func NewRersourcesMap() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"decort_resgroup":          rg.ResourceResgroup(),
-		"decort_kvmvm":             kvmvm.ResourceCompute(),
-		"decort_disk":              disks.ResourceDisk(),
-		"decort_disk_snapshot":     disks.ResourceDiskSnapshot(),
-		"decort_vins":              vins.ResourceVins(),
-		"decort_pfw":               pfw.ResourcePfw(),
-		"decort_k8s":               k8s.ResourceK8s(),
-		"decort_k8s_wg":            k8s.ResourceK8sWg(),
-		"decort_snapshot":          snapshot.ResourceSnapshot(),
-		"decort_account":           account.ResourceAccount(),
-		"decort_bservice":          bservice.ResourceBasicService(),
-		"decort_bservice_group":    bservice.ResourceBasicServiceGroup(),
-		"decort_image":             image.ResourceImage(),
-		"decort_image_virtual":     image.ResourceImageVirtual(),
-		"decort_lb":                lb.ResourceLB(),
-		"decort_lb_backend":        lb.ResourceLBBackend(),
-		"decort_lb_backend_server": lb.ResourceLBBackendServer(),
-		"decort_lb_frontend":       lb.ResourceLBFrontend(),
-		"decort_lb_frontend_bind":  lb.ResourceLBFrontendBind(),
+		"decort_resgroup":                rg.ResourceResgroup(),
+		"decort_kvmvm":                   kvmvm.ResourceCompute(),
+		"decort_kvmvm_network_interface": kvmvm.ResourceComputeNetworkInterface(),
+		"decort_kvmvm_disk_attachment":   kvmvm.ResourceComputeDiskAttachment(),
+		"decort_disk":                    disks.ResourceDisk(),
+		"decort_disk_snapshot":           disks.ResourceDiskSnapshot(),
+		"decort_vins":                    vins.ResourceVins(),
+		"decort_vins_nat_rule":           vins.ResourceVinsNatRule(),
+		"decort_vins_ip_reservation":     vins.ResourceVinsIpReservation(),
+		"decort_pfw":                     pfw.ResourcePfw(),
+		"decort_pfw_set":                 pfw.ResourcePfwSet(),
+		"decort_k8s":                     k8s.ResourceK8s(),
+		"decort_k8s_wg":                  k8s.ResourceK8sWg(),
+		"decort_snapshot":                snapshot.ResourceSnapshot(),
+		"decort_account":                 account.ResourceAccount(),
+		"decort_bservice":                bservice.ResourceBasicService(),
+		"decort_bservice_group":          bservice.ResourceBasicServiceGroup(),
+		"decort_image":                   image.ResourceImage(),
+		"decort_image_virtual":           image.ResourceImageVirtual(),
+		"decort_lb":                      lb.ResourceLB(),
+		"decort_lb_backend":              lb.ResourceLBBackend(),
+		"decort_lb_backend_server":       lb.ResourceLBBackendServer(),
+		"decort_lb_frontend":             lb.ResourceLBFrontend(),
+		"decort_lb_frontend_bind":        lb.ResourceLBFrontendBind(),
 	}
 }
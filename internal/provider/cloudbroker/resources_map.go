@@ -50,6 +50,7 @@ func NewRersourcesMap() map[string]*schema.Resource {
 		"decort_kvmvm":         kvmvm.ResourceCompute(),
 		"decort_vins":          vins.ResourceVins(),
 		"decort_pfw":           pfw.ResourcePfw(),
+		"decort_pfw_set":       pfw.ResourcePfwSet(),
 		"decort_k8s":           k8s.ResourceK8s(),
 		"decort_k8s_wg":        k8s.ResourceK8sWg(),
 		"decort_snapshot":      snapshot.ResourceSnapshot(),
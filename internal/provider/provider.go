@@ -69,6 +69,7 @@ func Provider() *schema.Provider {
 
 			"password": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("DECORT_PASSWORD", nil),
 				Description: "User password for DECORT cloud API operations in 'legacy' authentication mode.",
@@ -83,6 +84,7 @@ func Provider() *schema.Provider {
 
 			"app_secret": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("DECORT_APP_SECRET", nil),
 				Description: "Application secret to access DECORT cloud API in 'oauth2' authentication mode.",
@@ -90,6 +92,7 @@ func Provider() *schema.Provider {
 
 			"jwt": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("DECORT_JWT", nil),
 				Description: "JWT to access DECORT cloud API in 'jwt' authentication mode.",
@@ -101,6 +104,55 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: "If true, DECORT API will not verify SSL certificates. Use this with caution and in trusted environments only!",
 			},
+
+			"retry_max_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DECORT_RETRY_MAX_ATTEMPTS", controller.DefaultRetryMaxAttempts),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of attempts for a single DECORT cloud API call, including the first one.",
+			},
+
+			"retry_min_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DECORT_RETRY_MIN_BACKOFF", int(controller.DefaultRetryMinBackoff.Seconds())),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Delay in seconds before the first retry of a failed API call. Delay is doubled with each subsequent attempt and randomized (jitter).",
+			},
+
+			"retry_max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DECORT_RETRY_MAX_BACKOFF", int(controller.DefaultRetryMaxBackoff.Seconds())),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Upper bound in seconds for a single delay between retries of a failed API call.",
+			},
+
+			"retry_status_codes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(400, 599),
+				},
+				Description: "HTTP status codes which are considered transient and trigger a retry of an API call. Defaults to 429, 500, 502, 503 and 504. Network errors are retried as well, Retry-After header is honored up to retry_max_backoff. Calls which create or attach objects are only retried on refused connections and status codes 429 and 503, so that objects are not created twice.",
+			},
+
+			"drift_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DECORT_DRIFT_POLICY", string(controller.DefaultDriftPolicy)),
+				ValidateFunc: validation.StringInSlice(controller.DriftPolicies, false),
+				Description:  "How refresh handles resources deleted outside of Terraform. 'recreate' restores DELETED objects and recreates DESTROYED ones right away, 'restore' restores DELETED objects and leaves DESTROYED ones to be created by the next apply, 'report' never modifies cloud objects during refresh and removes DELETED and DESTROYED ones from the state with a warning.",
+			},
+
+			"omit_secrets_from_state": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DECORT_OMIT_SECRETS_FROM_STATE", false),
+				Description: "If true, computed secrets such as k8s kubeconfig and passwords of guest OS users are not stored in state of resources and of decort_k8s / decort_kvmvm data sources. Data sources persist their results to state like resources do, so decort_k8s_kubeconfig and decort_kvmvm_os_users data sources store these secrets in state regardless of this setting. Do not use them, if secrets must be kept out of state; read secrets outside of Terraform (e.g. with DECORT API) instead.",
+			},
 		},
 
 		ResourcesMap: selectSchema(false),
@@ -112,7 +164,7 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	decsController, err := controller.ControllerConfigure(d)
+	decsController, err := controller.ControllerConfigure(ctx, d)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
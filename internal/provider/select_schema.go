@@ -23,12 +23,19 @@ package provider
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	ca "repository.basistech.ru/BASIS/terraform-provider-decort/internal/provider/cloudapi"
 	cb "repository.basistech.ru/BASIS/terraform-provider-decort/internal/provider/cloudbroker"
 )
 
+// Prefix of resources and data sources working with the administrative cloudbroker API.
+// They are served next to the user mode ones, so both can be managed in the same configuration.
+const cloudbrokerPrefix = "decort_cb_"
+
+const adminModeDeprecation = "DECORT_ADMIN_MODE is deprecated, use the corresponding decort_cb_* resource or data source instead"
+
 func selectSchema(isDatasource bool) map[string]*schema.Resource {
 	adminMode, err := strconv.ParseBool(os.Getenv("DECORT_ADMIN_MODE"))
 	if err != nil {
@@ -42,15 +49,42 @@ func selectSchema(isDatasource bool) map[string]*schema.Resource {
 }
 
 func selectDataSourceSchema(adminMode bool) map[string]*schema.Resource {
+	res := withCloudbrokerPrefix(cb.NewDataSourcesMap())
 	if adminMode {
-		return cb.NewDataSourcesMap()
+		return mergeSchema(res, deprecateAdminMode(cb.NewDataSourcesMap()))
 	}
-	return ca.NewDataSourcesMap()
+	return mergeSchema(res, ca.NewDataSourcesMap())
 }
 
 func selectResourceSchema(adminMode bool) map[string]*schema.Resource {
+	res := withCloudbrokerPrefix(cb.NewRersourcesMap())
 	if adminMode {
-		return cb.NewRersourcesMap()
+		return mergeSchema(res, deprecateAdminMode(cb.NewRersourcesMap()))
+	}
+	return mergeSchema(res, ca.NewRersourcesMap())
+}
+
+// withCloudbrokerPrefix renames decort_* entries of cloudbroker map into decort_cb_*
+func withCloudbrokerPrefix(m map[string]*schema.Resource) map[string]*schema.Resource {
+	res := make(map[string]*schema.Resource, len(m))
+	for name, r := range m {
+		res[cloudbrokerPrefix+strings.TrimPrefix(name, "decort_")] = r
+	}
+	return res
+}
+
+// deprecateAdminMode marks cloudbroker entries served under legacy decort_* names in
+// DECORT_ADMIN_MODE, where they replace user mode resources and data sources
+func deprecateAdminMode(m map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range m {
+		r.DeprecationMessage = adminModeDeprecation
+	}
+	return m
+}
+
+func mergeSchema(dst, src map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, r := range src {
+		dst[name] = r
 	}
-	return ca.NewRersourcesMap()
+	return dst
 }
@@ -0,0 +1,370 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+// Compute is a group of compute (virtual machine) endpoints
+type Compute struct {
+	caller controller.APICaller
+	prefix string
+
+	// MinInterval is the delay before the second poll in WaitTechStatus
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between polls in WaitTechStatus
+	MaxInterval time.Duration
+}
+
+// New returns compute endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *Compute {
+	return &Compute{
+		caller:      caller,
+		prefix:      prefix,
+		MinInterval: DefaultMinInterval,
+		MaxInterval: DefaultMaxInterval,
+	}
+}
+
+func (c *Compute) path(api string) string {
+	return c.prefix + api
+}
+
+// Get returns detailed information about compute
+func (c *Compute) Get(ctx context.Context, req GetRequest) (*RecordCompute, error) {
+	res := &RecordCompute{}
+	if err := request.Do(ctx, c.caller, c.path("/compute/get"), req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List returns computes available to the user
+func (c *Compute) List(ctx context.Context, req ListRequest) (ListComputes, error) {
+	res := ListComputes{}
+	if err := request.Do(ctx, c.caller, c.path("/compute/list"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListInRG returns brief information about computes of the resource group
+func (c *Compute) ListInRG(ctx context.Context, req ListInRGRequest) (RgListComputesResp, error) {
+	res := RgListComputesResp{}
+	if err := request.Do(ctx, c.caller, c.path("/rg/listComputes"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateX86 creates KVM x86 compute and returns its ID
+func (c *Compute) CreateX86(ctx context.Context, req CreateRequest) (uint64, error) {
+	return request.ID(ctx, c.caller, c.path("/kvmx86/create"), req)
+}
+
+// CreatePPC creates KVM PowerPC compute and returns its ID
+func (c *Compute) CreatePPC(ctx context.Context, req CreateRequest) (uint64, error) {
+	return request.ID(ctx, c.caller, c.path("/kvmppc/create"), req)
+}
+
+// Clone creates a copy of compute, optionally from its snapshot, and returns ID of the clone
+func (c *Compute) Clone(ctx context.Context, req CloneRequest) (uint64, error) {
+	return request.ID(ctx, c.caller, c.path("/compute/clone"), req)
+}
+
+// Update changes name and description of compute
+func (c *Compute) Update(ctx context.Context, req UpdateRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/update"), req)
+}
+
+// Resize changes CPU and RAM of compute
+func (c *Compute) Resize(ctx context.Context, req ResizeRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/resize"), req)
+}
+
+// Delete deletes compute
+func (c *Compute) Delete(ctx context.Context, req DeleteRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/delete"), req)
+}
+
+// Restore restores compute from recycle bin
+func (c *Compute) Restore(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/restore"), req)
+}
+
+// Enable enables compute
+func (c *Compute) Enable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/enable"), req)
+}
+
+// Disable disables compute
+func (c *Compute) Disable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/disable"), req)
+}
+
+// Start starts compute
+func (c *Compute) Start(ctx context.Context, req StartRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/start"), req)
+}
+
+// Stop stops compute
+func (c *Compute) Stop(ctx context.Context, req StopRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/stop"), req)
+}
+
+// Pause pauses running compute
+func (c *Compute) Pause(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/pause"), req)
+}
+
+// Resume resumes paused compute
+func (c *Compute) Resume(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/resume"), req)
+}
+
+// BootOrderSet sets order of compute boot devices
+func (c *Compute) BootOrderSet(ctx context.Context, req BootOrderSetRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/bootOrderSet"), req)
+}
+
+// CdInsert inserts CD-ROM image into compute
+func (c *Compute) CdInsert(ctx context.Context, req CdInsertRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/cdInsert"), req)
+}
+
+// CdEject ejects CD-ROM image from compute
+func (c *Compute) CdEject(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/cdEject"), req)
+}
+
+// NetAttach connects compute to ViNS or external network
+func (c *Compute) NetAttach(ctx context.Context, req NetAttachRequest) (*RecordNetAttach, error) {
+	res := &RecordNetAttach{}
+	if err := request.Do(ctx, c.caller, c.path("/compute/netAttach"), req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NetDetach disconnects compute from network
+func (c *Compute) NetDetach(ctx context.Context, req NetDetachRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/netDetach"), req)
+}
+
+// ChangeIP changes IP address of the compute interface connected to ViNS or external network
+func (c *Compute) ChangeIP(ctx context.Context, req ChangeIPRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/changeIp"), req)
+}
+
+// DiskAttach attaches existing disk to compute
+func (c *Compute) DiskAttach(ctx context.Context, req DiskRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/diskAttach"), req)
+}
+
+// DiskDetach detaches disk from compute
+func (c *Compute) DiskDetach(ctx context.Context, req DiskRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/diskDetach"), req)
+}
+
+// AttachGPU attaches vGPU to stopped compute
+func (c *Compute) AttachGPU(ctx context.Context, req GPURequest) (uint64, error) {
+	return request.ID(ctx, c.caller, c.path("/compute/attachGpu"), req)
+}
+
+// DetachGPU detaches vGPU from stopped compute
+func (c *Compute) DetachGPU(ctx context.Context, req GPURequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/detachGpu"), req)
+}
+
+// AttachPCIDevice passes PCI device through to stopped compute
+func (c *Compute) AttachPCIDevice(ctx context.Context, req PCIDeviceRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/attachPciDevice"), req)
+}
+
+// DetachPCIDevice detaches PCI device from stopped compute
+func (c *Compute) DetachPCIDevice(ctx context.Context, req PCIDeviceRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/detachPciDevice"), req)
+}
+
+// PCIDeviceList returns PCI devices attached to compute
+func (c *Compute) PCIDeviceList(ctx context.Context, req IDRequest) (ListPCIDevices, error) {
+	res := ListPCIDevices{}
+	if err := request.Do(ctx, c.caller, c.path("/compute/pciDeviceList"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PFWList returns port forwarding rules of compute
+func (c *Compute) PFWList(ctx context.Context, req IDRequest) (ListPFWs, error) {
+	if err := request.Validate(req); err != nil {
+		return nil, err
+	}
+
+	path := c.path("/compute/pfwList")
+	resp, err := c.caller.DecortAPICall(ctx, "POST", path, request.Values(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// compute without port forwarding rules may respond with empty body
+	res := ListPFWs{}
+	if resp == "" {
+		return res, nil
+	}
+	if err := json.Unmarshal([]byte(resp), &res); err != nil {
+		return nil, fmt.Errorf("cannot decode response of %s: %w", path, err)
+	}
+	return res, nil
+}
+
+// PFWAdd adds port forwarding rule to compute and returns ID of the rule
+func (c *Compute) PFWAdd(ctx context.Context, req PFWAddRequest) (uint64, error) {
+	return request.ID(ctx, c.caller, c.path("/compute/pfwAdd"), req)
+}
+
+// PFWDel deletes port forwarding rule of compute
+func (c *Compute) PFWDel(ctx context.Context, req PFWDelRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/pfwDel"), req)
+}
+
+// DiskAdd creates new disk and attaches it to compute, returns ID of the disk
+func (c *Compute) DiskAdd(ctx context.Context, req DiskAddRequest) (uint64, error) {
+	return request.ID(ctx, c.caller, c.path("/compute/diskAdd"), req)
+}
+
+// DiskDel detaches disk from compute and deletes it
+func (c *Compute) DiskDel(ctx context.Context, req DiskDelRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/diskDel"), req)
+}
+
+// AffinityLabelSet sets affinity label of compute
+func (c *Compute) AffinityLabelSet(ctx context.Context, req AffinityLabelSetRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/affinityLabelSet"), req)
+}
+
+// AffinityLabelRemove removes affinity label of compute
+func (c *Compute) AffinityLabelRemove(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/affinityLabelRemove"), req)
+}
+
+// AffinityRuleAdd adds affinity rule to compute
+func (c *Compute) AffinityRuleAdd(ctx context.Context, req AffinityRuleRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/affinityRuleAdd"), req)
+}
+
+// AffinityRuleRemove removes affinity rule of compute
+func (c *Compute) AffinityRuleRemove(ctx context.Context, req AffinityRuleRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/affinityRuleRemove"), req)
+}
+
+// AffinityRulesClear removes all affinity rules of compute
+func (c *Compute) AffinityRulesClear(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/affinityRulesClear"), req)
+}
+
+// AntiAffinityRuleAdd adds anti-affinity rule to compute
+func (c *Compute) AntiAffinityRuleAdd(ctx context.Context, req AffinityRuleRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/antiAffinityRuleAdd"), req)
+}
+
+// AntiAffinityRuleRemove removes anti-affinity rule of compute
+func (c *Compute) AntiAffinityRuleRemove(ctx context.Context, req AffinityRuleRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/antiAffinityRuleRemove"), req)
+}
+
+// AntiAffinityRulesClear removes all anti-affinity rules of compute
+func (c *Compute) AntiAffinityRulesClear(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/antiAffinityRulesClear"), req)
+}
+
+// TagAdd adds tag to compute
+func (c *Compute) TagAdd(ctx context.Context, req TagAddRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/tagAdd"), req)
+}
+
+// TagRemove removes tag of compute
+func (c *Compute) TagRemove(ctx context.Context, req TagRemoveRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/tagRemove"), req)
+}
+
+// UserGrant grants user access to compute
+func (c *Compute) UserGrant(ctx context.Context, req UserGrantRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/userGrant"), req)
+}
+
+// UserRevoke revokes user access to compute
+func (c *Compute) UserRevoke(ctx context.Context, req UserRevokeRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/userRevoke"), req)
+}
+
+// SnapshotCreate creates snapshot of compute disks and returns its GUID
+func (c *Compute) SnapshotCreate(ctx context.Context, req SnapshotRequest) (string, error) {
+	var res string
+	if err := request.Do(ctx, c.caller, c.path("/compute/snapshotCreate"), req, &res); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// SnapshotDelete deletes snapshot of compute
+func (c *Compute) SnapshotDelete(ctx context.Context, req SnapshotRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/snapshotDelete"), req)
+}
+
+// SnapshotRollback rolls stopped compute back to snapshot
+func (c *Compute) SnapshotRollback(ctx context.Context, req SnapshotRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/snapshotRollback"), req)
+}
+
+// PinToStack pins compute to its current stack, so that it is not migrated
+func (c *Compute) PinToStack(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/pinToStack"), req)
+}
+
+// UnpinFromStack unpins compute from stack
+func (c *Compute) UnpinFromStack(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/unpinFromStack"), req)
+}
+
+// Reset resets compute, as if it was powered off and on
+func (c *Compute) Reset(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/reset"), req)
+}
+
+// MoveToRG moves compute to another resource group of the same account. Running compute is
+// stopped for the move.
+func (c *Compute) MoveToRG(ctx context.Context, req MoveToRGRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/moveToRg"), req)
+}
+
+// Redeploy recreates boot disk of stopped compute from image
+func (c *Compute) Redeploy(ctx context.Context, req RedeployRequest) (bool, error) {
+	return request.Bool(ctx, c.caller, c.path("/compute/redeploy"), req)
+}
@@ -0,0 +1,561 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+
+// Request struct for the endpoints, which only take compute ID
+type IDRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+}
+
+// Request struct for get compute
+type GetRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
+
+// Request struct for list computes
+type ListRequest struct {
+	// Include deleted computes
+	IncludeDeleted bool `url:"includedeleted"`
+
+	// Page number
+	Page uint64 `url:"page,omitempty"`
+
+	// Page size
+	Size uint64 `url:"size,omitempty"`
+}
+
+// Request struct for list computes of resource group
+type ListInRGRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Include deleted computes
+	IncludeDeleted bool `url:"includedeleted"`
+}
+
+// Request struct for create compute
+type CreateRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Name of compute
+	Name string `url:"name" validate:"required"`
+
+	// Number of CPUs
+	CPU uint64 `url:"cpu" validate:"required"`
+
+	// RAM size in MB
+	RAM uint64 `url:"ram" validate:"required"`
+
+	// ID of OS image
+	ImageID uint64 `url:"imageId" validate:"required"`
+
+	// Boot disk size in GB
+	BootDisk uint64 `url:"bootDisk,omitempty"`
+
+	// ID of SEP for the boot disk
+	SEPID uint64 `url:"sepId,omitempty"`
+
+	// Pool for the boot disk
+	Pool string `url:"pool,omitempty"`
+
+	// Type of the first network: EXTNET, VINS or NONE
+	NetType string `url:"netType,omitempty"`
+
+	// ID of the first network
+	NetID uint64 `url:"netId,omitempty"`
+
+	// IP address in the first network
+	IPAddr string `url:"ipAddr,omitempty"`
+
+	// Cloud-init user data
+	Userdata string `url:"userdata,omitempty"`
+
+	// Description
+	Description string `url:"desc,omitempty"`
+
+	// Start compute after creation, true by default
+	Start *bool `url:"start"`
+
+	// IPA type of the compute (PPC only)
+	IPAType string `url:"ipaType,omitempty"`
+
+	// Is compute managed by the user
+	IS string `url:"IS,omitempty"`
+}
+
+func (r CreateRequest) Validate() error {
+	switch r.NetType {
+	case "", "NONE", "EXTNET", "VINS":
+	default:
+		return request.Invalid(r, "NetType", "must be one of EXTNET, VINS or NONE, got %q", r.NetType)
+	}
+	if (r.NetType == "EXTNET" || r.NetType == "VINS") && r.NetID == 0 {
+		return request.Invalid(r, "NetID", "is required when NetType is %s", r.NetType)
+	}
+	return nil
+}
+
+// Request struct for update compute
+type UpdateRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// New name
+	Name string `url:"name,omitempty"`
+
+	// New description, nil keeps the current one and empty string clears it
+	Description *string `url:"desc"`
+}
+
+// Request struct for resize compute
+type ResizeRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// New number of CPUs, 0 keeps the current value
+	CPU uint64 `url:"cpu,omitempty"`
+
+	// New RAM size in MB, 0 keeps the current value
+	RAM uint64 `url:"ram,omitempty"`
+
+	// Stop compute if hot resize is not possible
+	Force bool `url:"force,omitempty"`
+}
+
+func (r ResizeRequest) Validate() error {
+	if r.CPU == 0 && r.RAM == 0 {
+		return request.Invalid(r, "", "either CPU or RAM must be specified")
+	}
+	return nil
+}
+
+// Request struct for clone compute
+type CloneRequest struct {
+	// ID of source compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Name of the clone
+	Name string `url:"name" validate:"required"`
+
+	// Timestamp of the source snapshot, current state of the source is cloned if both
+	// timestamp and name of the snapshot are empty
+	SnapshotTimestamp uint64 `url:"snapshotTimestamp,omitempty"`
+
+	// Name of the source snapshot
+	SnapshotName string `url:"snapshotName,omitempty"`
+
+	// Clone running source compute
+	Force bool `url:"force,omitempty"`
+}
+
+// Request struct for delete compute
+type DeleteRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Delete permanently, bypassing recycle bin
+	Permanently bool `url:"permanently"`
+
+	// Detach data disks instead of deleting them
+	DetachDisks bool `url:"detachDisks"`
+}
+
+// Request struct for start compute
+type StartRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of alternative boot disk or image, 0 boots from the boot disk
+	AltBootID uint64 `url:"altBootId"`
+}
+
+// Request struct for stop compute
+type StopRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Power off compute instead of graceful shutdown
+	Force bool `url:"force"`
+}
+
+// Request struct for move compute to another resource group
+type MoveToRGRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of the target resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// New name of compute, the current name is kept if not set
+	Name string `url:"name,omitempty"`
+
+	// Start compute after move
+	AutoStart bool `url:"autostart"`
+
+	// Power off running compute instead of graceful shutdown
+	ForceStop bool `url:"forceStop"`
+}
+
+// Request struct for set compute boot order
+type BootOrderSetRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Boot devices in order of priority: hd, cdrom or network
+	Order []string `url:"order" validate:"required"`
+}
+
+func (r BootOrderSetRequest) Validate() error {
+	for _, device := range r.Order {
+		if device != "hd" && device != "cdrom" && device != "network" {
+			return request.Invalid(r, "Order", "boot device must be one of hd, cdrom or network, got %q", device)
+		}
+	}
+	return nil
+}
+
+// Request struct for insert CD-ROM image into compute
+type CdInsertRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of CD-ROM image
+	CDROMID uint64 `url:"cdromId" validate:"required"`
+}
+
+// Request struct for attach network to compute
+type NetAttachRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Network type: EXTNET or VINS
+	NetType string `url:"netType" validate:"required"`
+
+	// ID of network
+	NetID uint64 `url:"netId" validate:"required"`
+
+	// IP address, allocated automatically if empty
+	IPAddr string `url:"ipAddr,omitempty"`
+}
+
+func (r NetAttachRequest) Validate() error {
+	if r.NetType != "EXTNET" && r.NetType != "VINS" {
+		return request.Invalid(r, "NetType", "must be either EXTNET or VINS, got %q", r.NetType)
+	}
+	return nil
+}
+
+// Request struct for detach network from compute
+type NetDetachRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// IP address of the interface
+	IPAddr string `url:"ipAddr,omitempty"`
+
+	// MAC address of the interface
+	MAC string `url:"mac,omitempty"`
+}
+
+func (r NetDetachRequest) Validate() error {
+	if r.IPAddr == "" && r.MAC == "" {
+		return request.Invalid(r, "", "either IPAddr or MAC must be specified")
+	}
+	return nil
+}
+
+// Request struct for change IP address of compute interface
+type ChangeIPRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Network type: EXTNET or VINS
+	NetType string `url:"netType" validate:"required"`
+
+	// ID of network
+	NetID uint64 `url:"netId" validate:"required"`
+
+	// New IP address
+	IPAddr string `url:"ipAddr" validate:"required"`
+}
+
+func (r ChangeIPRequest) Validate() error {
+	if r.NetType != "EXTNET" && r.NetType != "VINS" {
+		return request.Invalid(r, "NetType", "must be either EXTNET or VINS, got %q", r.NetType)
+	}
+	return nil
+}
+
+// Request struct for attach or detach disk
+type DiskRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+}
+
+// Request struct for attach or detach vGPU
+type GPURequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of vGPU
+	VGPUID uint64 `url:"vgpuId" validate:"required"`
+}
+
+// Request struct for attach or detach PCI device
+type PCIDeviceRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of PCI device
+	DeviceID uint64 `url:"deviceId" validate:"required"`
+}
+
+// Request struct for add port forwarding rule
+type PFWAddRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Start of the public ports range
+	PublicPortStart uint64 `url:"publicPortStart" validate:"required"`
+
+	// End of the public ports range, equals to PublicPortStart if not set
+	PublicPortEnd uint64 `url:"publicPortEnd,omitempty"`
+
+	// Local port the range is forwarded to
+	LocalBasePort uint64 `url:"localBasePort" validate:"required"`
+
+	// Protocol: tcp or udp
+	Proto string `url:"proto" validate:"required"`
+}
+
+func (r PFWAddRequest) Validate() error {
+	if r.PublicPortEnd != 0 && r.PublicPortEnd < r.PublicPortStart {
+		return request.Invalid(r, "PublicPortEnd", "must not be less than PublicPortStart")
+	}
+	switch r.Proto {
+	case "tcp", "udp":
+		return nil
+	}
+	return request.Invalid(r, "Proto", "must be either tcp or udp, got %q", r.Proto)
+}
+
+// Request struct for delete port forwarding rule. The rule is identified either by its ID
+// or by its ports and protocol.
+type PFWDelRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of the rule
+	RuleID uint64 `url:"ruleId,omitempty"`
+
+	// Start of the public ports range
+	PublicPortStart uint64 `url:"publicPortStart,omitempty"`
+
+	// End of the public ports range
+	PublicPortEnd uint64 `url:"publicPortEnd,omitempty"`
+
+	// Local port the range is forwarded to
+	LocalBasePort uint64 `url:"localBasePort,omitempty"`
+
+	// Protocol: tcp or udp
+	Proto string `url:"proto,omitempty"`
+}
+
+func (r PFWDelRequest) Validate() error {
+	if r.RuleID == 0 && (r.PublicPortStart == 0 || r.LocalBasePort == 0 || r.Proto == "") {
+		return request.Invalid(r, "RuleID", "either RuleID or ports and protocol of the rule must be specified")
+	}
+	return nil
+}
+
+// Request struct for add disk to compute
+type DiskAddRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Name of disk
+	DiskName string `url:"diskName" validate:"required"`
+
+	// Size of disk in GB
+	Size uint64 `url:"size" validate:"required"`
+
+	// Type of disk: D (data) by default
+	DiskType string `url:"diskType,omitempty"`
+
+	// ID of SEP, the SEP of the boot disk is used by default
+	SEPID uint64 `url:"sepId,omitempty"`
+
+	// Pool of SEP
+	Pool string `url:"pool,omitempty"`
+
+	// Description
+	Description string `url:"desc,omitempty"`
+
+	// ID of image to create disk from
+	ImageID uint64 `url:"imageId,omitempty"`
+}
+
+// Request struct for delete disk of compute
+type DiskDelRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+
+	// Delete permanently, bypassing recycle bin
+	Permanently bool `url:"permanently"`
+}
+
+// Request struct for set affinity label of compute
+type AffinityLabelSetRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Affinity label
+	AffinityLabel string `url:"affinityLabel" validate:"required"`
+}
+
+// Request struct for add or remove affinity and anti-affinity rules
+type AffinityRuleRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Topology the rule applies to: node or compute
+	Topology string `url:"topology" validate:"required"`
+
+	// Policy of the rule: RECOMMENDED or REQUIRED
+	Policy string `url:"policy" validate:"required"`
+
+	// Comparison mode: EQ, NE or ANY
+	Mode string `url:"mode" validate:"required"`
+
+	// Key of the label
+	Key string `url:"key" validate:"required"`
+
+	// Value of the label
+	Value string `url:"value"`
+}
+
+func (r AffinityRuleRequest) Validate() error {
+	if r.Topology != "node" && r.Topology != "compute" {
+		return request.Invalid(r, "Topology", "must be either node or compute, got %q", r.Topology)
+	}
+	if r.Policy != "RECOMMENDED" && r.Policy != "REQUIRED" {
+		return request.Invalid(r, "Policy", "must be either RECOMMENDED or REQUIRED, got %q", r.Policy)
+	}
+	switch r.Mode {
+	case "EQ", "NE", "ANY":
+		return nil
+	}
+	return request.Invalid(r, "Mode", "must be one of EQ, NE or ANY, got %q", r.Mode)
+}
+
+// Request struct for add tag to compute
+type TagAddRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Key of the tag
+	Key string `url:"key" validate:"required"`
+
+	// Value of the tag
+	Value string `url:"value" validate:"required"`
+}
+
+// Request struct for remove tag of compute
+type TagRemoveRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Key of the tag
+	Key string `url:"key" validate:"required"`
+}
+
+// Request struct for grant user access to compute
+type UserGrantRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Name of the user
+	Username string `url:"userName" validate:"required"`
+
+	// Access type: R, RCX or ARCXDU
+	AccessType string `url:"accesstype" validate:"required"`
+}
+
+// Request struct for revoke user access to compute
+type UserRevokeRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Name of the user
+	Username string `url:"userName" validate:"required"`
+}
+
+// Request struct for create, delete or rollback compute snapshot
+type SnapshotRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// Label of snapshot
+	Label string `url:"label" validate:"required"`
+}
+
+// Request struct for redeploy compute from image
+type RedeployRequest struct {
+	// ID of compute
+	ComputeID uint64 `url:"computeId" validate:"required"`
+
+	// ID of the new image, the current image is used if not set
+	ImageID uint64 `url:"imageId,omitempty"`
+
+	// New size of the boot disk in GB
+	DiskSize uint64 `url:"diskSize,omitempty"`
+
+	// What to do with data disks: KEEP, DETACH or DESTROY
+	DataDisks string `url:"dataDisks,omitempty"`
+
+	// Start compute after redeploy
+	AutoStart bool `url:"autoStart"`
+
+	// Power off running compute instead of graceful shutdown
+	ForceStop bool `url:"forceStop"`
+}
+
+func (r RedeployRequest) Validate() error {
+	switch r.DataDisks {
+	case "", "KEEP", "DETACH", "DESTROY":
+		return nil
+	}
+	return request.Invalid(r, "DataDisks", "must be one of KEEP, DETACH or DESTROY, got %q", r.DataDisks)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+var log = logging.New("compute")
+
+// Default polling intervals of WaitTechStatus. Interval grows by half on every poll until
+// it reaches DefaultMaxInterval.
+const (
+	DefaultMinInterval = 2 * time.Second
+	DefaultMaxInterval = 15 * time.Second
+)
+
+// TechStatusError is returned when compute did not reach the expected tech status
+type TechStatusError struct {
+	ComputeID  uint64
+	Want       []string
+	TechStatus string
+
+	// Err is the context error if waiting was interrupted, nil if compute went down
+	Err error
+}
+
+func (e *TechStatusError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("compute %d did not reach tech status %s, last tech status %s: %v",
+			e.ComputeID, strings.Join(e.Want, " or "), e.TechStatus, e.Err)
+	}
+	return fmt.Sprintf("compute %d is %s while waiting for tech status %s",
+		e.ComputeID, e.TechStatus, strings.Join(e.Want, " or "))
+}
+
+func (e *TechStatusError) Unwrap() error {
+	return e.Err
+}
+
+// WaitTechStatus polls compute/get until compute tech status is one of want or ctx is done,
+// whichever happens first. Resource CRUD contexts carry the resource timeout as deadline, so
+// the wait is bound by it. Compute in DOWN tech status fails the wait, unless DOWN is wanted.
+// On success the compute is returned, otherwise the error is *TechStatusError or API error.
+func (c *Compute) WaitTechStatus(ctx context.Context, computeID uint64, want ...string) (*RecordCompute, error) {
+	interval := c.MinInterval
+	techStatus := ""
+
+	for {
+		compute, err := c.Get(ctx, GetRequest{ComputeID: computeID})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, &TechStatusError{ComputeID: computeID, Want: want, TechStatus: techStatus, Err: ctxErr}
+			}
+			return nil, err
+		}
+
+		if compute.TechStatus != techStatus {
+			log.Debugf(ctx, "compute.WaitTechStatus: compute %d tech status %q -> %q", computeID, techStatus, compute.TechStatus)
+			techStatus = compute.TechStatus
+		}
+
+		for _, status := range want {
+			if techStatus == status {
+				return compute, nil
+			}
+		}
+		if techStatus == techstatus.Down {
+			return compute, &TechStatusError{ComputeID: computeID, Want: want, TechStatus: techStatus}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &TechStatusError{ComputeID: computeID, Want: want, TechStatus: techStatus, Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+		interval += interval / 2
+		if interval > c.MaxInterval {
+			interval = c.MaxInterval
+		}
+	}
+}
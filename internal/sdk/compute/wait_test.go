@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+const getPath = "/restmachine/cloudapi/compute/get"
+
+// settleAfter makes compute/get of the fake controller report compute in its current tech status
+// until the given poll, which finds it in the settled tech status
+func settleAfter(s *fake.Server, computeID int, poll int, settled string) {
+	polls := 0
+	s.Handle(getPath, func(s *fake.Server, form url.Values) (interface{}, error) {
+		polls++
+		if polls == poll {
+			s.Update(fake.KindCompute, computeID, func(obj fake.Object) { obj["techStatus"] = settled })
+		}
+		obj, ok := s.Get(fake.KindCompute, computeID)
+		if !ok {
+			return nil, &fake.Error{StatusCode: http.StatusNotFound, Message: "compute not found"}
+		}
+		return obj, nil
+	})
+}
+
+func TestWaitTechStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		techStatus string
+		settleAt   int
+		settled    string
+		want       []string
+		timeout    time.Duration
+		missing    bool
+		wantPolls  int
+		wantErr    bool
+		wantStatus string
+		wantCtx    bool
+	}{
+		{name: "settled at once", techStatus: techstatus.Stopped, want: []string{techstatus.Stopped}, wantPolls: 1},
+		{name: "any of wanted", techStatus: techstatus.Paused, want: []string{techstatus.Started, techstatus.Paused}, wantPolls: 1},
+		{name: "settles after polls", techStatus: techstatus.Starting, settleAt: 3, settled: techstatus.Started,
+			want: []string{techstatus.Started}, wantPolls: 3},
+		{name: "down", techStatus: techstatus.Starting, settleAt: 2, settled: techstatus.Down,
+			want: []string{techstatus.Started}, wantPolls: 2, wantErr: true, wantStatus: techstatus.Down},
+		{name: "down wanted", techStatus: techstatus.Down, want: []string{techstatus.Down}, wantPolls: 1},
+		{name: "timed out", techStatus: techstatus.Starting, want: []string{techstatus.Started},
+			timeout: 20 * time.Millisecond, wantErr: true, wantStatus: techstatus.Starting, wantCtx: true},
+		{name: "not found", missing: true, want: []string{techstatus.Started}, wantPolls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+
+			id := s.NewID()
+			if !tt.missing {
+				s.Put(fake.KindCompute, id, fake.Object{"id": id, "name": "vm", "techStatus": tt.techStatus})
+			}
+			if tt.settleAt != 0 {
+				settleAfter(s, id, tt.settleAt, tt.settled)
+			}
+
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			api := New(s.Client(), "/restmachine/cloudapi")
+			api.MinInterval = time.Millisecond
+			api.MaxInterval = 2 * time.Millisecond
+
+			compute, err := api.WaitTechStatus(ctx, uint64(id), tt.want...)
+			if tt.wantPolls != 0 && s.Calls(getPath) != tt.wantPolls {
+				t.Errorf("WaitTechStatus() polled %d times, want %d", s.Calls(getPath), tt.wantPolls)
+			}
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("WaitTechStatus() error = %v", err)
+				}
+				if compute.ID != uint64(id) {
+					t.Errorf("WaitTechStatus() returned compute ID %d, want %d", compute.ID, id)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("WaitTechStatus() succeeded, want error")
+			}
+			if tt.missing {
+				if !controller.IsNotFound(err) {
+					t.Errorf("WaitTechStatus() error = %v, want not found API error", err)
+				}
+				return
+			}
+
+			var statusErr *TechStatusError
+			if !errors.As(err, &statusErr) {
+				t.Fatalf("WaitTechStatus() error = %T, want *TechStatusError", err)
+			}
+			if statusErr.ComputeID != uint64(id) || statusErr.TechStatus != tt.wantStatus {
+				t.Errorf("TechStatusError = compute %d in %q, want compute %d in %q", statusErr.ComputeID, statusErr.TechStatus, id, tt.wantStatus)
+			}
+			if got := errors.Is(err, context.DeadlineExceeded); got != tt.wantCtx {
+				t.Errorf("errors.Is(err, context.DeadlineExceeded) = %v, want %v", got, tt.wantCtx)
+			}
+		})
+	}
+}
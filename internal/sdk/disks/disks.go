@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disks
+
+import (
+	"context"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+// Disks is a group of disk endpoints
+type Disks struct {
+	caller controller.APICaller
+	prefix string
+}
+
+// New returns disk endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *Disks {
+	return &Disks{caller: caller, prefix: prefix}
+}
+
+func (d *Disks) path(api string) string {
+	return d.prefix + api
+}
+
+// Get returns detailed information about disk
+func (d *Disks) Get(ctx context.Context, req GetRequest) (*Disk, error) {
+	res := &Disk{}
+	if err := request.Do(ctx, d.caller, d.path("/disks/get"), req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List returns disks available to the user
+func (d *Disks) List(ctx context.Context, req ListRequest) (DisksList, error) {
+	res := DisksList{}
+	if err := request.Do(ctx, d.caller, d.path("/disks/list"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Create creates disk and returns its ID
+func (d *Disks) Create(ctx context.Context, req CreateRequest) (uint64, error) {
+	return request.ID(ctx, d.caller, d.path("/disks/create"), req)
+}
+
+// Resize grows disk to the new size
+func (d *Disks) Resize(ctx context.Context, req ResizeRequest) (bool, error) {
+	return request.Bool(ctx, d.caller, d.path("/disks/resize2"), req)
+}
+
+// Rename changes name of disk
+func (d *Disks) Rename(ctx context.Context, req RenameRequest) (bool, error) {
+	return request.Bool(ctx, d.caller, d.path("/disks/rename"), req)
+}
+
+// LimitIO sets IO limits of disk
+func (d *Disks) LimitIO(ctx context.Context, req LimitIORequest) (bool, error) {
+	return request.Bool(ctx, d.caller, d.path("/disks/limitIO"), req)
+}
+
+// Delete deletes disk
+func (d *Disks) Delete(ctx context.Context, req DeleteRequest) (bool, error) {
+	return request.Bool(ctx, d.caller, d.path("/disks/delete"), req)
+}
+
+// Restore restores disk from recycle bin
+func (d *Disks) Restore(ctx context.Context, req RestoreRequest) (bool, error) {
+	return request.Bool(ctx, d.caller, d.path("/disks/restore"), req)
+}
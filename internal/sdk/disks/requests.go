@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disks
+
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+
+// Request struct for get disk
+type GetRequest struct {
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+}
+
+// Request struct for list disks
+type ListRequest struct {
+	// ID of account to list disks of
+	AccountID uint64 `url:"accountId,omitempty"`
+
+	// Type of disks
+	Type string `url:"type,omitempty"`
+
+	// Page number
+	Page uint64 `url:"page,omitempty"`
+
+	// Page size
+	Size uint64 `url:"size,omitempty"`
+}
+
+// Request struct for create disk
+type CreateRequest struct {
+	// ID of account
+	AccountID uint64 `url:"accountId" validate:"required"`
+
+	// ID of grid
+	GID uint64 `url:"gid" validate:"required"`
+
+	// Name of disk
+	Name string `url:"name" validate:"required"`
+
+	// Description
+	Description string `url:"description,omitempty"`
+
+	// Size in GB
+	Size uint64 `url:"size" validate:"required"`
+
+	// Type of disk: B (boot), D (data) or T (temporary)
+	Type string `url:"type" validate:"required"`
+
+	// ID of SEP
+	SEPID uint64 `url:"sep_id,omitempty"`
+
+	// Pool name
+	Pool string `url:"pool,omitempty"`
+}
+
+func (r CreateRequest) Validate() error {
+	switch r.Type {
+	case "B", "D", "T":
+		return nil
+	}
+	return request.Invalid(r, "Type", "must be one of B, D or T, got %q", r.Type)
+}
+
+// Request struct for resize disk
+type ResizeRequest struct {
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+
+	// New size in GB
+	Size uint64 `url:"size" validate:"required"`
+}
+
+// Request struct for rename disk
+type RenameRequest struct {
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+
+	// New name
+	Name string `url:"name" validate:"required"`
+}
+
+// Request struct for limit disk IO
+type LimitIORequest struct {
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+
+	// Max IOPS, total for read and write
+	IOPS uint64 `url:"iops"`
+
+	ReadBytesSec     uint64 `url:"read_bytes_sec"`
+	ReadBytesSecMax  uint64 `url:"read_bytes_sec_max"`
+	ReadIOPSSec      uint64 `url:"read_iops_sec"`
+	ReadIOPSSecMax   uint64 `url:"read_iops_sec_max"`
+	SizeIOPSSec      uint64 `url:"size_iops_sec"`
+	TotalBytesSec    uint64 `url:"total_bytes_sec"`
+	TotalBytesSecMax uint64 `url:"total_bytes_sec_max"`
+	TotalIOPSSecMax  uint64 `url:"total_iops_sec_max"`
+	WriteBytesSec    uint64 `url:"write_bytes_sec"`
+	WriteBytesSecMax uint64 `url:"write_bytes_sec_max"`
+	WriteIOPSSec     uint64 `url:"write_iops_sec"`
+	WriteIOPSSecMax  uint64 `url:"write_iops_sec_max"`
+}
+
+// Request struct for delete disk
+type DeleteRequest struct {
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+
+	// Detach disk from compute before deletion
+	Detach bool `url:"detach"`
+
+	// Delete permanently, bypassing recycle bin
+	Permanently bool `url:"permanently"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
+
+// Request struct for restore disk
+type RestoreRequest struct {
+	// ID of disk
+	DiskID uint64 `url:"diskId" validate:"required"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lb
+
+import (
+	"context"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+// LB is a group of load balancer endpoints
+type LB struct {
+	caller controller.APICaller
+	prefix string
+}
+
+// New returns load balancer endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *LB {
+	return &LB{caller: caller, prefix: prefix}
+}
+
+func (l *LB) path(api string) string {
+	return l.prefix + api
+}
+
+// Get returns detailed information about load balancer
+func (l *LB) Get(ctx context.Context, req IDRequest) (*LoadBalancer, error) {
+	res := &LoadBalancer{}
+	if err := request.Do(ctx, l.caller, l.path("/lb/get"), req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List returns load balancers available to the user
+func (l *LB) List(ctx context.Context, req ListRequest) (LBList, error) {
+	res := LBList{}
+	if err := request.Do(ctx, l.caller, l.path("/lb/list"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Create creates load balancer and returns its ID
+func (l *LB) Create(ctx context.Context, req CreateRequest) (uint64, error) {
+	return request.ID(ctx, l.caller, l.path("/lb/create"), req)
+}
+
+// Update changes description of load balancer
+func (l *LB) Update(ctx context.Context, req UpdateRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/update"), req)
+}
+
+// Delete deletes load balancer
+func (l *LB) Delete(ctx context.Context, req DeleteRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/delete"), req)
+}
+
+// Restore restores load balancer from recycle bin
+func (l *LB) Restore(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/restore"), req)
+}
+
+// Enable enables load balancer
+func (l *LB) Enable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/enable"), req)
+}
+
+// Disable disables load balancer
+func (l *LB) Disable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/disable"), req)
+}
+
+// Start starts load balancer
+func (l *LB) Start(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/start"), req)
+}
+
+// Stop stops load balancer
+func (l *LB) Stop(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/stop"), req)
+}
+
+// Restart restarts load balancer
+func (l *LB) Restart(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/restart"), req)
+}
+
+// ConfigReset resets configuration of load balancer to the one stored on the platform
+func (l *LB) ConfigReset(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/configReset"), req)
+}
+
+// BackendCreate creates backend of load balancer
+func (l *LB) BackendCreate(ctx context.Context, req BackendRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/backendCreate"), req)
+}
+
+// BackendUpdate changes settings of backend, which are set in the request
+func (l *LB) BackendUpdate(ctx context.Context, req BackendRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/backendUpdate"), req)
+}
+
+// BackendDelete deletes backend of load balancer
+func (l *LB) BackendDelete(ctx context.Context, req BackendDeleteRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/backendDelete"), req)
+}
+
+// BackendServerAdd adds server to backend
+func (l *LB) BackendServerAdd(ctx context.Context, req BackendServerRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/backendServerAdd"), req)
+}
+
+// BackendServerUpdate changes settings of backend server, which are set in the request
+func (l *LB) BackendServerUpdate(ctx context.Context, req BackendServerRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/backendServerUpdate"), req)
+}
+
+// BackendServerDelete deletes server of backend
+func (l *LB) BackendServerDelete(ctx context.Context, req BackendServerDeleteRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/backendServerDelete"), req)
+}
+
+// FrontendBind creates binding of frontend
+func (l *LB) FrontendBind(ctx context.Context, req FrontendBindRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/frontendBind"), req)
+}
+
+// FrontendBindingUpdate changes settings of frontend binding, which are set in the request
+func (l *LB) FrontendBindingUpdate(ctx context.Context, req FrontendBindRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/frontendBindingUpdate"), req)
+}
+
+// FrontendBindDelete deletes binding of frontend
+func (l *LB) FrontendBindDelete(ctx context.Context, req FrontendBindDeleteRequest) (bool, error) {
+	return request.Bool(ctx, l.caller, l.path("/lb/frontendBindDelete"), req)
+}
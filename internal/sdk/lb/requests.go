@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lb
+
+// Request struct for the endpoints, which only take load balancer ID
+type IDRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+}
+
+// Request struct for list load balancers
+type ListRequest struct {
+	// Include deleted load balancers
+	IncludeDeleted bool `url:"includedeleted"`
+
+	// Page number
+	Page uint64 `url:"page,omitempty"`
+
+	// Page size
+	Size uint64 `url:"size,omitempty"`
+}
+
+// Request struct for create load balancer
+type CreateRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Name of load balancer
+	Name string `url:"name" validate:"required"`
+
+	// ID of external network
+	ExtNetID uint64 `url:"extnetId" validate:"required"`
+
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// Start load balancer after creation
+	Start bool `url:"start"`
+
+	// Description
+	Description string `url:"desc,omitempty"`
+}
+
+// Request struct for update load balancer
+type UpdateRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// New description
+	Description string `url:"desc"`
+}
+
+// Request struct for delete load balancer
+type DeleteRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Delete permanently, bypassing recycle bin
+	Permanently bool `url:"permanently"`
+}
+
+// Health check settings of backend servers shared by backend and server requests. Nil settings
+// are not sent, so that the current or default values are kept.
+type HealthCheckSettings struct {
+	// Interval between health checks in ms
+	Inter *uint64 `url:"inter"`
+
+	// Interval between health checks of server, which is down, in ms
+	DownInter *uint64 `url:"downinter"`
+
+	// Number of successful checks to consider server up
+	Rise *uint64 `url:"rise"`
+
+	// Number of failed checks to consider server down
+	Fall *uint64 `url:"fall"`
+
+	// Time in ms to reach full weight after server is up
+	SlowStart *uint64 `url:"slowstart"`
+
+	// Maximum number of concurrent connections to server
+	MaxConn *uint64 `url:"maxconn"`
+
+	// Maximum number of queued connections to server
+	MaxQueue *uint64 `url:"maxqueue"`
+
+	// Weight of server in load balancing
+	Weight *uint64 `url:"weight"`
+}
+
+// Request struct for create or update backend
+type BackendRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Name of backend
+	BackendName string `url:"backendName" validate:"required"`
+
+	// Balancing algorithm: roundrobin, static-rr or leastconn
+	Algorithm string `url:"algorithm,omitempty"`
+
+	HealthCheckSettings
+}
+
+// Request struct for delete backend
+type BackendDeleteRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Name of backend
+	BackendName string `url:"backendName" validate:"required"`
+}
+
+// Request struct for add or update backend server
+type BackendServerRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Name of backend
+	BackendName string `url:"backendName" validate:"required"`
+
+	// Name of server
+	ServerName string `url:"serverName" validate:"required"`
+
+	// IP address of server
+	Address string `url:"address" validate:"required"`
+
+	// Port of server
+	Port uint64 `url:"port" validate:"required"`
+
+	// Health check of server: enabled or disabled
+	Check string `url:"check,omitempty"`
+
+	HealthCheckSettings
+}
+
+// Request struct for delete backend server
+type BackendServerDeleteRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Name of backend
+	BackendName string `url:"backendName" validate:"required"`
+
+	// Name of server
+	ServerName string `url:"serverName" validate:"required"`
+}
+
+// Request struct for create or update frontend binding
+type FrontendBindRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Name of frontend
+	FrontendName string `url:"frontendName" validate:"required"`
+
+	// Name of binding
+	BindingName string `url:"bindingName" validate:"required"`
+
+	// IP address of binding, the current one is kept on update if empty
+	BindingAddress string `url:"bindingAddress,omitempty"`
+
+	// Port of binding, the current one is kept on update if 0
+	BindingPort uint64 `url:"bindingPort,omitempty"`
+}
+
+// Request struct for delete frontend binding
+type FrontendBindDeleteRequest struct {
+	// ID of load balancer
+	LBID uint64 `url:"lbId" validate:"required"`
+
+	// Name of frontend
+	FrontendName string `url:"frontendName" validate:"required"`
+
+	// Name of binding
+	BindingName string `url:"bindingName" validate:"required"`
+}
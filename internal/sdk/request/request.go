@@ -0,0 +1,240 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package request implements the plumbing shared by all endpoint groups of the DECORT SDK:
+// validation of typed requests, their encoding into url.Values and decoding of API responses.
+//
+// Request structs describe API parameters with struct tags:
+//
+//	type GetRequest struct {
+//		ComputeID uint64 `url:"computeId" validate:"required"`
+//		Reason    string `url:"reason,omitempty"`
+//	}
+//
+// Field tagged with `validate:"required"` must not hold the zero value. Fields with omitempty
+// option are not sent when they hold the zero value, nil pointers are never sent, so that
+// optional parameter, which must be sent to reset it to the zero value, is declared as pointer.
+// Slices are sent as repeated parameters. Requests may additionally implement Validator to check
+// constraints that cannot be expressed with tags.
+//
+// Only exported fields of scalar types (bool, numbers and strings), pointers to them and slices
+// of them can be tagged with url. Validate rejects requests with url tag on any other field, e.g.
+// unexported, nested struct or map one, instead of silently dropping the parameter. Fields
+// without url tag are not sent, except for embedded structs: their fields are sent as if they
+// were fields of the request, so that groups of parameters can be shared between requests.
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+)
+
+// Validator is implemented by requests with constraints beyond required fields
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError is returned when request does not pass validation. No API call is made
+// in this case.
+type ValidationError struct {
+	Request string
+	Field   string
+	Reason  string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("validation error: %s: %s", e.Request, e.Reason)
+	}
+	return fmt.Sprintf("validation error: %s.%s: %s", e.Request, e.Field, e.Reason)
+}
+
+// Invalid is a helper for Validator implementations
+func Invalid(req interface{}, field, format string, args ...interface{}) error {
+	return &ValidationError{
+		Request: reflect.Indirect(reflect.ValueOf(req)).Type().Name(),
+		Field:   field,
+		Reason:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Validate checks url tags and required fields of the request and runs its Validator, if any
+func Validate(req interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return &ValidationError{Request: v.Type().String(), Reason: "request must be a struct"}
+	}
+	if err := validateFields(v.Type().Name(), v); err != nil {
+		return err
+	}
+	if vr, ok := req.(Validator); ok {
+		return vr.Validate()
+	}
+	return nil
+}
+
+func validateFields(name string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("url")
+		if isEmbedded(f) {
+			if err := validateFields(name, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag != "" && tag != "-" {
+			if !f.IsExported() {
+				return &ValidationError{Request: name, Field: f.Name, Reason: "unexported field cannot be sent"}
+			}
+			if !isEncodable(f.Type) {
+				return &ValidationError{Request: name, Field: f.Name, Reason: fmt.Sprintf("fields of type %s cannot be sent", f.Type)}
+			}
+		}
+		if f.Tag.Get("validate") == "required" && v.Field(i).IsZero() {
+			return &ValidationError{Request: name, Field: f.Name, Reason: "is required"}
+		}
+	}
+	return nil
+}
+
+// isEmbedded reports whether f is an embedded struct, which fields are sent with the request
+func isEmbedded(f reflect.StructField) bool {
+	return f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("url") == ""
+}
+
+// isEncodable reports whether values of type t can be encoded by Values
+func isEncodable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Values encodes request struct into url.Values according to its url tags. Request is expected
+// to pass Validate, fields which cannot be encoded are skipped.
+func Values(req interface{}) *url.Values {
+	values := &url.Values{}
+	encode(values, reflect.Indirect(reflect.ValueOf(req)))
+	return values
+}
+
+func encode(values *url.Values, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if isEmbedded(f) {
+			encode(values, v.Field(i))
+			continue
+		}
+		tag := f.Tag.Get("url")
+		if tag == "" || tag == "-" || !f.IsExported() || !isEncodable(f.Type) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		} else if opts == "omitempty" && fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Slice {
+			for j := 0; j < fv.Len(); j++ {
+				values.Add(name, format(fv.Index(j)))
+			}
+			continue
+		}
+		values.Add(name, format(fv))
+	}
+}
+
+func format(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return v.String()
+	}
+}
+
+// Do validates and sends the request to the API path and decodes JSON response into out.
+// If out is nil, the response is discarded.
+func Do(ctx context.Context, caller controller.APICaller, path string, req interface{}, out interface{}) error {
+	if err := Validate(req); err != nil {
+		return err
+	}
+
+	resp, err := caller.DecortAPICall(ctx, "POST", path, Values(req))
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(resp), out); err != nil {
+		return fmt.Errorf("cannot decode response of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Bool sends the request to the API, which responds with a boolean result
+func Bool(ctx context.Context, caller controller.APICaller, path string, req interface{}) (bool, error) {
+	var res bool
+	if err := Do(ctx, caller, path, req, &res); err != nil {
+		return false, err
+	}
+	return res, nil
+}
+
+// ID sends the request to the API, which responds with ID of the created object
+func ID(ctx context.Context, caller controller.APICaller, path string, req interface{}) (uint64, error) {
+	var res uint64
+	if err := Do(ctx, caller, path, req, &res); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
@@ -0,0 +1,243 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type testRequest struct {
+	ID       uint64   `url:"id" validate:"required"`
+	Name     string   `url:"name,omitempty"`
+	Force    bool     `url:"force"`
+	Lease    *uint64  `url:"lease"`
+	Domain   *string  `url:"domain"`
+	Rate     float64  `url:"rate,omitempty"`
+	Delta    int      `url:"delta,omitempty"`
+	IDs      []uint64 `url:"ids,omitempty"`
+	Order    []string `url:"order"`
+	Skipped  string   `url:"-"`
+	Untagged string
+}
+
+type unexportedRequest struct {
+	ID     uint64 `url:"id"`
+	secret string `url:"secret"`
+}
+
+type nestedRequest struct {
+	ID     uint64            `url:"id"`
+	Nested struct{ A int }   `url:"nested"`
+	Labels map[string]string `url:"labels"`
+}
+
+type Settings struct {
+	Rise *uint64 `url:"rise"`
+	Mode string  `url:"mode" validate:"required"`
+}
+
+type embeddingRequest struct {
+	Settings
+	ID uint64 `url:"id"`
+}
+
+type unexportedSettings struct {
+	Name   string `url:"name,omitempty"`
+	secret string `url:"secret"`
+}
+
+type embeddingUnexportedRequest struct {
+	unexportedSettings
+	ID uint64 `url:"id"`
+}
+
+type validatedRequest struct {
+	ID  uint64 `url:"id" validate:"required"`
+	Min uint64 `url:"min"`
+	Max uint64 `url:"max"`
+}
+
+func (r validatedRequest) Validate() error {
+	if r.Max < r.Min {
+		return Invalid(r, "Max", "must not be less than Min")
+	}
+	return nil
+}
+
+func uint64Ptr(v uint64) *uint64 { return &v }
+func stringPtr(v string) *string { return &v }
+
+func TestValues(t *testing.T) {
+	cases := []struct {
+		name string
+		req  interface{}
+		want url.Values
+	}{
+		{
+			name: "zero values",
+			req:  testRequest{ID: 1},
+			want: url.Values{"id": {"1"}, "force": {"false"}},
+		},
+		{
+			name: "omitempty set",
+			req:  testRequest{ID: 1, Name: "vm", Rate: 1.5, Delta: -2},
+			want: url.Values{"id": {"1"}, "name": {"vm"}, "force": {"false"}, "rate": {"1.5"}, "delta": {"-2"}},
+		},
+		{
+			name: "pointers to zero values are sent",
+			req:  testRequest{ID: 1, Lease: uint64Ptr(0), Domain: stringPtr("")},
+			want: url.Values{"id": {"1"}, "force": {"false"}, "lease": {"0"}, "domain": {""}},
+		},
+		{
+			name: "pointers",
+			req:  &testRequest{ID: 1, Force: true, Lease: uint64Ptr(3600), Domain: stringPtr("local")},
+			want: url.Values{"id": {"1"}, "force": {"true"}, "lease": {"3600"}, "domain": {"local"}},
+		},
+		{
+			name: "slices are repeated",
+			req:  testRequest{ID: 1, IDs: []uint64{3, 4}, Order: []string{"hd", "cdrom"}},
+			want: url.Values{"id": {"1"}, "force": {"false"}, "ids": {"3", "4"}, "order": {"hd", "cdrom"}},
+		},
+		{
+			name: "ignored fields",
+			req:  testRequest{ID: 1, Skipped: "a", Untagged: "b"},
+			want: url.Values{"id": {"1"}, "force": {"false"}},
+		},
+		{
+			name: "unsupported fields are skipped",
+			req:  nestedRequest{ID: 1, Labels: map[string]string{"a": "b"}},
+			want: url.Values{"id": {"1"}},
+		},
+		{
+			name: "embedded struct fields are sent",
+			req:  embeddingRequest{Settings: Settings{Rise: uint64Ptr(2), Mode: "http"}, ID: 1},
+			want: url.Values{"id": {"1"}, "rise": {"2"}, "mode": {"http"}},
+		},
+		{
+			name: "unexported embedded struct fields are sent",
+			req:  embeddingUnexportedRequest{unexportedSettings: unexportedSettings{Name: "vm"}, ID: 1},
+			want: url.Values{"id": {"1"}, "name": {"vm"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := *Values(tc.req); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Values() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		req     interface{}
+		wantErr bool
+		field   string
+	}{
+		{name: "valid", req: testRequest{ID: 1}},
+		{name: "valid pointer", req: &testRequest{ID: 1}},
+		{name: "required", req: testRequest{Name: "vm"}, wantErr: true, field: "ID"},
+		{name: "unexported field", req: unexportedRequest{ID: 1}, wantErr: true, field: "secret"},
+		{name: "nested struct", req: nestedRequest{ID: 1}, wantErr: true, field: "Nested"},
+		{name: "embedded struct", req: embeddingRequest{Settings: Settings{Mode: "tcp"}, ID: 1}},
+		{name: "embedded struct required", req: embeddingRequest{ID: 1}, wantErr: true, field: "Mode"},
+		{name: "embedded struct unexported field", req: embeddingUnexportedRequest{ID: 1}, wantErr: true, field: "secret"},
+		{name: "validator passes", req: validatedRequest{ID: 1, Min: 1, Max: 2}},
+		{name: "validator fails", req: validatedRequest{ID: 1, Min: 2, Max: 1}, wantErr: true, field: "Max"},
+		{name: "required before validator", req: validatedRequest{Min: 2, Max: 1}, wantErr: true, field: "ID"},
+		{name: "not a struct", req: "id=1", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.req)
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want ValidationError", err)
+			}
+			if verr.Field != tc.field {
+				t.Errorf("Validate() field = %q, want %q", verr.Field, tc.field)
+			}
+		})
+	}
+}
+
+type recordingCaller struct {
+	path   string
+	values *url.Values
+	resp   string
+}
+
+func (c *recordingCaller) DecortAPICall(ctx context.Context, method string, path string, values *url.Values) (string, error) {
+	c.path = path
+	c.values = values
+	return c.resp, nil
+}
+
+func (c *recordingCaller) GetDecortUsername() string {
+	return "user"
+}
+
+func TestDo(t *testing.T) {
+	t.Run("invalid request is not sent", func(t *testing.T) {
+		caller := &recordingCaller{resp: "true"}
+		_, err := Bool(context.Background(), caller, "/compute/stop", testRequest{})
+		if err == nil {
+			t.Fatal("Bool() succeeded for invalid request")
+		}
+		if caller.path != "" {
+			t.Errorf("invalid request was sent to %s", caller.path)
+		}
+	})
+
+	t.Run("id response", func(t *testing.T) {
+		caller := &recordingCaller{resp: "42"}
+		id, err := ID(context.Background(), caller, "/compute/create", testRequest{ID: 1, Name: "vm"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != 42 {
+			t.Errorf("ID() = %d, want 42", id)
+		}
+		if got := caller.values.Get("name"); got != "vm" {
+			t.Errorf("name = %q, want vm", got)
+		}
+	})
+
+	t.Run("undecodable response", func(t *testing.T) {
+		caller := &recordingCaller{resp: "<html>"}
+		if _, err := Bool(context.Background(), caller, "/compute/stop", testRequest{ID: 1}); err == nil {
+			t.Fatal("Bool() succeeded for undecodable response")
+		}
+	})
+}
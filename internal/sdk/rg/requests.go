@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rg
+
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+
+// Request struct for the endpoints, which only take resource group ID
+type IDRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
+
+// Request struct for get resource group
+type GetRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
+
+// Request struct for list resource groups
+type ListRequest struct {
+	// Include deleted resource groups
+	IncludeDeleted bool `url:"includedeleted"`
+
+	// Page number
+	Page uint64 `url:"page,omitempty"`
+
+	// Page size
+	Size uint64 `url:"size,omitempty"`
+}
+
+// Request struct for create resource group
+type CreateRequest struct {
+	// ID of account
+	AccountID uint64 `url:"accountId" validate:"required"`
+
+	// ID of grid
+	GID uint64 `url:"gid" validate:"required"`
+
+	// Name of resource group
+	Name string `url:"name" validate:"required"`
+
+	// Max size of memory in MB, -1 is unlimited
+	MaxMemoryCapacity *int64 `url:"maxMemoryCapacity"`
+
+	// Max size of aggregated virtual disks in GB, -1 is unlimited
+	MaxVDiskCapacity *int64 `url:"maxVDiskCapacity"`
+
+	// Max number of CPU cores, -1 is unlimited
+	MaxCPUCapacity *int64 `url:"maxCPUCapacity"`
+
+	// Max sent/received network transfer peering, -1 is unlimited
+	MaxNetworkPeerTransfer *int64 `url:"maxNetworkPeerTransfer"`
+
+	// Max number of assigned public IPs, -1 is unlimited
+	MaxNumPublicIP *int64 `url:"maxNumPublicIP"`
+
+	// Username of the owner
+	Owner string `url:"owner,omitempty"`
+
+	// Type of the default network: PRIVATE, PUBLIC or NONE
+	DefNet string `url:"def_net,omitempty"`
+
+	// Private network IP CIDR if default network is PRIVATE
+	IPCIDR string `url:"ipcidr,omitempty"`
+
+	// Description
+	Description string `url:"desc,omitempty"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+
+	// ID of external network
+	ExtNetID uint64 `url:"extNetId,omitempty"`
+
+	// IP address in external network
+	ExtIP string `url:"extIp,omitempty"`
+
+	// Register computes in registration system
+	RegisterComputes bool `url:"registerComputes,omitempty"`
+}
+
+func (r CreateRequest) Validate() error {
+	switch r.DefNet {
+	case "", "PRIVATE", "PUBLIC", "NONE":
+	default:
+		return request.Invalid(r, "DefNet", "must be one of PRIVATE, PUBLIC or NONE, got %q", r.DefNet)
+	}
+	return nil
+}
+
+// Request struct for update resource group
+type UpdateRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// New name
+	Name string `url:"name,omitempty"`
+
+	// New description
+	Description string `url:"desc,omitempty"`
+
+	// Max size of memory in MB, -1 is unlimited
+	MaxMemoryCapacity *int64 `url:"maxMemoryCapacity"`
+
+	// Max size of aggregated virtual disks in GB, -1 is unlimited
+	MaxVDiskCapacity *int64 `url:"maxVDiskCapacity"`
+
+	// Max number of CPU cores, -1 is unlimited
+	MaxCPUCapacity *int64 `url:"maxCPUCapacity"`
+
+	// Max sent/received network transfer peering, -1 is unlimited
+	MaxNetworkPeerTransfer *int64 `url:"maxNetworkPeerTransfer"`
+
+	// Max number of assigned public IPs, -1 is unlimited
+	MaxNumPublicIP *int64 `url:"maxNumPublicIP"`
+
+	// Register computes in registration system
+	RegisterComputes *bool `url:"registerComputes"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
+
+// Request struct for delete resource group
+type DeleteRequest struct {
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Delete even if resource group contains computes
+	Force bool `url:"force"`
+
+	// Delete permanently, bypassing recycle bin
+	Permanently bool `url:"permanently"`
+
+	// Reason for action
+	Reason string `url:"reason,omitempty"`
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rg
+
+import (
+	"context"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+// RG is a group of resource group endpoints
+type RG struct {
+	caller controller.APICaller
+	prefix string
+}
+
+// New returns resource group endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *RG {
+	return &RG{caller: caller, prefix: prefix}
+}
+
+func (r *RG) path(api string) string {
+	return r.prefix + api
+}
+
+// Get returns detailed information about resource group
+func (r *RG) Get(ctx context.Context, req GetRequest) (*RecordResourceGroup, error) {
+	res := &RecordResourceGroup{}
+	if err := request.Do(ctx, r.caller, r.path("/rg/get"), req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List returns resource groups available to the user
+func (r *RG) List(ctx context.Context, req ListRequest) (ListResourceGroups, error) {
+	res := ListResourceGroups{}
+	if err := request.Do(ctx, r.caller, r.path("/rg/list"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Create creates resource group and returns its ID
+func (r *RG) Create(ctx context.Context, req CreateRequest) (uint64, error) {
+	return request.ID(ctx, r.caller, r.path("/rg/create"), req)
+}
+
+// Update changes name, description and quotas of resource group
+func (r *RG) Update(ctx context.Context, req UpdateRequest) (bool, error) {
+	return request.Bool(ctx, r.caller, r.path("/rg/update"), req)
+}
+
+// Delete deletes resource group
+func (r *RG) Delete(ctx context.Context, req DeleteRequest) (bool, error) {
+	return request.Bool(ctx, r.caller, r.path("/rg/delete"), req)
+}
+
+// Restore restores resource group from recycle bin
+func (r *RG) Restore(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, r.caller, r.path("/rg/restore"), req)
+}
+
+// Enable enables resource group
+func (r *RG) Enable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, r.caller, r.path("/rg/enable"), req)
+}
+
+// Disable disables resource group
+func (r *RG) Disable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, r.caller, r.path("/rg/disable"), req)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk is a typed client of the DECORT REST API. Endpoints are grouped the same way
+// as in the API (compute, rg, disks, vins, lb, vgpu, tasks), each group lives in its own
+// subpackage with request structs and response models.
+//
+// Groups are shared between cloudapi and cloudbroker where request and response payloads
+// match, the only difference is the API prefix the client was created with:
+//
+//	client := sdk.New(m.(controller.APICaller))
+//	compute, err := client.Compute().Get(ctx, compute.GetRequest{ComputeID: 42})
+package sdk
+
+import (
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/disks"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/lb"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/rg"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/tasks"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vgpu"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+)
+
+// PlatformVersion is the DECORT platform version the request and response models correspond to
+const PlatformVersion = "3.8.5"
+
+// API prefixes
+const (
+	CloudAPI    = "/restmachine/cloudapi"
+	CloudBroker = "/restmachine/cloudbroker"
+)
+
+// Client is a typed DECORT API client bound to either cloudapi or cloudbroker
+type Client struct {
+	caller controller.APICaller
+	prefix string
+}
+
+// New returns client of the user level cloudapi
+func New(caller controller.APICaller) *Client {
+	return &Client{caller: caller, prefix: CloudAPI}
+}
+
+// NewCloudBroker returns client of the administrative cloudbroker API
+func NewCloudBroker(caller controller.APICaller) *Client {
+	return &Client{caller: caller, prefix: CloudBroker}
+}
+
+// Compute returns compute (kvmvm) endpoints
+func (c *Client) Compute() *compute.Compute {
+	return compute.New(c.caller, c.prefix)
+}
+
+// RG returns resource group endpoints
+func (c *Client) RG() *rg.RG {
+	return rg.New(c.caller, c.prefix)
+}
+
+// Disks returns disk endpoints
+func (c *Client) Disks() *disks.Disks {
+	return disks.New(c.caller, c.prefix)
+}
+
+// Vins returns ViNS endpoints
+func (c *Client) Vins() *vins.Vins {
+	return vins.New(c.caller, c.prefix)
+}
+
+// LB returns load balancer endpoints
+func (c *Client) LB() *lb.LB {
+	return lb.New(c.caller, c.prefix)
+}
+
+// VGPU returns vGPU endpoints
+func (c *Client) VGPU() *vgpu.VGPU {
+	return vgpu.New(c.caller, c.prefix)
+}
+
+// Tasks returns asynchronous task endpoints
+func (c *Client) Tasks() *tasks.Tasks {
+	return tasks.New(c.caller, c.prefix)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Result of asynchronous task, usually ID of the created object. API returns it either as
+// a number, a quoted number or a single element list.
+type TaskResult int
+
+func (r *TaskResult) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 || string(b) == "null" {
+		*r = 0
+		return nil
+	}
+
+	switch b[0] {
+	case '"':
+		s, err := strconv.Unquote(string(b))
+		if err != nil {
+			return err
+		}
+		if s == "" {
+			*r = 0
+			return nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*r = TaskResult(n)
+	case '[':
+		res := []interface{}{}
+		if err := json.Unmarshal(b, &res); err != nil {
+			return err
+		}
+		if len(res) == 0 {
+			*r = 0
+			return nil
+		}
+		n, ok := res[0].(float64)
+		if !ok {
+			return fmt.Errorf("could not unmarshal %v into int", res[0])
+		}
+		*r = TaskResult(n)
+	default:
+		var n float64
+		if err := json.Unmarshal(b, &n); err != nil {
+			// results of other types (e.g. true) carry no ID
+			*r = 0
+			return nil
+		}
+		*r = TaskResult(n)
+	}
+
+	return nil
+}
+
+// AsyncTask represents a long task completion status
+type AsyncTask struct {
+	AuditID     string     `json:"auditId"`
+	Completed   bool       `json:"completed"`
+	Error       string     `json:"error"`
+	Log         []string   `json:"log"`
+	Result      TaskResult `json:"result"`
+	Stage       string     `json:"stage"`
+	Status      string     `json:"status"`
+	UpdateTime  uint64     `json:"updateTime"`
+	UpdatedTime uint64     `json:"updatedTime"`
+}
@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tasks waits for asynchronous DECORT tasks. APIs called in asynchronous mode
+// (e.g. k8s/create) return an audit ID, which is polled with tasks/get until the task
+// is completed.
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+var log = logging.New("tasks")
+
+// Default polling intervals. Interval grows by half on every poll until it reaches
+// DefaultMaxInterval.
+const (
+	DefaultMinInterval = 2 * time.Second
+	DefaultMaxInterval = 30 * time.Second
+)
+
+// Tasks is a group of asynchronous task endpoints
+type Tasks struct {
+	caller controller.APICaller
+	prefix string
+
+	// MinInterval is the delay before the second poll
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between polls
+	MaxInterval time.Duration
+}
+
+// New returns asynchronous task endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *Tasks {
+	return &Tasks{
+		caller:      caller,
+		prefix:      prefix,
+		MinInterval: DefaultMinInterval,
+		MaxInterval: DefaultMaxInterval,
+	}
+}
+
+// Request struct for get task
+type GetRequest struct {
+	// Audit ID returned by asynchronous API
+	AuditID string `url:"auditId" validate:"required"`
+}
+
+// Get returns current status of the task
+func (t *Tasks) Get(ctx context.Context, req GetRequest) (*AsyncTask, error) {
+	res := &AsyncTask{}
+	if err := request.Do(ctx, t.caller, t.prefix+"/tasks/get", req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TaskError is returned when the task completed with error or did not complete in time
+type TaskError struct {
+	AuditID string
+	Stage   string
+	Message string
+	Log     []string
+
+	// Err is the context error if waiting was interrupted, nil if the task itself failed
+	Err error
+}
+
+func (e *TaskError) Error() string {
+	var b strings.Builder
+	if e.Err != nil {
+		fmt.Fprintf(&b, "task %s did not complete at stage %q: %v", e.AuditID, e.Stage, e.Err)
+	} else {
+		fmt.Fprintf(&b, "task %s failed at stage %q: %s", e.AuditID, e.Stage, e.Message)
+	}
+	if len(e.Log) > 0 {
+		b.WriteString("\ntask log:\n  ")
+		b.WriteString(strings.Join(e.Log, "\n  "))
+	}
+	return b.String()
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// Wait polls the task until it is completed or ctx is done, whichever happens first.
+// Resource CRUD contexts carry the resource timeout as deadline, so the wait is bound by it.
+// Stage transitions are logged as progress. On success the completed task is returned,
+// otherwise the error is *TaskError.
+func (t *Tasks) Wait(ctx context.Context, auditID string) (*AsyncTask, error) {
+	auditID = strings.Trim(auditID, `"`)
+	interval := t.MinInterval
+	stage := ""
+	var taskLog []string
+
+	for {
+		task, err := t.Get(ctx, GetRequest{AuditID: auditID})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, &TaskError{AuditID: auditID, Stage: stage, Log: taskLog, Err: ctxErr}
+			}
+			return nil, err
+		}
+
+		if task.Stage != stage {
+			log.Debugf(ctx, "tasks.Wait: task %s stage %q -> %q", auditID, stage, task.Stage)
+			stage = task.Stage
+		}
+		taskLog = task.Log
+
+		if task.Completed {
+			if task.Error != "" {
+				return task, &TaskError{AuditID: auditID, Stage: task.Stage, Message: task.Error, Log: task.Log}
+			}
+			log.Debugf(ctx, "tasks.Wait: task %s completed with result %d", auditID, task.Result)
+			return task, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &TaskError{AuditID: auditID, Stage: stage, Log: taskLog, Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+		interval += interval / 2
+		if interval > t.MaxInterval {
+			interval = t.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedCaller answers tasks/get with the scripted responses in order, repeating the last one
+type scriptedCaller struct {
+	responses []string
+	err       error
+	auditIDs  []string
+}
+
+func (c *scriptedCaller) DecortAPICall(ctx context.Context, method string, path string, values *url.Values) (string, error) {
+	c.auditIDs = append(c.auditIDs, values.Get("auditId"))
+	if c.err != nil {
+		return "", c.err
+	}
+	i := len(c.auditIDs) - 1
+	if i >= len(c.responses) {
+		i = len(c.responses) - 1
+	}
+	return c.responses[i], nil
+}
+
+func (c *scriptedCaller) GetDecortUsername() string {
+	return "user"
+}
+
+func task(t AsyncTask) string {
+	b, _ := json.Marshal(t)
+	return string(b)
+}
+
+func TestTaskResultUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want TaskResult
+	}{
+		{name: "number", in: `42`, want: 42},
+		{name: "quoted number", in: `"42"`, want: 42},
+		{name: "empty string", in: `""`, want: 0},
+		{name: "list", in: `[42, "cluster"]`, want: 42},
+		{name: "empty list", in: `[]`, want: 0},
+		{name: "null", in: `null`, want: 0},
+		{name: "bool", in: `true`, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TaskResult = -1
+			if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWait(t *testing.T) {
+	running := task(AsyncTask{Stage: "creating", Log: []string{"started"}})
+	done := task(AsyncTask{Completed: true, Stage: "done", Result: 7})
+	failed := task(AsyncTask{Completed: true, Stage: "masters", Error: "no resources", Log: []string{"started", "masters"}})
+	callErr := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		responses []string
+		err       error
+		timeout   time.Duration
+		wantPolls int
+		wantRes   TaskResult
+		wantErr   bool
+		wantStage string
+		wantCtx   bool
+	}{
+		{name: "completed at once", responses: []string{done}, wantPolls: 1, wantRes: 7},
+		{name: "completed after polls", responses: []string{running, running, done}, wantPolls: 3, wantRes: 7},
+		{name: "failed", responses: []string{running, failed}, wantPolls: 2, wantErr: true, wantStage: "masters"},
+		{name: "timed out", responses: []string{running}, timeout: 20 * time.Millisecond, wantErr: true, wantStage: "creating", wantCtx: true},
+		{name: "call error", err: callErr, wantPolls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			caller := &scriptedCaller{responses: tt.responses, err: tt.err}
+			tasks := New(caller, "/restmachine/cloudapi")
+			tasks.MinInterval = time.Millisecond
+			tasks.MaxInterval = 2 * time.Millisecond
+
+			res, err := tasks.Wait(ctx, `"audit-1"`)
+			if tt.wantPolls != 0 && len(caller.auditIDs) != tt.wantPolls {
+				t.Errorf("Wait() polled %d times, want %d", len(caller.auditIDs), tt.wantPolls)
+			}
+			for _, id := range caller.auditIDs {
+				if id != "audit-1" {
+					t.Fatalf("Wait() polled audit ID %q, want quotes trimmed", id)
+				}
+			}
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Wait() error = %v", err)
+				}
+				if res.Result != tt.wantRes {
+					t.Errorf("Wait() result = %d, want %d", res.Result, tt.wantRes)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("Wait() succeeded, want error")
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Wait() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+
+			var taskErr *TaskError
+			if !errors.As(err, &taskErr) {
+				t.Fatalf("Wait() error = %T, want *TaskError", err)
+			}
+			if taskErr.Stage != tt.wantStage {
+				t.Errorf("TaskError.Stage = %q, want %q", taskErr.Stage, tt.wantStage)
+			}
+			if got := errors.Is(err, context.DeadlineExceeded); got != tt.wantCtx {
+				t.Errorf("errors.Is(err, context.DeadlineExceeded) = %v, want %v", got, tt.wantCtx)
+			}
+			if len(taskErr.Log) == 0 || !strings.Contains(err.Error(), "task log:") {
+				t.Errorf("Wait() error %q does not carry task log", err)
+			}
+		})
+	}
+}
+
+func TestWaitBackoff(t *testing.T) {
+	caller := &scriptedCaller{responses: []string{task(AsyncTask{Stage: "creating"})}}
+	tasks := New(caller, "/restmachine/cloudapi")
+	tasks.MinInterval = 2 * time.Millisecond
+	tasks.MaxInterval = 4 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := tasks.Wait(ctx, "audit-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() error = %v, want deadline exceeded", err)
+	}
+
+	// intervals of 2, 3, 4, 4... ms leave room for at least a few polls, but not for
+	// polling every millisecond
+	polls := len(caller.auditIDs)
+	if polls < 3 || time.Since(start) < 40*time.Millisecond || polls > 26 {
+		t.Errorf("Wait() polled %d times in %s", polls, time.Since(start))
+	}
+}
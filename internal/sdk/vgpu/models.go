@@ -1,37 +1,54 @@
-/*
-Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
-Authors:
-Petr Krutov, <petr.krutov@digitalenergy.online>
-Stanislav Solovev, <spsolovev@digitalenergy.online>
-Kasim Baybikov, <kmbaybikov@basistech.ru>
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-/*
-Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
-Orchestration Technology) with Terraform by Hashicorp.
-
-Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
-
-Please see README.md to learn where to place source code so that it
-builds seamlessly.
-
-Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
-*/
-
-package pfw
-
-const ComputePfwListAPI = "/restmachine/cloudapi/compute/pfwList"
-const ComputePfwAddAPI = "/restmachine/cloudapi/compute/pfwAdd"
-const ComputePfwDelAPI = "/restmachine/cloudapi/compute/pfwDel"
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+// Detailed information about vGPU
+type ItemVGPU struct {
+	// ID of account
+	AccountID uint64 `json:"accountId"`
+
+	// ID of vGPU
+	ID uint64 `json:"id"`
+
+	// Mode
+	Mode string `json:"mode"`
+
+	// ID of physical GPU
+	PGPUID uint64 `json:"pgpuid"`
+
+	// ID of vGPU profile
+	ProfileID uint64 `json:"profileId"`
+
+	// Amount of video memory
+	RAM uint64 `json:"ram"`
+
+	// Status
+	Status string `json:"status"`
+
+	// Type
+	Type string `json:"type"`
+
+	// ID of compute the vGPU is attached to, 0 if it is free
+	VMID uint64 `json:"vmid"`
+}
+
+// List of vGPUs
+type ListVGPUs []ItemVGPU
@@ -1,36 +1,42 @@
-/*
-Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
-Authors:
-Petr Krutov, <petr.krutov@digitalenergy.online>
-Stanislav Solovev, <spsolovev@digitalenergy.online>
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-/*
-Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
-Orchestration Technology) with Terraform by Hashicorp.
-
-Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
-
-Please see README.md to learn where to place source code so that it
-builds seamlessly.
-
-Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
-*/
-
-package pfw
-
-const ComputePfwListAPI = "/restmachine/cloudbroker/compute/pfwList"
-const ComputePfwAddAPI = "/restmachine/cloudbroker/compute/pfwAdd"
-const ComputePfwDelAPI = "/restmachine/cloudbroker/compute/pfwDel"
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+// Request struct for list vGPUs
+type ListRequest struct {
+	// Include deleted vGPUs
+	IncludeDeleted bool `url:"includeDeleted"`
+
+	// Page number
+	Page uint64 `url:"page,omitempty"`
+
+	// Page size
+	Size uint64 `url:"size,omitempty"`
+}
+
+// Request struct for list vGPUs of account
+type ListInAccountRequest struct {
+	// ID of account
+	AccountID uint64 `url:"accountId" validate:"required"`
+
+	// Include deleted vGPUs
+	IncludeDeleted bool `url:"includedeleted"`
+}
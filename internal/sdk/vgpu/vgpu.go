@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+import (
+	"context"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+// VGPU is a group of vGPU endpoints. All vGPUs of the platform are listed with cloudbroker
+// only, cloudapi lists vGPUs of an account.
+type VGPU struct {
+	caller controller.APICaller
+	prefix string
+}
+
+// New returns vGPU endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *VGPU {
+	return &VGPU{caller: caller, prefix: prefix}
+}
+
+func (v *VGPU) path(api string) string {
+	return v.prefix + api
+}
+
+// List returns vGPUs of the platform
+func (v *VGPU) List(ctx context.Context, req ListRequest) (ListVGPUs, error) {
+	res := ListVGPUs{}
+	if err := request.Do(ctx, v.caller, v.path("/vgpu/list"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListInAccount returns vGPUs of the account
+func (v *VGPU) ListInAccount(ctx context.Context, req ListInAccountRequest) (ListVGPUs, error) {
+	res := ListVGPUs{}
+	if err := request.Do(ctx, v.caller, v.path("/account/listVGPU"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vins
+
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+
+// Request struct for the endpoints, which only take ViNS ID
+type IDRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+}
+
+// Request struct for list ViNSes
+type ListRequest struct {
+	// Include deleted ViNSes
+	IncludeDeleted bool `url:"includeDeleted"`
+
+	// Page number
+	Page uint64 `url:"page,omitempty"`
+
+	// Page size
+	Size uint64 `url:"size,omitempty"`
+}
+
+// Request struct for create ViNS in resource group
+type CreateInRGRequest struct {
+	// Name of ViNS
+	Name string `url:"name" validate:"required"`
+
+	// ID of resource group
+	RGID uint64 `url:"rgId" validate:"required"`
+
+	// Private network IP CIDR
+	IPCIDR string `url:"ipcidr,omitempty"`
+
+	// ID of external network to connect to
+	ExtNetID uint64 `url:"extNetId,omitempty"`
+
+	// IP address in external network
+	ExtIP string `url:"extIp,omitempty"`
+
+	// Description
+	Description string `url:"desc,omitempty"`
+
+	// Number of pre created reservations
+	PreReservationsNum uint64 `url:"preReservationsNum,omitempty"`
+}
+
+// Request struct for create ViNS in account
+type CreateInAccountRequest struct {
+	// Name of ViNS
+	Name string `url:"name" validate:"required"`
+
+	// ID of account
+	AccountID uint64 `url:"accountId" validate:"required"`
+
+	// ID of grid
+	GID uint64 `url:"gid,omitempty"`
+
+	// Private network IP CIDR
+	IPCIDR string `url:"ipcidr,omitempty"`
+
+	// Description
+	Description string `url:"desc,omitempty"`
+
+	// Number of pre created reservations
+	PreReservationsNum uint64 `url:"preReservationsNum,omitempty"`
+}
+
+// Request struct for delete ViNS
+type DeleteRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// Delete even if ViNS has connected computes
+	Force bool `url:"force"`
+
+	// Delete permanently, bypassing recycle bin
+	Permanently bool `url:"permanently"`
+}
+
+// Request struct for connect ViNS to external network
+type ExtNetConnectRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// ID of external network
+	NetID uint64 `url:"netId,omitempty"`
+
+	// IP address in external network
+	IP string `url:"Ip,omitempty"`
+}
+
+// Request struct for reserve IP address
+type IPReserveRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// Type of reservation: DHCP, VIP or EXCLUDE
+	Type string `url:"type" validate:"required"`
+
+	// IP address, allocated automatically if empty
+	IPAddr string `url:"ipAddr,omitempty"`
+
+	// MAC address
+	MAC string `url:"mac,omitempty"`
+
+	// ID of compute
+	ComputeID uint64 `url:"computeId,omitempty"`
+}
+
+func (r IPReserveRequest) Validate() error {
+	switch r.Type {
+	case "DHCP", "VIP", "EXCLUDE":
+		return nil
+	}
+	return request.Invalid(r, "Type", "must be one of DHCP, VIP or EXCLUDE, got %q", r.Type)
+}
+
+// Request struct for release IP address reservation
+type IPReleaseRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// IP address, all reservations are released if both IPAddr and MAC are empty
+	IPAddr string `url:"ipAddr,omitempty"`
+
+	// MAC address
+	MAC string `url:"mac,omitempty"`
+}
+
+// Request struct for create NAT rule
+type NATRuleAddRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// Internal IP address
+	IntIP string `url:"intIp" validate:"required"`
+
+	// Internal port
+	IntPort uint64 `url:"intPort" validate:"required"`
+
+	// Start of external ports range
+	ExtPortStart uint64 `url:"extPortStart" validate:"required"`
+
+	// End of external ports range
+	ExtPortEnd uint64 `url:"extPortEnd,omitempty"`
+
+	// Protocol: tcp or udp
+	Proto string `url:"proto,omitempty"`
+}
+
+func (r NATRuleAddRequest) Validate() error {
+	if r.ExtPortEnd != 0 && r.ExtPortEnd < r.ExtPortStart {
+		return request.Invalid(r, "ExtPortEnd", "must not be less than ExtPortStart")
+	}
+	switch r.Proto {
+	case "", "tcp", "udp":
+		return nil
+	}
+	return request.Invalid(r, "Proto", "must be either tcp or udp, got %q", r.Proto)
+}
+
+// Request struct for delete NAT rule
+type NATRuleDelRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// ID of NAT rule, -1 deletes all rules
+	RuleID int64 `url:"ruleId" validate:"required"`
+}
+
+// Request struct for apply DNS servers to ViNS DHCP
+type DNSApplyRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// IP addresses of DNS servers, default DNS servers are used if empty
+	DNSList []string `url:"dnsList,omitempty"`
+}
+
+// Request struct for configure ViNS DHCP. Options left nil are not changed, so that an option
+// is reset by sending it empty.
+type DHCPConfigureRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// First IP address of the lease range
+	IPStart *string `url:"ipStart"`
+
+	// Last IP address of the lease range
+	IPEnd *string `url:"ipEnd"`
+
+	// Lease time in seconds
+	Lease *uint64 `url:"lease"`
+
+	// Default gateway announced to clients instead of the ViNS gateway, empty resets it to the ViNS gateway
+	DefaultGW *string `url:"defaultGw"`
+
+	// Domain name announced to clients
+	Domain *string `url:"domain"`
+}
+
+func (r DHCPConfigureRequest) Validate() error {
+	if (r.IPStart == nil) != (r.IPEnd == nil) {
+		return request.Invalid(r, "IPEnd", "lease range requires both IPStart and IPEnd")
+	}
+	return nil
+}
+
+// Request struct for set default QoS of ViNS. Limits left nil are not changed.
+type NetQOSRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// Ingress rate, kbit/s
+	IngressRate *uint64 `url:"ingress_rate"`
+
+	// Ingress burst, kbit
+	IngressBurst *uint64 `url:"ingress_birst"`
+
+	// Egress rate, kbit/s
+	EgressRate *uint64 `url:"egress_rate"`
+}
+
+// Request struct for add static route to ViNS
+type StaticRouteAddRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// Destination network address
+	Destination string `url:"destination" validate:"required"`
+
+	// Destination network mask
+	Netmask string `url:"netmask" validate:"required"`
+
+	// IP address of the next hop
+	Gateway string `url:"gateway" validate:"required"`
+
+	// IDs of computes to push the route to, the route is only applied to ViNS if empty
+	ComputeIDs []uint64 `url:"computeIds,omitempty"`
+}
+
+// Request struct for delete static route of ViNS
+type StaticRouteDelRequest struct {
+	// ID of ViNS
+	VinsID uint64 `url:"vinsId" validate:"required"`
+
+	// ID of static route
+	RouteID uint64 `url:"routeId" validate:"required"`
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vins
+
+import (
+	"context"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/request"
+)
+
+// Vins is a group of ViNS (virtual network) endpoints
+type Vins struct {
+	caller controller.APICaller
+	prefix string
+
+	// MinInterval is the delay before the second poll in WaitExtNet
+	MinInterval time.Duration
+
+	// MaxInterval caps the delay between polls in WaitExtNet
+	MaxInterval time.Duration
+}
+
+// New returns ViNS endpoints under the given API prefix
+func New(caller controller.APICaller, prefix string) *Vins {
+	return &Vins{
+		caller:      caller,
+		prefix:      prefix,
+		MinInterval: DefaultMinInterval,
+		MaxInterval: DefaultMaxInterval,
+	}
+}
+
+func (v *Vins) path(api string) string {
+	return v.prefix + api
+}
+
+// Get returns detailed information about ViNS
+func (v *Vins) Get(ctx context.Context, req IDRequest) (*VINSDetailed, error) {
+	res := &VINSDetailed{}
+	if err := request.Do(ctx, v.caller, v.path("/vins/get"), req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// List returns ViNSes available to the user
+func (v *Vins) List(ctx context.Context, req ListRequest) (VINSList, error) {
+	res := VINSList{}
+	if err := request.Do(ctx, v.caller, v.path("/vins/list"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateInRG creates ViNS in resource group and returns its ID
+func (v *Vins) CreateInRG(ctx context.Context, req CreateInRGRequest) (uint64, error) {
+	return request.ID(ctx, v.caller, v.path("/vins/createInRG"), req)
+}
+
+// CreateInAccount creates ViNS in account and returns its ID
+func (v *Vins) CreateInAccount(ctx context.Context, req CreateInAccountRequest) (uint64, error) {
+	return request.ID(ctx, v.caller, v.path("/vins/createInAccount"), req)
+}
+
+// Delete deletes ViNS
+func (v *Vins) Delete(ctx context.Context, req DeleteRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/delete"), req)
+}
+
+// Restore restores ViNS from recycle bin
+func (v *Vins) Restore(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/restore"), req)
+}
+
+// Enable enables ViNS
+func (v *Vins) Enable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/enable"), req)
+}
+
+// Disable disables ViNS
+func (v *Vins) Disable(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/disable"), req)
+}
+
+// ExtNetConnect connects ViNS to external network
+func (v *Vins) ExtNetConnect(ctx context.Context, req ExtNetConnectRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/extNetConnect"), req)
+}
+
+// ExtNetDisconnect disconnects ViNS from external network
+func (v *Vins) ExtNetDisconnect(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/extNetDisconnect"), req)
+}
+
+// IPList returns DHCP reservations of ViNS
+func (v *Vins) IPList(ctx context.Context, req IDRequest) (IPList, error) {
+	res := IPList{}
+	if err := request.Do(ctx, v.caller, v.path("/vins/ipList"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IPReserve reserves IP address in ViNS and returns it
+func (v *Vins) IPReserve(ctx context.Context, req IPReserveRequest) (string, error) {
+	var res string
+	if err := request.Do(ctx, v.caller, v.path("/vins/ipReserve"), req, &res); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// IPRelease releases IP address reservation in ViNS
+func (v *Vins) IPRelease(ctx context.Context, req IPReleaseRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/ipRelease"), req)
+}
+
+// NATRuleList returns NAT rules of ViNS
+func (v *Vins) NATRuleList(ctx context.Context, req IDRequest) (NATRuleList, error) {
+	res := NATRuleList{}
+	if err := request.Do(ctx, v.caller, v.path("/vins/natRuleList"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NATRuleAdd creates NAT rule in ViNS and returns its ID
+func (v *Vins) NATRuleAdd(ctx context.Context, req NATRuleAddRequest) (uint64, error) {
+	return request.ID(ctx, v.caller, v.path("/vins/natRuleAdd"), req)
+}
+
+// NATRuleDel deletes NAT rule of ViNS
+func (v *Vins) NATRuleDel(ctx context.Context, req NATRuleDelRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/natRuleDel"), req)
+}
+
+// DNSApply sets DNS servers announced by ViNS DHCP
+func (v *Vins) DNSApply(ctx context.Context, req DNSApplyRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/dnsApply"), req)
+}
+
+// DHCPConfigure changes lease range, lease time, gateway and domain announced by ViNS DHCP
+func (v *Vins) DHCPConfigure(ctx context.Context, req DHCPConfigureRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/dhcpConfigure"), req)
+}
+
+// NetQOS sets default QoS limits of ViNS
+func (v *Vins) NetQOS(ctx context.Context, req NetQOSRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/netQos"), req)
+}
+
+// StaticRouteList returns static routes of ViNS
+func (v *Vins) StaticRouteList(ctx context.Context, req IDRequest) (ListStaticRoutes, error) {
+	res := ListStaticRoutes{}
+	if err := request.Do(ctx, v.caller, v.path("/vins/staticRouteList"), req, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// StaticRouteAdd adds static route to ViNS
+func (v *Vins) StaticRouteAdd(ctx context.Context, req StaticRouteAddRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/staticRouteAdd"), req)
+}
+
+// StaticRouteDel deletes static route of ViNS
+func (v *Vins) StaticRouteDel(ctx context.Context, req StaticRouteDelRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/staticRouteDel"), req)
+}
+
+// VnfdevRestart restarts virtual network functions device of ViNS
+func (v *Vins) VnfdevRestart(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/vnfdevRestart"), req)
+}
+
+// VnfdevRedeploy redeploys virtual network functions device of ViNS
+func (v *Vins) VnfdevRedeploy(ctx context.Context, req IDRequest) (bool, error) {
+	return request.Bool(ctx, v.caller, v.path("/vins/vnfdevRedeploy"), req)
+}
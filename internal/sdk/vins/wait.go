@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vins
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+)
+
+var log = logging.New("vins")
+
+// Default polling intervals of WaitExtNet. Interval grows by half on every poll until
+// it reaches DefaultMaxInterval.
+const (
+	DefaultMinInterval = 2 * time.Second
+	DefaultMaxInterval = 15 * time.Second
+)
+
+// ExtNetError is returned when ViNS did not reach the expected external network connection
+type ExtNetError struct {
+	VinsID   uint64
+	Want     uint64
+	ExtNetID uint64
+
+	// Err is the context error waiting was interrupted with
+	Err error
+}
+
+func (e *ExtNetError) Error() string {
+	if e.Want == 0 {
+		return fmt.Sprintf("ViNS %d was not disconnected from external network %d: %v", e.VinsID, e.ExtNetID, e.Err)
+	}
+	return fmt.Sprintf("ViNS %d was not connected to external network %d, connected to %d: %v",
+		e.VinsID, e.Want, e.ExtNetID, e.Err)
+}
+
+func (e *ExtNetError) Unwrap() error {
+	return e.Err
+}
+
+// WaitExtNet polls vins/get until ViNS gateway is connected to external network extNetID
+// and has got an IP address in it, or ctx is done, whichever happens first. Zero extNetID
+// waits for ViNS to be disconnected. On success the ViNS is returned, otherwise the error
+// is *ExtNetError or API error.
+func (v *Vins) WaitExtNet(ctx context.Context, vinsID, extNetID uint64) (*VINSDetailed, error) {
+	interval := v.MinInterval
+	// external network ViNS was last seen connected to, reported if waiting is interrupted
+	var lastExtNetID uint64
+
+	for {
+		vins, err := v.Get(ctx, IDRequest{VinsID: vinsID})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, &ExtNetError{VinsID: vinsID, Want: extNetID, ExtNetID: lastExtNetID, Err: ctxErr}
+			}
+			return nil, err
+		}
+
+		gw := vins.VNFS.GW.Config
+		lastExtNetID = gw.ExtNetID
+		if gw.ExtNetID == extNetID && (extNetID == 0 || gw.ExtNetIP != "") {
+			return vins, nil
+		}
+		log.Debugf(ctx, "vins.WaitExtNet: ViNS %d is connected to external network %d, waiting for %d", vinsID, gw.ExtNetID, extNetID)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &ExtNetError{VinsID: vinsID, Want: extNetID, ExtNetID: gw.ExtNetID, Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+		interval += interval / 2
+		if interval > v.MaxInterval {
+			interval = v.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vins
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+)
+
+const getPath = "/restmachine/cloudapi/vins/get"
+
+// putVins puts ViNS connected to external network extNetID with address extNetIP into the fake controller
+func putVins(s *fake.Server, id, extNetID int, extNetIP string) {
+	s.Put(fake.KindVins, id, fake.Object{
+		"id":     id,
+		"name":   "vins",
+		"status": "ENABLED",
+		"vnfs": fake.Object{
+			"GW": fake.Object{"config": fake.Object{"ext_net_id": extNetID, "ext_net_ip": extNetIP}},
+		},
+	})
+}
+
+// connectAfter makes vins/get of the fake controller report ViNS as is until the given poll,
+// which finds it connected to external network extNetID with address extNetIP
+func connectAfter(s *fake.Server, vinsID int, poll int, extNetID int, extNetIP string) {
+	polls := 0
+	s.Handle(getPath, func(s *fake.Server, form url.Values) (interface{}, error) {
+		polls++
+		if polls == poll {
+			putVins(s, vinsID, extNetID, extNetIP)
+		}
+		obj, ok := s.Get(fake.KindVins, vinsID)
+		if !ok {
+			return nil, &fake.Error{StatusCode: http.StatusNotFound, Message: "vins not found"}
+		}
+		return obj, nil
+	})
+}
+
+func TestWaitExtNet(t *testing.T) {
+	tests := []struct {
+		name         string
+		extNetID     int
+		extNetIP     string
+		connectAt    int
+		connectNetID int
+		connectIP    string
+		want         uint64
+		timeout      time.Duration
+		missing      bool
+		wantPolls    int
+		wantErr      bool
+		wantExtNetID uint64
+		wantCtx      bool
+	}{
+		{name: "connected at once", extNetID: 5, extNetIP: "10.0.0.2", want: 5, wantPolls: 1},
+		{name: "disconnected at once", want: 0, wantPolls: 1},
+		{name: "connects after polls", connectAt: 3, connectNetID: 5, connectIP: "10.0.0.2", want: 5, wantPolls: 3},
+		{name: "waits for address", extNetID: 5, connectAt: 2, connectNetID: 5, connectIP: "10.0.0.2", want: 5, wantPolls: 2},
+		{name: "disconnects after polls", extNetID: 5, extNetIP: "10.0.0.2", connectAt: 2, want: 0, wantPolls: 2},
+		{name: "timed out on another network", extNetID: 6, extNetIP: "10.0.1.2", want: 5,
+			timeout: 20 * time.Millisecond, wantErr: true, wantExtNetID: 6, wantCtx: true},
+		{name: "timed out without address", extNetID: 5, want: 5,
+			timeout: 20 * time.Millisecond, wantErr: true, wantExtNetID: 5, wantCtx: true},
+		{name: "not found", missing: true, want: 5, wantPolls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			defer s.Close()
+
+			id := s.NewID()
+			if !tt.missing {
+				putVins(s, id, tt.extNetID, tt.extNetIP)
+			}
+			if tt.connectAt != 0 {
+				connectAfter(s, id, tt.connectAt, tt.connectNetID, tt.connectIP)
+			}
+
+			ctx := context.Background()
+			if tt.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			api := New(s.Client(), "/restmachine/cloudapi")
+			api.MinInterval = time.Millisecond
+			api.MaxInterval = 2 * time.Millisecond
+
+			vins, err := api.WaitExtNet(ctx, uint64(id), tt.want)
+			if tt.wantPolls != 0 && s.Calls(getPath) != tt.wantPolls {
+				t.Errorf("WaitExtNet() polled %d times, want %d", s.Calls(getPath), tt.wantPolls)
+			}
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("WaitExtNet() error = %v", err)
+				}
+				if gw := vins.VNFS.GW.Config; vins.ID != uint64(id) || gw.ExtNetID != tt.want {
+					t.Errorf("WaitExtNet() returned ViNS ID %d connected to %d, want ViNS ID %d connected to %d", vins.ID, gw.ExtNetID, id, tt.want)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("WaitExtNet() succeeded, want error")
+			}
+			if tt.missing {
+				if !controller.IsNotFound(err) {
+					t.Errorf("WaitExtNet() error = %v, want not found API error", err)
+				}
+				return
+			}
+
+			var extNetErr *ExtNetError
+			if !errors.As(err, &extNetErr) {
+				t.Fatalf("WaitExtNet() error = %T, want *ExtNetError", err)
+			}
+			if extNetErr.VinsID != uint64(id) || extNetErr.Want != tt.want || extNetErr.ExtNetID != tt.wantExtNetID {
+				t.Errorf("ExtNetError = ViNS %d connected to %d waiting for %d, want ViNS %d connected to %d waiting for %d",
+					extNetErr.VinsID, extNetErr.ExtNetID, extNetErr.Want, id, tt.wantExtNetID, tt.want)
+			}
+			if got := errors.Is(err, context.DeadlineExceeded); got != tt.wantCtx {
+				t.Errorf("errors.Is(err, context.DeadlineExceeded) = %v, want %v", got, tt.wantCtx)
+			}
+		})
+	}
+}
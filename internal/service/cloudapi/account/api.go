@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package account
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const accountAddUserAPI = "/restmachine/cloudapi/account/addUser"
 const accountAuditsAPI = "/restmachine/cloudapi/account/audits"
 const accountCreateAPI = "/restmachine/cloudapi/account/create"
@@ -54,3 +56,5 @@ const accountListVinsAPI = "/restmachine/cloudapi/account/listVins"
 const accountRestoreAPI = "/restmachine/cloudapi/account/restore"
 const accountUpdateAPI = "/restmachine/cloudapi/account/update"
 const accountUpdateUserAPI = "/restmachine/cloudapi/account/updateUser"
+
+var log = logging.New("account")
@@ -40,16 +40,15 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 )
 
 func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountCreate")
+	log.Debugf(ctx, "resourceAccountCreate")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("name", d.Get("account_name").(string))
@@ -168,9 +167,9 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountRead: called for account with ID: %v", d.Id())
+	log.Debugf(ctx, "resourceAccountRead: called for account with ID: %v", d.Id())
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	acc, err := utilityAccountCheckPresence(ctx, d, m)
 	if err != nil {
@@ -197,7 +196,7 @@ func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 		hasChanged = true
 	case status.Disabled:
-		log.Debugf("The account is in status: %s, troubles may occur with update. Please, enable account first.", acc.Status)
+		log.Debugf(ctx, "The account is in status: %s, troubles may occur with update. Please, enable account first.", acc.Status)
 	case status.Confirmed:
 	}
 
@@ -215,7 +214,7 @@ func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountDelete")
+	log.Debugf(ctx, "resourceAccountDelete")
 
 	account, err := utilityAccountCheckPresence(ctx, d, m)
 	if account == nil {
@@ -225,7 +224,7 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("accountId", strconv.Itoa(d.Get("account_id").(int)))
 	urlValues.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
@@ -240,8 +239,8 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountEdit")
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceAccountEdit")
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 
@@ -270,7 +269,7 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 		hasChanged = true
 	case status.Disabled:
-		log.Debugf("The account is in status: %s, troubles may occur with update. Please, enable account first.", acc.Status)
+		log.Debugf(ctx, "The account is in status: %s, troubles may occur with update. Please, enable account first.", acc.Status)
 	case status.Confirmed:
 	}
 
@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityAccountCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*AccountWithResources, error) {
 	account := &AccountWithResources{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if (strconv.Itoa(d.Get("account_id").(int))) != "0" {
@@ -55,7 +54,7 @@ func utilityAccountCheckPresence(ctx context.Context, d *schema.ResourceData, m
 		urlValues.Add("accountId", d.Id())
 	}
 
-	log.Debugf("utilityAccountCheckPresence: load account")
+	log.Debugf(ctx, "utilityAccountCheckPresence: load account")
 	accountRaw, err := c.DecortAPICall(ctx, "POST", accountGetAPI, urlValues)
 	if err != nil {
 		return nil, err
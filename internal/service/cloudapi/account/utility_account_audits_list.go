@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,12 +45,12 @@ import (
 
 func utilityAccountAuditsListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (AccountAuditsList, error) {
 	accountAuditsList := AccountAuditsList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("accountId", strconv.Itoa(d.Get("account_id").(int)))
 
-	log.Debugf("utilityAccountAuditsListCheckPresence: load account list")
+	log.Debugf(ctx, "utilityAccountAuditsListCheckPresence: load account list")
 	accountAuditsListRaw, err := c.DecortAPICall(ctx, "POST", accountAuditsAPI, urlValues)
 	if err != nil {
 		return nil, err
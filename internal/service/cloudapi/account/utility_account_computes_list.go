@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,12 +45,12 @@ import (
 
 func utilityAccountComputesListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (AccountComputesList, error) {
 	accountComputesList := AccountComputesList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("accountId", strconv.Itoa(d.Get("account_id").(int)))
 
-	log.Debugf("utilityAccountComputesListCheckPresence: load account list")
+	log.Debugf(ctx, "utilityAccountComputesListCheckPresence: load account list")
 	accountComputesListRaw, err := c.DecortAPICall(ctx, "POST", accountListComputesAPI, urlValues)
 	if err != nil {
 		return nil, err
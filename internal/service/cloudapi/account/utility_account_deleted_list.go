@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityAccountDeletedListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (AccountCloudApiList, error) {
 	accountDeletedList := AccountCloudApiList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if page, ok := d.GetOk("page"); ok {
@@ -56,7 +55,7 @@ func utilityAccountDeletedListCheckPresence(ctx context.Context, d *schema.Resou
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityAccountDeletedListCheckPresence: load")
+	log.Debugf(ctx, "utilityAccountDeletedListCheckPresence: load")
 	accountDeletedListRaw, err := c.DecortAPICall(ctx, "POST", accountListDeletedAPI, urlValues)
 	if err != nil {
 		return nil, err
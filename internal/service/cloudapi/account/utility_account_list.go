@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityAccountListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (AccountCloudApiList, error) {
 	accountList := AccountCloudApiList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if page, ok := d.GetOk("page"); ok {
@@ -56,7 +55,7 @@ func utilityAccountListCheckPresence(ctx context.Context, d *schema.ResourceData
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityAccountListCheckPresence: load account list")
+	log.Debugf(ctx, "utilityAccountListCheckPresence: load account list")
 	accountListRaw, err := c.DecortAPICall(ctx, "POST", accountListAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -83,7 +82,7 @@ func utilityAccountListCheckPresence(d *schema.ResourceData, m interface{}) (Acc
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityAccountListCheckPresence: load account list")
+	log.Debugf(ctx, "utilityAccountListCheckPresence: load account list")
 	accountListRaw, err := controller.decortAPICall("POST", accountListAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package bservice
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const bserviceCreateAPI = "/restmachine/cloudapi/bservice/create"
 const bserviceDeleteAPI = "/restmachine/cloudapi/bservice/delete"
 const bserviceDisableAPI = "/restmachine/cloudapi/bservice/disable"
@@ -58,3 +60,5 @@ const bserviceSnapshotListAPI = "/restmachine/cloudapi/bservice/snapshotList"
 const bserviceSnapshotRollbackAPI = "/restmachine/cloudapi/bservice/snapshotRollback"
 const bserviceStartAPI = "/restmachine/cloudapi/bservice/start"
 const bserviceStopAPI = "/restmachine/cloudapi/bservice/stop"
+
+var log = logging.New("bservice")
@@ -157,8 +157,9 @@ func dataSourceBasicServiceGroupSchemaMake() map[string]*schema.Schema {
 									Computed: true,
 								},
 								"password": {
-									Type:     schema.TypeString,
-									Computed: true,
+									Type:      schema.TypeString,
+									Sensitive: true,
+									Computed:  true,
 								},
 							},
 						},
@@ -39,16 +39,16 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 )
 
 func resourceBasicServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceCreate")
+	log.Debugf(ctx, "resourceBasicServiceCreate")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	haveRGID, err := existRGID(ctx, d, m)
@@ -89,9 +89,9 @@ func resourceBasicServiceCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceBasicServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceRead")
+	log.Debugf(ctx, "resourceBasicServiceRead")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	bs, err := utilityBasicServiceCheckPresence(ctx, d, m)
 	if err != nil {
@@ -108,10 +108,13 @@ func resourceBasicServiceRead(ctx context.Context, d *schema.ResourceData, m int
 	case status.Enabled:
 	case status.Enabling:
 	case status.Disabled:
-		log.Debugf("The basic service is in status: %s, troubles can occur with the update. Please, enable bservice first.", bs.Status)
+		log.Debugf(ctx, "The basic service is in status: %s, troubles can occur with the update. Please, enable bservice first.", bs.Status)
 	case status.Disabling:
-		log.Debugf("The basic service is in status: %s, troubles can occur with the update.", bs.Status)
+		log.Debugf(ctx, "The basic service is in status: %s, troubles can occur with the update.", bs.Status)
 	case status.Deleted:
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "Basic service", bs.Status)
+		}
 		urlVal := &url.Values{}
 		urlVal.Add("serviceId", d.Id())
 
@@ -123,6 +126,9 @@ func resourceBasicServiceRead(ctx context.Context, d *schema.ResourceData, m int
 		hasChanged = true
 	case status.Deleting:
 	case status.Destroyed:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "Basic service", bs.Status)
+		}
 		d.SetId("")
 		return resourceBasicServiceCreate(ctx, d, m)
 	case status.Destroying:
@@ -145,7 +151,7 @@ func resourceBasicServiceRead(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceBasicServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceDelete")
+	log.Debugf(ctx, "resourceBasicServiceDelete")
 
 	bs, err := utilityBasicServiceCheckPresence(ctx, d, m)
 	if bs == nil {
@@ -155,7 +161,7 @@ func resourceBasicServiceDelete(ctx context.Context, d *schema.ResourceData, m i
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("serviceId", strconv.Itoa(d.Get("service_id").(int)))
 	urlValues.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
@@ -170,8 +176,8 @@ func resourceBasicServiceDelete(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceBasicServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceEdit")
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceBasicServiceEdit")
+	c := m.(controller.APICaller)
 
 	haveRGID, err := existRGID(ctx, d, m)
 	if err != nil {
@@ -197,9 +203,9 @@ func resourceBasicServiceUpdate(ctx context.Context, d *schema.ResourceData, m i
 	case status.Enabled:
 	case status.Enabling:
 	case status.Disabled:
-		log.Debugf("The basic service is in status: %s, troubles can occur with the update. Please, enable bservice first.", bs.Status)
+		log.Debugf(ctx, "The basic service is in status: %s, troubles can occur with the update. Please, enable bservice first.", bs.Status)
 	case status.Disabling:
-		log.Debugf("The basic service is in status: %s, troubles can occur with the update.", bs.Status)
+		log.Debugf(ctx, "The basic service is in status: %s, troubles can occur with the update.", bs.Status)
 	case status.Deleted:
 		urlVal := &url.Values{}
 		urlVal.Add("serviceId", d.Id())
@@ -41,15 +41,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
 )
 
 func resourceBasicServiceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceGroupCreate")
+	log.Debugf(ctx, "resourceBasicServiceGroupCreate")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("serviceId", strconv.Itoa(d.Get("service_id").(int)))
@@ -116,7 +116,7 @@ func resourceBasicServiceGroupCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceBasicServiceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceGroupRead")
+	log.Debugf(ctx, "resourceBasicServiceGroupRead")
 
 	bsg, err := utilityBasicServiceGroupCheckPresence(ctx, d, m)
 	if bsg == nil {
@@ -126,7 +126,12 @@ func resourceBasicServiceGroupRead(ctx context.Context, d *schema.ResourceData,
 
 	d.Set("account_id", bsg.AccountId)
 	d.Set("account_name", bsg.AccountName)
-	d.Set("computes", flattenBSGroupComputes(bsg.Computes))
+	if controller.OmitSecretsOf(m) {
+		// users are kept, so that only their passwords are left out of state
+		d.Set("computes", flattens.OmitSecrets(flattenBSGroupComputes(bsg.Computes)))
+	} else {
+		d.Set("computes", flattenBSGroupComputes(bsg.Computes))
+	}
 	d.Set("consistency", bsg.Consistency)
 	d.Set("cpu", bsg.CPU)
 	d.Set("created_by", bsg.CreatedBy)
@@ -160,7 +165,7 @@ func resourceBasicServiceGroupRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceBasicServiceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceGroupDelete")
+	log.Debugf(ctx, "resourceBasicServiceGroupDelete")
 
 	bsg, err := utilityBasicServiceGroupCheckPresence(ctx, d, m)
 	if bsg == nil {
@@ -170,7 +175,7 @@ func resourceBasicServiceGroupDelete(ctx context.Context, d *schema.ResourceData
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("serviceId", strconv.Itoa(d.Get("service_id").(int)))
 	urlValues.Add("compgroupId", strconv.Itoa(d.Get("compgroup_id").(int)))
@@ -185,8 +190,8 @@ func resourceBasicServiceGroupDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceBasicServiceGroupEdit(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceBasicServiceGroupEdit")
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceBasicServiceGroupEdit")
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 
@@ -531,8 +536,9 @@ func resourceBasicServiceGroupSchemaMake() map[string]*schema.Schema {
 									Computed: true,
 								},
 								"password": {
-									Type:     schema.TypeString,
-									Computed: true,
+									Type:      schema.TypeString,
+									Sensitive: true,
+									Computed:  true,
 								},
 							},
 						},
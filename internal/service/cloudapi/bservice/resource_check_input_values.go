@@ -2,41 +2,13 @@ package bservice
 
 import (
 	"context"
-	"encoding/json"
-	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/lookup"
 )
 
 func existRGID(ctx context.Context, d *schema.ResourceData, m interface{}) (bool, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-
-	rgList := []struct {
-		ID int `json:"id"`
-	}{}
-
-	rgListAPI := "/restmachine/cloudapi/rg/list"
-
-	rgListRaw, err := c.DecortAPICall(ctx, "POST", rgListAPI, urlValues)
-	if err != nil {
-		return false, err
-	}
-
-	err = json.Unmarshal([]byte(rgListRaw), &rgList)
-	if err != nil {
-		return false, err
-	}
-
-	haveRG := false
-	rgId := d.Get("rg_id").(int)
-	for _, rg := range rgList {
-		if rg.ID == rgId {
-			haveRG = true
-			break
-		}
-	}
-
-	return haveRG, nil
+	c := m.(controller.APICaller)
+	return lookup.For(c).RG(ctx, d.Get("rg_id").(int))
 }
@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityBasicServiceDeletedListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (BasicServiceList, error) {
 	basicServiceDeletedList := BasicServiceList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if accountId, ok := d.GetOk("account_id"); ok {
@@ -62,7 +61,7 @@ func utilityBasicServiceDeletedListCheckPresence(ctx context.Context, d *schema.
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityBasicServiceDeletedListCheckPresence")
+	log.Debugf(ctx, "utilityBasicServiceDeletedListCheckPresence")
 	basicServiceDeletedListRaw, err := c.DecortAPICall(ctx, "POST", bserviceListDeletedAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityBasicServiceCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*BasicServiceExtend, error) {
 	bservice := &BasicServiceExtend{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if (strconv.Itoa(d.Get("service_id").(int))) != "0" {
@@ -55,7 +54,7 @@ func utilityBasicServiceCheckPresence(ctx context.Context, d *schema.ResourceDat
 		urlValues.Add("serviceId", d.Id())
 	}
 
-	log.Debugf("utilityBasicServiceCheckPresence")
+	log.Debugf(ctx, "utilityBasicServiceCheckPresence")
 	bserviceRaw, err := c.DecortAPICall(ctx, "POST", bserviceGetAPI, urlValues)
 	if err != nil {
 		return nil, err
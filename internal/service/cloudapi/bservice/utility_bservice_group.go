@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityBasicServiceGroupCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*BasicServiceGroup, error) {
 	bserviceGroup := &BasicServiceGroup{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("serviceId", strconv.Itoa(d.Get("service_id").(int)))
@@ -56,7 +55,7 @@ func utilityBasicServiceGroupCheckPresence(ctx context.Context, d *schema.Resour
 		urlValues.Add("compgroupId", d.Id())
 	}
 
-	log.Debugf("utilityBasicServiceGroupCheckPresence")
+	log.Debugf(ctx, "utilityBasicServiceGroupCheckPresence")
 	bserviceGroupRaw, err := c.DecortAPICall(ctx, "POST", bserviceGroupGetAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityBasicServiceListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (BasicServiceList, error) {
 	basicServiceList := BasicServiceList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if accountId, ok := d.GetOk("account_id"); ok {
@@ -62,7 +61,7 @@ func utilityBasicServiceListCheckPresence(ctx context.Context, d *schema.Resourc
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityBasicServiceListCheckPresence")
+	log.Debugf(ctx, "utilityBasicServiceListCheckPresence")
 	basicServiceListRaw, err := c.DecortAPICall(ctx, "POST", bserviceListAPI, urlValues)
 	if err != nil {
 		return nil, err
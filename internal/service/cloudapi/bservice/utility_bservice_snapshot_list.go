@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,14 +45,14 @@ import (
 
 func utilityBasicServiceSnapshotListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (BasicServiceSnapshots, error) {
 	basicServiceSnapshotList := BasicServiceSnapshots{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if serviceId, ok := d.GetOk("service_id"); ok {
 		urlValues.Add("serviceId", strconv.Itoa(serviceId.(int)))
 	}
 
-	log.Debugf("utilityBasicServiceSnapshotListCheckPresence")
+	log.Debugf(ctx, "utilityBasicServiceSnapshotListCheckPresence")
 	basicServiceSnapshotListRaw, err := c.DecortAPICall(ctx, "POST", bserviceSnapshotListAPI, urlValues)
 	if err != nil {
 		return nil, err
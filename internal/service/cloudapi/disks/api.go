@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package disks
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const (
 	disksCreateAPI         = "/restmachine/cloudapi/disks/create"
 	disksGetAPI            = "/restmachine/cloudapi/disks/get"
@@ -50,3 +52,5 @@ const (
 	disksShareAPI            = "/restmachine/cloudapi/disks/share"
 	disksUnshareAPI          = "/restmachine/cloudapi/disks/unshare"
 )
+
+var log = logging.New("disks")
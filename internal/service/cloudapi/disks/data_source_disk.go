@@ -310,6 +310,7 @@ func dataSourceDiskSchemaMake() map[string]*schema.Schema {
 		},
 		"passwd": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "Password to access the disk",
 		},
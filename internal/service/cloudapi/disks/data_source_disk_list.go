@@ -294,6 +294,7 @@ func dataSourceDiskListSchemaMake() map[string]*schema.Schema {
 					},
 					"passwd": {
 						Type:        schema.TypeString,
+						Sensitive:   true,
 						Computed:    true,
 						Description: "Password to access the disk",
 					},
@@ -44,18 +44,17 @@ import (
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
-	log "github.com/sirupsen/logrus"
 )
 
 func utilityDiskListUnattachedCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (UnattachedList, error) {
 	unattachedList := UnattachedList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	if accountId, ok := d.GetOk("accountId"); ok {
 		urlValues.Add("accountId", strconv.Itoa(accountId.(int)))
 	}
 
-	log.Debugf("utilityDiskListUnattachedCheckPresence: load disk Unattached list")
+	log.Debugf(ctx, "utilityDiskListUnattachedCheckPresence: load disk Unattached list")
 	unattachedListRaw, err := c.DecortAPICall(ctx, "POST", disksListUnattachedAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -356,6 +355,7 @@ func dataSourceDiskListUnattachedSchemaMake() map[string]*schema.Schema {
 					},
 					"passwd": {
 						Type:        schema.TypeString,
+						Sensitive:   true,
 						Computed:    true,
 						Description: "Password to access the disk",
 					},
@@ -39,7 +39,6 @@ import (
 	"strconv"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
@@ -51,7 +50,7 @@ import (
 )
 
 func resourceDiskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	haveAccount, err := existAccountID(ctx, d, m)
@@ -147,7 +146,7 @@ func resourceDiskCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceDiskRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	urlValues := &url.Values{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	warnings := dc.Warnings{}
 
 	disk, err := utilityDiskCheckPresence(ctx, d, m)
@@ -160,9 +159,15 @@ func resourceDiskRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	switch disk.Status {
 	case status.Destroyed, status.Purged:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "Disk", disk.Status)
+		}
 		d.Set("disk_id", 0)
 		return resourceDiskCreate(ctx, d, m)
 	case status.Deleted:
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "Disk", disk.Status)
+		}
 		hasChangeState = true
 		urlValues.Add("diskId", d.Id())
 		urlValues.Add("reason", d.Get("reason").(string))
@@ -194,7 +199,7 @@ func resourceDiskRead(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceDiskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	warnings := dc.Warnings{}
 	urlValues := &url.Values{}
 
@@ -254,7 +259,7 @@ func resourceDiskUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	if d.HasChange("size_max") {
 		oldSize, newSize := d.GetChange("size_max")
 		if oldSize.(int) < newSize.(int) {
-			log.Debugf("resourceDiskUpdate: resizing disk ID %s - %d GB -> %d GB",
+			log.Debugf(ctx, "resourceDiskUpdate: resizing disk ID %s - %d GB -> %d GB",
 				d.Id(), oldSize.(int), newSize.(int))
 			urlValues.Add("diskId", d.Id())
 			urlValues.Add("size", strconv.Itoa(newSize.(int)))
@@ -342,7 +347,7 @@ func resourceDiskDelete(ctx context.Context, d *schema.ResourceData, m interface
 	params.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
 	params.Add("reason", d.Get("reason").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	_, err = c.DecortAPICall(ctx, "POST", disksDeleteAPI, params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -628,6 +633,7 @@ func resourceDiskSchemaMake() map[string]*schema.Schema {
 		},
 		"passwd": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "Password to access the disk",
 		},
@@ -39,14 +39,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 func resourceDiskSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	urlValues := &url.Values{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	disk, err := utilityDiskCheckPresence(ctx, d, m)
 	if err != nil {
@@ -69,7 +68,7 @@ func resourceDiskSnapshotCreate(ctx context.Context, d *schema.ResourceData, m i
 		urlValues.Add("diskId", strconv.Itoa(d.Get("disk_id").(int)))
 		urlValues.Add("label", label)
 		urlValues.Add("timestamp", strconv.Itoa(d.Get("timestamp").(int)))
-		log.Debugf("resourceDiskCreate: Snapshot rollback with label", label)
+		log.Debugf(ctx, "resourceDiskCreate: Snapshot rollback with label %q", label)
 		_, err := c.DecortAPICall(ctx, "POST", disksSnapshotRollbackAPI, urlValues)
 		if err != nil {
 			return diag.FromErr(err)
@@ -111,7 +110,7 @@ func resourceDiskSnapshotRead(ctx context.Context, d *schema.ResourceData, m int
 
 func resourceDiskSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	urlValues := &url.Values{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	disk, err := utilityDiskCheckPresence(ctx, d, m)
 	if disk == nil {
 		if err != nil {
@@ -135,7 +134,7 @@ func resourceDiskSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m i
 		urlValues.Add("diskId", strconv.Itoa(d.Get("disk_id").(int)))
 		urlValues.Add("label", label)
 		urlValues.Add("timestamp", strconv.Itoa(d.Get("timestamp").(int)))
-		log.Debugf("resourceDiskUpdtae: Snapshot rollback with label", label)
+		log.Debugf(ctx, "resourceDiskUpdtae: Snapshot rollback with label %q", label)
 		_, err := c.DecortAPICall(ctx, "POST", disksSnapshotRollbackAPI, urlValues)
 		if err != nil {
 			return diag.FromErr(err)
@@ -147,7 +146,7 @@ func resourceDiskSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceDiskSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	disk, err := utilityDiskCheckPresence(ctx, d, m)
 	if disk == nil { //if disk not exits, can't call snapshotDelete
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package disks_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/acctest"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/disks"
+)
+
+const testDiskConfig = `
+resource "decort_disk" "disk" {
+  account_id = %d
+  gid        = %d
+  disk_name  = %q
+  size_max   = %d
+}
+`
+
+func TestResourceDisk(t *testing.T) {
+	s := acctest.NewServer(t)
+	config := func(name string, size int) string {
+		return acctest.Config(s, testDiskConfig, fake.DefaultAccountID, fake.DefaultGridID, name, size)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckDestroyed(s, fake.KindDisk, "decort_disk"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("disk-test", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_disk.disk", "size_max", "10"),
+					acctest.CheckObject(s, fake.KindDisk, "decort_disk.disk", "sizeMax", 10),
+				),
+			},
+			{
+				Config: config("disk-renamed", 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_disk.disk", "disk_name", "disk-renamed"),
+					resource.TestCheckResourceAttr("decort_disk.disk", "size_max", "20"),
+					acctest.CheckObject(s, fake.KindDisk, "decort_disk.disk", "name", "disk-renamed"),
+					acctest.CheckObject(s, fake.KindDisk, "decort_disk.disk", "sizeMax", 20),
+				),
+			},
+			{
+				ResourceName:            "decort_disk.disk",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"detach", "permanently"},
+			},
+		},
+	})
+}
+
+// TestResourceDiskHandlers runs the steps of TestResourceDisk through the resource handlers,
+// so that they are covered without Terraform CLI
+func TestResourceDiskHandlers(t *testing.T) {
+	s := acctest.NewServer(t)
+	m := s.Client()
+	ctx := context.Background()
+	r := disks.ResourceDisk()
+
+	config := map[string]interface{}{
+		"account_id": fake.DefaultAccountID,
+		"gid":        fake.DefaultGridID,
+		"disk_name":  "disk-test",
+		"size_max":   10,
+	}
+	d := acctest.ResourceData(t, r, nil, config, m)
+	if diags := r.CreateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("CreateContext() = %v", diags)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		t.Fatalf("resource ID %q: %v", d.Id(), err)
+	}
+	if obj, ok := s.Get(fake.KindDisk, id); !ok || obj["sizeMax"] != 10 {
+		t.Fatalf("disk %d = %v, want size 10", id, obj)
+	}
+
+	config["disk_name"] = "disk-renamed"
+	config["size_max"] = 20
+	d = acctest.ResourceData(t, r, d, config, m)
+	if diags := r.UpdateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("UpdateContext() = %v", diags)
+	}
+	if obj, _ := s.Get(fake.KindDisk, id); obj["name"] != "disk-renamed" || obj["sizeMax"] != 20 {
+		t.Errorf("disk name = %v, size = %v, want disk-renamed, 20", obj["name"], obj["sizeMax"])
+	}
+
+	imported := acctest.Imported(r, d.Id())
+	if diags := r.ReadContext(ctx, imported, m); diags.HasError() {
+		t.Fatalf("ReadContext() = %v", diags)
+	}
+	for _, key := range []string{"disk_name", "size_max", "account_id", "gid"} {
+		if got, want := imported.Get(key), d.Get(key); got != want {
+			t.Errorf("imported %s = %v, want %v", key, got, want)
+		}
+	}
+
+	if diags := r.DeleteContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("DeleteContext() = %v", diags)
+	}
+	if obj, ok := s.Get(fake.KindDisk, id); ok && obj["status"] != "DELETED" && obj["status"] != "DESTROYED" {
+		t.Errorf("disk status = %v after delete", obj["status"])
+	}
+}
@@ -39,15 +39,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 )
 
 func utilityDiskListTypesDetailedCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (TypesDetailedList, error) {
 	listTypesDetailed := TypesDetailedList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("detailed", "true")
-	log.Debugf("utilityDiskListTypesDetailedCheckPresence: load disk list Types Detailed")
+	log.Debugf(ctx, "utilityDiskListTypesDetailedCheckPresence: load disk list Types Detailed")
 	diskListRaw, err := c.DecortAPICall(ctx, "POST", disksListTypesAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -32,7 +32,11 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package extnet
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const extnetListAPI = "/restmachine/cloudapi/extnet/list"
 const extnetListComputesAPI = "/restmachine/cloudapi/extnet/listComputes"
 const extnetGetDefaultAPI = "/restmachine/cloudapi/extnet/getDefault"
 const extnetGetAPI = "/restmachine/cloudapi/extnet/get"
+
+var log = logging.New("extnet")
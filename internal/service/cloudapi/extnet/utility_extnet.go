@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,12 +45,12 @@ import (
 
 func utilityExtnetCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*ExtnetDetailed, error) {
 	extnet := &ExtnetDetailed{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("net_id", strconv.Itoa(d.Get("net_id").(int)))
 
-	log.Debugf("utilityExtnetCheckPresence")
+	log.Debugf(ctx, "utilityExtnetCheckPresence")
 	extnetRaw, err := c.DecortAPICall(ctx, "POST", extnetGetAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -36,15 +36,14 @@ import (
 	"context"
 	"net/url"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 func utilityExtnetDefaultCheckPresence(ctx context.Context, m interface{}) (string, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
-	log.Debugf("utilityExtnetDefaultCheckPresence")
+	log.Debugf(ctx, "utilityExtnetDefaultCheckPresence")
 	res, err := c.DecortAPICall(ctx, "POST", extnetGetDefaultAPI, urlValues)
 	if err != nil {
 		return "", err
@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityExtnetListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ExtnetList, error) {
 	extnetList := ExtnetList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if accountId, ok := d.GetOk("account_id"); ok {
@@ -59,7 +58,7 @@ func utilityExtnetListCheckPresence(ctx context.Context, d *schema.ResourceData,
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityExtnetListCheckPresence")
+	log.Debugf(ctx, "utilityExtnetListCheckPresence")
 	extnetListRaw, err := c.DecortAPICall(ctx, "POST", extnetListAPI, urlValues)
 	if err != nil {
 		return nil, err
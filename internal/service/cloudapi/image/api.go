@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package image
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const imageCreateAPI = "/restmachine/cloudapi/image/create"
 const imageCreateVirtualAPI = "/restmachine/cloudapi/image/createVirtual"
 const imageGetAPI = "/restmachine/cloudapi/image/get"
@@ -39,3 +41,5 @@ const imageListGetAPI = "/restmachine/cloudapi/image/list"
 const imageDeleteAPI = "/restmachine/cloudapi/image/delete"
 const imageEditNameAPI = "/restmachine/cloudapi/image/rename"
 const imageLinkAPI = "/restmachine/cloudapi/image/link"
+
+var log = logging.New("image")
@@ -147,8 +147,9 @@ func dataSourceImageExtendSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"password": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"pool_name": {
 			Type:     schema.TypeString,
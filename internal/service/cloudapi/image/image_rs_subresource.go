@@ -94,6 +94,7 @@ func resourceImageSchemaMake(sch map[string]*schema.Schema) map[string]*schema.S
 
 	sch["password"] = &schema.Schema{
 		Type:        schema.TypeString,
+		Sensitive:   true,
 		Optional:    true,
 		Computed:    true,
 		Description: "Optional password for the image",
@@ -114,6 +115,7 @@ func resourceImageSchemaMake(sch map[string]*schema.Schema) map[string]*schema.S
 
 	sch["password_dl"] = &schema.Schema{
 		Type:        schema.TypeString,
+		Sensitive:   true,
 		Optional:    true,
 		Description: "password for upload binary media",
 	}
@@ -40,14 +40,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 )
 
 func resourceImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageCreate: called for image %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourceImageCreate: called for image %s", d.Get("name").(string))
 
 	haveGID, err := existGID(ctx, d, m)
 	if err != nil {
@@ -69,7 +68,7 @@ func resourceImageCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("url", d.Get("url").(string))
@@ -148,7 +147,7 @@ func resourceImageCreate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceImageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageRead: called for %s id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceImageRead: called for %s id: %s", d.Get("name").(string), d.Id())
 
 	img, err := utilityImageCheckPresence(ctx, d, m)
 	if img == nil {
@@ -172,7 +171,7 @@ func resourceImageRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceImageDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
 
 	image, err := utilityImageCheckPresence(ctx, d, m)
 	if image == nil {
@@ -182,7 +181,7 @@ func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interfac
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 
@@ -200,8 +199,8 @@ func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceImageEditName(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceImageEditName: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceImageEditName: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	urlValues.Add("name", d.Get("name").(string))
@@ -214,7 +213,7 @@ func resourceImageEditName(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceImageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageEdit: called for %s, id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceImageEdit: called for %s, id: %s", d.Get("name").(string), d.Id())
 
 	haveGID, err := existGID(ctx, d, m)
 	if err != nil {
@@ -41,13 +41,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceImageVirtualCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageVirtualCreate: called for image %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourceImageVirtualCreate: called for image %s", d.Get("name").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("targetId", strconv.Itoa(d.Get("target_id").(int)))
@@ -74,7 +73,7 @@ func resourceImageVirtualCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceImageVirtualEdit(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageEdit: called for %s, id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceImageEdit: called for %s, id: %s", d.Get("name").(string), d.Id())
 
 	if d.HasChange("name") {
 		err := resourceImageEditName(ctx, d, m)
@@ -94,8 +93,8 @@ func resourceImageVirtualEdit(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceImageVirtualLink(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceVirtualImageLink: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceVirtualImageLink: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	urlValues.Add("targetId", strconv.Itoa(d.Get("link_to").(int)))
@@ -45,7 +45,7 @@ import (
 )
 
 func utilityImageCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*ImageExtend, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if (strconv.Itoa(d.Get("image_id").(int))) != "0" {
@@ -39,14 +39,13 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityImageListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ImageList, error) {
 	imageList := ImageList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if accountId, ok := d.GetOk("account_id"); ok {
@@ -60,7 +59,7 @@ func utilityImageListCheckPresence(ctx context.Context, d *schema.ResourceData,
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityImageListCheckPresence: load image list")
+	log.Debugf(ctx, "utilityImageListCheckPresence: load image list")
 	imageListRaw, err := c.DecortAPICall(ctx, "POST", imageListGetAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package k8s
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const (
 	K8sCreateAPI      = "/restmachine/cloudapi/k8s/create"
 	K8sGetAPI         = "/restmachine/cloudapi/k8s/get"
@@ -54,3 +56,5 @@ const (
 
 	AsyncTaskGetAPI = "/restmachine/cloudapi/tasks/get"
 )
+
+var log = logging.New("k8s")
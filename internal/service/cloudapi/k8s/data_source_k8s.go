@@ -42,7 +42,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/kvmvm"
 )
@@ -89,14 +88,19 @@ func dataSourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{
 		}
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", d.Id())
-	kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
-	if err != nil {
-		log.Warnf("could not get kubeconfig: %v", err)
+	if controller.OmitSecretsOf(m) {
+		// kubeconfig is not fetched at all, so that it cannot leak into state or logs
+		d.Set("kubeconfig", "")
+	} else {
+		kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
+		if err != nil {
+			log.Warnf(ctx, "could not get kubeconfig: %v", err)
+		}
+		d.Set("kubeconfig", kubeconfig)
 	}
-	d.Set("kubeconfig", kubeconfig)
 
 	urlValues = &url.Values{}
 	urlValues.Add("lbId", strconv.FormatUint(k8s.LBID, 10))
@@ -425,6 +429,7 @@ func dataSourceK8sSchemaMake() map[string]*schema.Schema {
 		},
 		"kubeconfig": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "Kubeconfig for cluster access.",
 		},
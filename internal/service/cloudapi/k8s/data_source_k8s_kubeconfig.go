@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package k8s
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+)
+
+func dataSourceK8sKubeconfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(controller.APICaller)
+	urlValues := &url.Values{}
+	urlValues.Add("k8sId", strconv.Itoa(d.Get("k8s_id").(int)))
+	kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(d.Get("k8s_id").(int)))
+	d.Set("kubeconfig", kubeconfig)
+	return nil
+}
+
+func dataSourceK8sKubeconfigSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"k8s_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "ID of the k8s cluster",
+		},
+		"kubeconfig": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "kubeconfig for the k8s cluster",
+		},
+	}
+}
+
+func DataSourceK8sKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 1,
+		Description:   "Reads kubeconfig of the k8s cluster. As any data source result, kubeconfig is stored in Terraform state, even if omit_secrets_from_state is set.",
+
+		ReadContext: dataSourceK8sKubeconfigRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read:    &constants.Timeout30s,
+			Default: &constants.Timeout60s,
+		},
+
+		Schema: dataSourceK8sKubeconfigSchemaMake(),
+	}
+}
@@ -62,8 +62,9 @@ func serviceAccountSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"password": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"username": {
 			Type:     schema.TypeString,
@@ -38,12 +38,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 )
 
 func dataSourceK8sWgRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("dataSourceK8sWgRead: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "dataSourceK8sWgRead: called with k8s id %d", d.Get("k8s_id").(int))
 
 	wg, workersComputeList, err := utilityDataK8sWgCheckPresence(ctx, d, m)
 	if err != nil {
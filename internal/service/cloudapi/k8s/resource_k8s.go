@@ -38,20 +38,19 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/kvmvm"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 )
 
 func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sCreate: called with name %s, rg %d", d.Get("name").(string), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sCreate: called with name %s, rg %d", d.Get("name").(string), d.Get("rg_id").(int))
 
 	haveRGID, err := existRGID(ctx, d, m)
 	if err != nil {
@@ -82,7 +81,7 @@ func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{
 		}
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("rgId", strconv.Itoa(d.Get("rg_id").(int)))
@@ -157,33 +156,13 @@ func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	urlValues = &url.Values{}
-	urlValues.Add("auditId", strings.Trim(resp, `"`))
-
-	for {
-		resp, err := c.DecortAPICall(ctx, "POST", AsyncTaskGetAPI, urlValues)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		task := AsyncTask{}
-		if err := json.Unmarshal([]byte(resp), &task); err != nil {
-			return diag.FromErr(err)
-		}
-		log.Debugf("resourceK8sCreate: instance creating - %s", task.Stage)
-
-		if task.Completed {
-			if task.Error != "" {
-				return diag.FromErr(fmt.Errorf("cannot create k8s instance: %v", task.Error))
-			}
-
-			d.SetId(strconv.Itoa(int(task.Result)))
-			break
-		}
-
-		time.Sleep(time.Second * 10)
+	task, err := sdk.New(c).Tasks().Wait(ctx, resp)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot create k8s instance: %w", err))
 	}
 
+	d.SetId(strconv.Itoa(int(task.Result)))
+
 	return resourceK8sRead(ctx, d, m)
 }
 
@@ -194,7 +173,7 @@ func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{})
 		return diag.FromErr(err)
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	hasChanged := false
 
@@ -205,6 +184,9 @@ func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	case status.Created:
 	case status.Deleting:
 	case status.Deleted:
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "K8s cluster", k8s.Status)
+		}
 		urlVal := &url.Values{}
 		urlVal.Add("k8sId", d.Id())
 
@@ -222,13 +204,16 @@ func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	case status.Destroying:
 		return diag.Errorf("The k8s cluster is in progress with status: %s", k8s.Status)
 	case status.Destroyed:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "K8s cluster", k8s.Status)
+		}
 		d.SetId("")
 		return resourceK8sCreate(ctx, d, m)
 	case status.Enabling:
 	case status.Enabled:
 	case status.Disabling:
 	case status.Disabled:
-		log.Debugf("The k8s cluster is in status: %s, troubles may occur with update. Please, enable compute first.", k8s.Status)
+		log.Debugf(ctx, "The k8s cluster is in status: %s, troubles may occur with update. Please, enable compute first.", k8s.Status)
 	case status.Restoring:
 	}
 
@@ -297,19 +282,24 @@ func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 	urlValues = &url.Values{}
 	urlValues.Add("k8sId", d.Id())
-	kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
-	if err != nil {
-		log.Warnf("could not get kubeconfig: %v", err)
+	if controller.OmitSecretsOf(m) {
+		// kubeconfig is not fetched at all, so that it cannot leak into state or logs
+		d.Set("kubeconfig", "")
+	} else {
+		kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
+		if err != nil {
+			log.Warnf(ctx, "could not get kubeconfig: %v", err)
+		}
+		d.Set("kubeconfig", kubeconfig)
 	}
-	d.Set("kubeconfig", kubeconfig)
 
 	return nil
 }
 
 func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sUpdate: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sUpdate: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	haveRGID, err := existRGID(ctx, d, m)
 	if err != nil {
@@ -366,7 +356,7 @@ func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	case status.Enabled:
 	case status.Disabling:
 	case status.Disabled:
-		log.Debugf("The k8s cluster is in status: %s, troubles may occur with update. Please, enable compute first.", k8s.Status)
+		log.Debugf(ctx, "The k8s cluster is in status: %s, troubles may occur with update. Please, enable compute first.", k8s.Status)
 	case status.Restoring:
 	}
 
@@ -419,7 +409,7 @@ func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceK8sDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sDelete: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sDelete: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
 
 	k8s, err := utilityK8sCheckPresence(ctx, d, m)
 	if k8s == nil {
@@ -429,7 +419,7 @@ func resourceK8sDelete(ctx context.Context, d *schema.ResourceData, m interface{
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", d.Id())
 	urlValues.Add("permanently", "true")
@@ -602,6 +592,7 @@ func resourceK8sSchemaMake() map[string]*schema.Schema {
 		},
 		"kubeconfig": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "Kubeconfig for cluster access.",
 		},
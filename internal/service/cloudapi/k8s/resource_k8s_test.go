@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package k8s_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/acctest"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/k8s"
+)
+
+const testK8sConfig = `
+resource "decort_resgroup" "rg" {
+  account_id = %d
+  gid        = %d
+  name       = "rg-test"
+}
+
+resource "decort_k8s" "k8s" {
+  rg_id    = decort_resgroup.rg.id
+  k8sci_id = %d
+  name     = %q
+  wg_name  = "workers"
+}
+`
+
+func TestResourceK8s(t *testing.T) {
+	s := acctest.NewServer(t)
+	config := func(name string) string {
+		return acctest.Config(s, testK8sConfig, fake.DefaultAccountID, fake.DefaultGridID, fake.DefaultK8CIID, name)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckDestroyed(s, fake.KindK8s, "decort_k8s"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("k8s-test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_k8s.k8s", "name", "k8s-test"),
+					resource.TestCheckResourceAttrSet("decort_k8s.k8s", "kubeconfig"),
+					acctest.CheckObject(s, fake.KindK8s, "decort_k8s.k8s", "status", "ENABLED"),
+				),
+			},
+			{
+				Config: config("k8s-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_k8s.k8s", "name", "k8s-renamed"),
+					acctest.CheckObject(s, fake.KindK8s, "decort_k8s.k8s", "name", "k8s-renamed"),
+				),
+			},
+			{
+				ResourceName:            "decort_k8s.k8s",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"clean"},
+			},
+		},
+	})
+}
+
+// TestResourceK8sHandlers runs the steps of TestResourceK8s through the resource handlers,
+// so that they are covered without Terraform CLI
+func TestResourceK8sHandlers(t *testing.T) {
+	s := acctest.NewServer(t)
+	m := s.Client()
+	ctx := context.Background()
+	r := k8s.ResourceK8s()
+
+	config := map[string]interface{}{
+		"rg_id":    acctest.NewRG(t, s),
+		"k8sci_id": fake.DefaultK8CIID,
+		"name":     "k8s-test",
+		"wg_name":  "workers",
+	}
+	d := acctest.ResourceData(t, r, nil, config, m)
+	if diags := r.CreateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("CreateContext() = %v", diags)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		t.Fatalf("resource ID %q: %v", d.Id(), err)
+	}
+	if obj, ok := s.Get(fake.KindK8s, id); !ok || obj["status"] != "ENABLED" {
+		t.Fatalf("k8s %d = %v, want ENABLED", id, obj)
+	}
+	if d.Get("kubeconfig") == "" {
+		t.Error("kubeconfig is not set")
+	}
+
+	config["name"] = "k8s-renamed"
+	d = acctest.ResourceData(t, r, d, config, m)
+	if diags := r.UpdateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("UpdateContext() = %v", diags)
+	}
+	if obj, _ := s.Get(fake.KindK8s, id); obj["name"] != "k8s-renamed" {
+		t.Errorf("k8s name = %v, want k8s-renamed", obj["name"])
+	}
+
+	imported := acctest.Imported(r, d.Id())
+	if diags := r.ReadContext(ctx, imported, m); diags.HasError() {
+		t.Fatalf("ReadContext() = %v", diags)
+	}
+	for _, key := range []string{"name", "rg_id", "k8sci_id", "wg_name"} {
+		if got, want := imported.Get(key), d.Get(key); got != want {
+			t.Errorf("imported %s = %v, want %v", key, got, want)
+		}
+	}
+
+	if diags := r.DeleteContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("DeleteContext() = %v", diags)
+	}
+	if obj, ok := s.Get(fake.KindK8s, id); ok && obj["status"] != "DELETED" && obj["status"] != "DESTROYED" {
+		t.Errorf("k8s status = %v after delete", obj["status"])
+	}
+}
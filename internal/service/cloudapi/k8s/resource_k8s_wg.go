@@ -40,14 +40,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/kvmvm"
 )
 
 func resourceK8sWgCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgCreate: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgCreate: called with k8s id %d", d.Get("k8s_id").(int))
 
 	haveK8sID, err := existK8sID(ctx, d, m)
 	if err != nil {
@@ -58,7 +57,7 @@ func resourceK8sWgCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.Errorf("resourceK8sCreate: can't create k8s cluster because K8sID %d is not allowed or does not exist", d.Get("k8s_id").(int))
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", strconv.Itoa(d.Get("k8s_id").(int)))
 	urlValues.Add("name", d.Get("name").(string))
@@ -79,40 +78,15 @@ func resourceK8sWgCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	d.SetId(resp)
 
-	// This code is the supposed flow, but at the time of writing it's not yet implemented by the platfom
-
-	//urlValues = &url.Values{}
-	//urlValues.Add("auditId", strings.Trim(resp, `"`))
-
-	//for {
-	//resp, err := controller.decortAPICall("POST", AsyncTaskGetAPI, urlValues)
-	//if err != nil {
-	//return err
-	//}
-
-	//task := AsyncTask{}
-	//if err := json.Unmarshal([]byte(resp), &task); err != nil {
-	//return err
-	//}
-	//log.Debugf("resourceK8sCreate: workers group creating - %s", task.Stage)
-
-	//if task.Completed {
-	//if task.Error != "" {
-	//return fmt.Errorf("cannot create workers group: %v", task.Error)
-	//}
-
-	//d.SetId(strconv.Itoa(int(task.Result)))
-	//break
-	//}
-
-	//time.Sleep(time.Second * 5)
-	//}
+	// The platform is supposed to create workers group asynchronously and return audit ID,
+	// which should then be waited for with sdk Tasks().Wait, but at the time of writing it's
+	// not yet implemented by the platform
 
 	return resourceK8sWgRead(ctx, d, m)
 }
 
 func resourceK8sWgRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgRead: called with %v", d.Id())
+	log.Debugf(ctx, "resourceK8sWgRead: called with %v", d.Id())
 
 	wg, err := utilityK8sWgCheckPresence(ctx, d, m)
 	if err != nil {
@@ -147,9 +121,9 @@ func resourceK8sWgRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceK8sWgUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgUpdate: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgUpdate: called with k8s id %d", d.Get("k8s_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	haveK8sID, err := existK8sID(ctx, d, m)
 	if err != nil {
@@ -189,7 +163,7 @@ func resourceK8sWgUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceK8sWgDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgDelete: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgDelete: called with k8s id %d", d.Get("k8s_id").(int))
 
 	wg, err := utilityK8sWgCheckPresence(ctx, d, m)
 	if wg == nil {
@@ -199,7 +173,7 @@ func resourceK8sWgDelete(ctx context.Context, d *schema.ResourceData, m interfac
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", strconv.Itoa(d.Get("k8s_id").(int)))
 	urlValues.Add("workersGroupId", strconv.FormatUint(wg.ID, 10))
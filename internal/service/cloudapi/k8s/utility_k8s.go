@@ -44,7 +44,7 @@ import (
 )
 
 func utilityK8sCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*K8SRecord, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", d.Id())
 
@@ -66,7 +66,7 @@ func utilityK8sCheckPresence(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}, computeID uint64) (*kvmvm.ComputeGetResp, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("computeId", strconv.FormatUint(computeID, 10))
@@ -86,7 +86,7 @@ func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m
 }
 
 func utilityDataK8sCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*K8SRecord, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", strconv.Itoa(d.Get("k8s_id").(int)))
 
@@ -104,7 +104,7 @@ func utilityDataK8sCheckPresence(ctx context.Context, d *schema.ResourceData, m
 }
 
 func utilityK8sListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}, api string) (K8SList, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("includedeleted", "false")
 	urlValues.Add("page", "0")
@@ -46,7 +46,7 @@ import (
 )
 
 func utilityDataK8sWgCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*K8SGroup, []kvmvm.ComputeGetResp, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	k8sId := d.Get("k8s_id").(int)
@@ -90,7 +90,7 @@ func utilityDataK8sWgCheckPresence(ctx context.Context, d *schema.ResourceData,
 }
 
 func utilityK8sWgCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*K8SGroup, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	var wgId int
 	var k8sId int
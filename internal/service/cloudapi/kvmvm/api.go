@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package kvmvm
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const (
 	KvmX86CreateAPI                  = "/restmachine/cloudapi/kvmx86/create"
 	KvmPPCCreateAPI                  = "/restmachine/cloudapi/kvmppc/create"
@@ -76,7 +78,7 @@ const (
 	ComputeUserGrantAPI              = "/restmachine/cloudapi/compute/userGrant"
 	ComputeUserRevokeAPI             = "/restmachine/cloudapi/compute/userRevoke"
 	ComputeSnapshotCreateAPI         = "/restmachine/cloudapi/compute/snapshotCreate"
-	ComputeSnapshotDeleteAPI         = "/restmachine/cloudapi/compute/snapshotCreate"
+	ComputeSnapshotDeleteAPI         = "/restmachine/cloudapi/compute/snapshotDelete"
 	ComputeSnapshotUsageAPI          = "/restmachine/cloudapi/compute/snapshotUsage"
 	ComputeSnapshotRollbackAPI       = "/restmachine/cloudapi/compute/snapshotRollback"
 	ComputePauseAPI                  = "/restmachine/cloudapi/compute/pause"
@@ -85,4 +87,7 @@ const (
 	ComputeCdEjectAPI                = "/restmachine/cloudapi/compute/cdEject"
 	ComputeResetAPI                  = "/restmachine/cloudapi/compute/reset"
 	ComputeRedeployAPI               = "/restmachine/cloudapi/compute/redeploy"
+	ImageGetAPI                      = "/restmachine/cloudapi/image/get"
 )
+
+var log = logging.New("kvmvm")
@@ -30,49 +30,24 @@ builds seamlessly.
 Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
 */
 
-package pfw
+package kvmvm
 
 import (
-	"context"
-	"encoding/json"
-	"net/url"
-	"strconv"
-	"strings"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/cloudinit"
 )
 
-func utilityPfwCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*PfwRecord, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-
-	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
-	resp, err := c.DecortAPICall(ctx, "POST", ComputePfwListAPI, urlValues)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp == "" {
-		return nil, nil
+// cloudInitDiffSuppress compares normalized userdata documents, so that formatting changes
+// do not show up as diffs. Compute stores userdata rendered from both cloud_init and
+// cloud_config, so the old value is also compared with the rendered document.
+func cloudInitDiffSuppress(key, oldVal, newVal string, d *schema.ResourceData) bool {
+	oldDoc := cloudinit.Normalize(oldVal)
+	if oldDoc == cloudinit.Normalize(newVal) {
+		return true
 	}
-
-	idS := strings.Split(d.Id(), "-")[1]
-	id, err := strconv.Atoi(idS)
+	userdata, err := cloudinit.Userdata(newVal, d.Get("cloud_config"))
 	if err != nil {
-		return nil, err
+		return false
 	}
-
-	var pfws []PfwRecord
-	if err := json.Unmarshal([]byte(resp), &pfws); err != nil {
-		return nil, err
-	}
-
-	for _, pfw := range pfws {
-		if pfw.ID == id {
-			return &pfw, nil
-		}
-	}
-
-	return nil, nil
+	return oldDoc == cloudinit.Normalize(userdata)
 }
@@ -37,6 +37,8 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -59,6 +61,11 @@ func dataSourceComputeRead(ctx context.Context, d *schema.ResourceData, m interf
 	d.SetId(strconv.Itoa(int(compute.ID)))
 
 	flattenDataCompute(d, compute)
+
+	if controller.OmitSecretsOf(m) {
+		// users are kept, so that only their passwords are left out of state
+		d.Set("os_users", flattens.OmitSecrets(d.Get("os_users")))
+	}
 	return nil
 }
 
@@ -329,8 +336,9 @@ func computeListDisksSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"passwd": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"pci_slot": {
 			Type:     schema.TypeInt,
@@ -515,8 +523,9 @@ func computeOsUsersSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"password": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"public_key": {
 			Type:     schema.TypeString,
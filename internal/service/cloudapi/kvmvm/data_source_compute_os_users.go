@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+)
+
+func dataSourceComputeOsUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	computeId := uint64(d.Get("compute_id").(int))
+	compute, err := sdk.New(m.(controller.APICaller)).Compute().Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatUint(computeId, 10))
+	d.Set("os_users", parseOsUsers(ctx, compute.OSUsers))
+	return nil
+}
+
+func dataSourceComputeOsUsersSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"compute_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "ID of the compute instance",
+		},
+		"os_users": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: osUsersSubresourceSchemaMake(),
+			},
+			Description: "Guest OS users provisioned on this compute instance.",
+		},
+	}
+}
+
+func DataSourceComputeOsUsers() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 1,
+		Description:   "Reads guest OS users of the compute instance with their passwords. As any data source result, passwords are stored in Terraform state, even if omit_secrets_from_state is set.",
+
+		ReadContext: dataSourceComputeOsUsersRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read:    &constants.Timeout30s,
+			Default: &constants.Timeout60s,
+		},
+
+		Schema: dataSourceComputeOsUsersSchemaMake(),
+	}
+}
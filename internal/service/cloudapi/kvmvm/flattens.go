@@ -33,12 +33,13 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 package kvmvm
 
 import (
+	"context"
 	"encoding/json"
-	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/computedevices"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 )
 
@@ -219,30 +220,40 @@ func flattenComputeList(computes ListComputes) []map[string]interface{} {
 	return res
 }
 
-func flattenComputeDisksDemo(disksList ListComputeDisks, extraDisks []interface{}) []map[string]interface{} {
-	res := make([]map[string]interface{}, 0, len(disksList))
-	for _, disk := range disksList {
-		if disk.Name == "bootdisk" || findInExtraDisks(uint(disk.ID), extraDisks) { //skip main bootdisk and extraDisks
+// flattenComputeDisksDemo flattens data disks managed by disks attribute. Only disks created by this
+// resource are reported, i.e. those which IDs are kept in state, and in the order of the state. Boot
+// disk, extra_disks and disks attached by decort_kvmvm_disk_attachment are never reported, so that
+// they could not be deleted by disks update. Disks removed outside of Terraform are dropped.
+func flattenComputeDisksDemo(disksList ListComputeDisks, owned []interface{}) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(owned))
+	for _, item := range owned {
+		ownedConv := item.(map[string]interface{})
+		diskId := uint64(ownedConv["disk_id"].(int))
+		if diskId == 0 {
 			continue
 		}
-		temp := map[string]interface{}{
-			"disk_name": disk.Name,
-			"disk_id":   disk.ID,
-			"disk_type": disk.Type,
-			"sep_id":    disk.SepID,
-			"shareable": disk.Shareable,
-			"size_max":  disk.SizeMax,
-			"size_used": disk.SizeUsed,
-			"pool":      disk.Pool,
-			"desc":      disk.Description,
-			"image_id":  disk.ImageID,
-			"size":      disk.SizeMax,
+		for _, disk := range disksList {
+			if disk.ID != diskId {
+				continue
+			}
+			temp := map[string]interface{}{
+				"disk_name":   disk.Name,
+				"disk_id":     disk.ID,
+				"disk_type":   disk.Type,
+				"sep_id":      disk.SepID,
+				"shareable":   disk.Shareable,
+				"size_max":    disk.SizeMax,
+				"size_used":   disk.SizeUsed,
+				"pool":        disk.Pool,
+				"desc":        disk.Description,
+				"image_id":    disk.ImageID,
+				"size":        disk.SizeMax,
+				"permanently": ownedConv["permanently"],
+			}
+			res = append(res, temp)
+			break
 		}
-		res = append(res, temp)
 	}
-	sort.Slice(res, func(i, j int) bool {
-		return res[i]["disk_id"].(uint64) < res[j]["disk_id"].(uint64)
-	})
 	return res
 }
 
@@ -270,13 +281,13 @@ func findBootDisk(disks ListComputeDisks) *ItemComputeDisk {
 	return nil
 }
 
-func flattenCompute(d *schema.ResourceData, compute RecordCompute) error {
+func flattenCompute(ctx context.Context, d *schema.ResourceData, compute RecordCompute) error {
 	// This function expects that compFacts string contains response from API compute/get,
 	// i.e. detailed information about compute instance.
 	//
 	// NOTE: this function modifies ResourceData argument - as such it should never be called
 	// from resourceComputeExists(...) method
-	log.Debugf("flattenCompute: ID %d, RG ID %d", compute.ID, compute.RGID)
+	log.Debugf(ctx, "flattenCompute: ID %d, RG ID %d", compute.ID, compute.RGID)
 
 	devices, _ := json.Marshal(compute.Devices)
 	userdata, _ := json.Marshal(compute.Userdata)
@@ -307,7 +318,7 @@ func flattenCompute(d *schema.ResourceData, compute RecordCompute) error {
 	d.Set("deleted_time", compute.DeletedTime)
 	d.Set("description", compute.Description)
 	d.Set("devices", string(devices))
-	err := d.Set("disks", flattenComputeDisksDemo(compute.Disks, d.Get("extra_disks").(*schema.Set).List()))
+	err := d.Set("disks", flattenComputeDisksDemo(compute.Disks, d.Get("disks").([]interface{})))
 	if err != nil {
 		return err
 	}
@@ -333,7 +344,7 @@ func flattenCompute(d *schema.ResourceData, compute RecordCompute) error {
 	d.Set("natable_vins_name", compute.NatableVINSName)
 	d.Set("natable_vins_network", compute.NatableVINSNetwork)
 	d.Set("natable_vins_network_name", compute.NatableVINSNetworkName)
-	if err := d.Set("os_users", parseOsUsers(compute.OSUsers)); err != nil {
+	if err := d.Set("os_users", parseOsUsers(ctx, compute.OSUsers)); err != nil {
 		return err
 	}
 	d.Set("pinned", compute.Pinned)
@@ -353,6 +364,7 @@ func flattenCompute(d *schema.ResourceData, compute RecordCompute) error {
 	d.Set("updated_time", compute.UpdatedTime)
 	d.Set("user_managed", compute.UserManaged)
 	d.Set("vgpus", compute.VGPUs)
+	d.Set("vgpu", computedevices.FlattenVGPUs(compute.VGPUs))
 	d.Set("virtual_image_id", compute.VirtualImageID)
 	d.Set("virtual_image_name", compute.VirtualImageName)
 
@@ -365,12 +377,15 @@ func flattenCompute(d *schema.ResourceData, compute RecordCompute) error {
 	if compute.TechStatus == "STARTED" {
 		d.Set("started", true)
 	}
+	if powerState := powerstate.Of(compute.TechStatus); powerState != "" {
+		d.Set("power_state", powerState)
+	}
 
 	d.Set("network", flattenNetwork(compute.Interfaces))
 
 	//if len(model.Disks) > 0 {
 	//log.Debugf("flattenCompute: calling parseComputeDisksToExtraDisks for %d disks", len(model.Disks))
-	//if err = d.Set("extra_disks", parseComputeDisksToExtraDisks(model.Disks)); err != nil {
+	//if err = d.Set("extra_disks", parseComputeDisksToExtraDisks(ctx, model.Disks)); err != nil {
 	//return err
 	//}
 	//}
@@ -33,12 +33,11 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 package kvmvm
 
 import (
-	log "github.com/sirupsen/logrus"
-
+	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func parseOsUsers(logins ListOSUser) []interface{} {
+func parseOsUsers(ctx context.Context, logins ListOSUser) []interface{} {
 	var result = make([]interface{}, len(logins))
 
 	for index, value := range logins {
@@ -49,7 +48,7 @@ func parseOsUsers(logins ListOSUser) []interface{} {
 		elem["password"] = value.Password
 		elem["public_key"] = value.PubKey
 		result[index] = elem
-		log.Debugf("parseOsUsers: parsed element %d - login %q", index, value.Login)
+		log.Debugf(ctx, "parseOsUsers: parsed element %d - login %q", index, value.Login)
 	}
 
 	return result
@@ -70,9 +69,9 @@ func osUsersSubresourceSchemaMake() map[string]*schema.Schema {
 		},
 
 		"password": {
-			Type:     schema.TypeString,
-			Computed: true,
-			//Sensitive:   true,
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
 			Description: "Password of this guest OS user.",
 		},
 
@@ -34,101 +34,64 @@ package kvmvm
 
 import (
 	"context"
-	"encoding/json"
-	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/lookup"
 )
 
-func existRgID(ctx context.Context, d *schema.ResourceData, m interface{}) bool {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	rgList := []struct {
-		ID int `json:"id"`
-	}{}
-
-	rgListAPI := "/restmachine/cloudapi/rg/list"
-	urlValues.Add("includedeleted", "false")
-	rgListRaw, err := c.DecortAPICall(ctx, "POST", rgListAPI, urlValues)
-	if err != nil {
-		return false
-	}
-	err = json.Unmarshal([]byte(rgListRaw), &rgList)
-	if err != nil {
-		return false
-	}
-	rgId := d.Get("rg_id").(int)
-	for _, rg := range rgList {
-		if rg.ID == rgId {
-			return true
-		}
-	}
-	return false
+// resourceGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff,
+// so that the same checks run at plan time (CustomizeDiff) and at apply time
+type resourceGetter interface {
+	Get(key string) interface{}
 }
 
-func existImageId(ctx context.Context, d *schema.ResourceData, m interface{}) bool {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	imageList := []struct {
-		ID int `json:"id"`
-	}{}
-	imageListAPI := "/restmachine/cloudapi/image/list"
-	imageListRaw, err := c.DecortAPICall(ctx, "POST", imageListAPI, urlValues)
-	if err != nil {
-		return false
-	}
-	err = json.Unmarshal([]byte(imageListRaw), &imageList)
-	if err != nil {
-		return false
-	}
-	imageId := d.Get("image_id").(int)
-	for _, image := range imageList {
-		if image.ID == imageId {
-			return true
-		}
-	}
-	return false
+func existRgID(ctx context.Context, d resourceGetter, m interface{}) (bool, error) {
+	c := m.(controller.APICaller)
+	return lookup.For(c).RG(ctx, d.Get("rg_id").(int))
 }
 
-func existVinsIdInList(vinsId int, vinsList []struct {
-	ID int `json:"id"`
-}) bool {
-	for _, vins := range vinsList {
-		if vinsId == vins.ID {
-			return true
-		}
-	}
-	return false
+func existImageId(ctx context.Context, d resourceGetter, m interface{}) (bool, error) {
+	c := m.(controller.APICaller)
+	return lookup.For(c).Image(ctx, d.Get("image_id").(int))
 }
 
-func existVinsId(ctx context.Context, d *schema.ResourceData, m interface{}) (int, bool) {
+func existVinsId(ctx context.Context, d resourceGetter, m interface{}) (int, bool, error) {
+	c := m.(controller.APICaller)
+	return existNetIdOfType(ctx, d, "VINS", lookup.For(c).Vins)
+}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
+func existExtNetId(ctx context.Context, d resourceGetter, m interface{}) (int, bool, error) {
+	c := m.(controller.APICaller)
+	return existNetIdOfType(ctx, d, "EXTNET", lookup.For(c).ExtNet)
+}
 
-	vinsListAPI := "/restmachine/cloudapi/vins/list"
-	urlValues.Add("includeDeleted", "false")
-	vinsList := []struct {
-		ID int `json:"id"`
-	}{}
-	vinsListRaw, err := c.DecortAPICall(ctx, "POST", vinsListAPI, urlValues)
-	if err != nil {
-		return 0, false
+// existNetId checks single network of the given type, e.g. for network interface resource
+func existNetId(ctx context.Context, m interface{}, netType string, netId int) (bool, error) {
+	c := m.(controller.APICaller)
+	if netType == "EXTNET" {
+		return lookup.For(c).ExtNet(ctx, netId)
 	}
-	err = json.Unmarshal([]byte(vinsListRaw), &vinsList)
-	if err != nil {
-		return 0, false
-	}
-
-	networks := d.Get("network").(*schema.Set).List()
-
-	for _, networkInterface := range networks {
+	return lookup.For(c).Vins(ctx, netId)
+}
 
+// existNetIdOfType checks that every network block of the given type refers to an existing
+// network. It returns the first missing network ID, if any.
+func existNetIdOfType(ctx context.Context, d resourceGetter, netType string, exists func(context.Context, int) (bool, error)) (int, bool, error) {
+	for _, networkInterface := range d.Get("network").(*schema.Set).List() {
 		networkItem := networkInterface.(map[string]interface{})
-		if !existVinsIdInList(networkItem["net_id"].(int), vinsList) {
-			return networkItem["net_id"].(int), false
+		netId := networkItem["net_id"].(int)
+		// net_id is zero when it is not known yet at plan time
+		if networkItem["net_type"].(string) != netType || netId == 0 {
+			continue
+		}
+		ok, err := exists(ctx, netId)
+		if err != nil {
+			return 0, false, err
+		}
+		if !ok {
+			return netId, false, nil
 		}
 	}
-	return 0, true
+	return 0, true, nil
 }
@@ -38,12 +38,17 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/cloudinit"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	diskssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/disks"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/statefuncs"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -54,21 +59,43 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	// we assume all mandatory parameters it takes to create a comptue instance are properly
 	// specified - we rely on schema "Required" attributes to let Terraform validate them for us
 
-	log.Debugf("resourceComputeCreate: called for Compute name %q, RG ID %d", d.Get("name").(string), d.Get("rg_id").(int))
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceComputeCreate: called for Compute name %q, RG ID %d", d.Get("name").(string), d.Get("rg_id").(int))
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
-	if !existRgID(ctx, d, m) {
-		return diag.Errorf("resourceComputeCreate: can't create Compute bacause rgID %d not allowed or does not exist", d.Get("rg_id").(int))
+	haveRGID, err := existRgID(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !haveRGID {
+		return diag.Errorf("resourceComputeCreate: can't create Compute because rgID %d not allowed or does not exist", d.Get("rg_id").(int))
 	}
 
-	if !existImageId(ctx, d, m) {
-		return diag.Errorf("resourceComputeCreate: can't create Compute bacause imageID %d not allowed or does not exist", d.Get("image_id").(int))
+	_, cloning := d.GetOk("clone_from")
+	if !cloning {
+		haveImageID, err := existImageId(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !haveImageID {
+			return diag.Errorf("resourceComputeCreate: can't create Compute because imageID %d not allowed or does not exist", d.Get("image_id").(int))
+		}
 	}
 
 	if _, ok := d.GetOk("network"); ok {
-		if vinsId, ok := existVinsId(ctx, d, m); !ok {
-			return diag.Errorf("resourceResgroupCreate: can't create RG bacause vins ID %d not allowed or does not exist", vinsId)
+		vinsId, ok, err := existVinsId(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ok {
+			return diag.Errorf("resourceComputeCreate: can't create Compute because vins ID %d not allowed or does not exist", vinsId)
+		}
+		extNetId, ok, err := existExtNetId(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ok {
+			return diag.Errorf("resourceComputeCreate: can't create Compute because extnet ID %d not allowed or does not exist", extNetId)
 		}
 	}
 
@@ -120,37 +147,37 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
-	/*
-		sshKeysVal, sshKeysSet := d.GetOk("ssh_keys")
-		if sshKeysSet {
-			// process SSH Key settings and set API values accordingly
-			log.Debugf("resourceComputeCreate: calling makeSshKeysArgString to setup SSH keys for guest login(s)")
-			urlValues.Add("userdata", makeSshKeysArgString(sshKeysVal.([]interface{})))
-		}
-	*/
-
 	computeCreateAPI := KvmX86CreateAPI
 	driver := d.Get("driver").(string)
 	if driver == "KVM_PPC" {
 		computeCreateAPI = KvmPPCCreateAPI
-		log.Debugf("resourceComputeCreate: creating Compute of type KVM VM PowerPC")
+		log.Debugf(ctx, "resourceComputeCreate: creating Compute of type KVM VM PowerPC")
 	} else { // note that we do not validate arch value for explicit "KVM_X86" here
-		log.Debugf("resourceComputeCreate: creating Compute of type KVM VM x86")
+		log.Debugf(ctx, "resourceComputeCreate: creating Compute of type KVM VM x86")
 	}
 
-	argVal, ok = d.GetOk("cloud_init")
-	if ok {
-		// userdata must not be empty string and must not be a reserved keyword "applied"
-		userdata := argVal.(string)
-		if userdata != "" && userdata != "applied" {
-			urlValues.Add("userdata", userdata)
-		}
-	}
-
-	apiResp, err := c.DecortAPICall(ctx, "POST", computeCreateAPI, urlValues)
+	userdata, err := cloudinit.Userdata(d.Get("cloud_init").(string), d.Get("cloud_config"))
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if userdata != "" {
+		urlValues.Add("userdata", userdata)
+	}
+
+	var apiResp string
+	if cloning {
+		// compute is cloned instead of being created from image, the rest of its configuration is the same
+		cloneId, err := utilityComputeCreateClone(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		apiResp = strconv.FormatUint(cloneId, 10)
+	} else {
+		apiResp, err = c.DecortAPICall(ctx, "POST", computeCreateAPI, urlValues)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	urlValues = &url.Values{}
 	// Compute create API returns ID of the new Compute instance on success
 
@@ -168,43 +195,68 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 			urlValues.Add("detachDisks", "1")
 
 			if _, err := c.DecortAPICall(ctx, "POST", ComputeDeleteAPI, urlValues); err != nil {
-				log.Errorf("resourceComputeCreate: could not delete compute after failed creation: %v", err)
+				log.Errorf(ctx, "resourceComputeCreate: could not delete compute after failed creation: %v", err)
 			}
 			d.SetId("")
 			urlValues = &url.Values{}
 		}
 	}()
 
-	log.Debugf("resourceComputeCreate: new simple Compute ID %d, name %s created", compId, d.Get("name").(string))
+	log.Debugf(ctx, "resourceComputeCreate: new simple Compute ID %d, name %s created", compId, d.Get("name").(string))
+
+	if cloning {
+		if err := utilityComputeCloneConfigure(ctx, d, m); err != nil {
+			log.Errorf(ctx, "resourceComputeCreate: error when configuring a new clone ID %d: %v", compId, err)
+			cleanup = true
+			return diag.FromErr(err)
+		}
+	}
 
 	argVal, ok = d.GetOk("extra_disks")
 	if ok && argVal.(*schema.Set).Len() > 0 {
-		log.Debugf("resourceComputeCreate: calling utilityComputeExtraDisksConfigure to attach %d extra disk(s)", argVal.(*schema.Set).Len())
+		log.Debugf(ctx, "resourceComputeCreate: calling utilityComputeExtraDisksConfigure to attach %d extra disk(s)", argVal.(*schema.Set).Len())
 		err = utilityComputeExtraDisksConfigure(ctx, d, m, false) // do_delta=false, as we are working on a new compute
 		if err != nil {
-			log.Errorf("resourceComputeCreate: error when attaching extra disk(s) to a new Compute ID %d: %v", compId, err)
+			log.Errorf(ctx, "resourceComputeCreate: error when attaching extra disk(s) to a new Compute ID %d: %v", compId, err)
 			cleanup = true
 			return diag.FromErr(err)
 		}
 	}
 	argVal, ok = d.GetOk("network")
 	if ok && argVal.(*schema.Set).Len() > 0 {
-		log.Debugf("resourceComputeCreate: calling utilityComputeNetworksConfigure to attach %d network(s)", argVal.(*schema.Set).Len())
-		err = utilityComputeNetworksConfigure(ctx, d, m, false, true)
+		log.Debugf(ctx, "resourceComputeCreate: calling utilityComputeNetworksConfigure to attach %d network(s)", argVal.(*schema.Set).Len())
+		// the first network is passed to create API, unless compute is cloned
+		err = utilityComputeNetworksConfigure(ctx, d, m, false, !cloning)
 		if err != nil {
-			log.Errorf("resourceComputeCreate: error when attaching networks to a new Compute ID %d: %s", compId, err)
+			log.Errorf(ctx, "resourceComputeCreate: error when attaching networks to a new Compute ID %d: %s", compId, err)
 			cleanup = true
 			return diag.FromErr(err)
 		}
 	}
 
+	if err := utilityComputeDevicesConfigure(ctx, d, m); err != nil {
+		log.Errorf(ctx, "resourceComputeCreate: error when attaching devices to a new Compute ID %d: %v", compId, err)
+		cleanup = true
+		return diag.FromErr(err)
+	}
+
+	if _, ok := d.GetOk("boot_order"); ok {
+		if err := utilityComputeBootOrderSet(ctx, d, m); err != nil {
+			warnings.Add(err)
+		}
+	}
+
 	// Note bene: we created compute in a STOPPED state (this is required to properly attach 1st network interface),
-	// now we need to start it before we report the sequence complete
-	if d.Get("started").(bool) {
-		reqValues := &url.Values{}
-		reqValues.Add("computeId", fmt.Sprintf("%d", compId))
-		log.Debugf("resourceComputeCreate: starting Compute ID %d after completing its resource configuration", compId)
-		if _, err := c.DecortAPICall(ctx, "POST", ComputeStartAPI, reqValues); err != nil {
+	// now we need to bring it to the configured power state before we report the sequence complete
+	if rescueMode, ok := d.GetOk("rescue_mode"); ok {
+		cdromId := rescueMode.([]interface{})[0].(map[string]interface{})["cdrom_id"].(int)
+		log.Debugf(ctx, "resourceComputeCreate: booting Compute ID %d from CD-ROM image ID %d", compId, cdromId)
+		if err := utilityComputeRescueBoot(ctx, d, m, uint64(cdromId)); err != nil {
+			warnings.Add(err)
+		}
+	} else if powerState := utilityComputeTargetPowerState(d, true); powerState != powerStateStopped {
+		log.Debugf(ctx, "resourceComputeCreate: bringing Compute ID %d to power state %s after completing its resource configuration", compId, powerState)
+		if err := utilityComputeSetPowerState(ctx, d, m, powerState); err != nil {
 			warnings.Add(err)
 		}
 	}
@@ -216,7 +268,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 		urlValues := &url.Values{}
 		urlValues.Add("computeId", fmt.Sprintf("%d", compId))
-		log.Debugf("resourceComputeCreate: enable=%t Compute ID %d after completing its resource configuration", compId, enabled)
+		log.Debugf(ctx, "resourceComputeCreate: enable=%t Compute ID %d after completing its resource configuration", enabled, compId)
 		if _, err := c.DecortAPICall(ctx, "POST", api, urlValues); err != nil {
 			warnings.Add(err)
 		}
@@ -237,42 +289,19 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 
 		if disks, ok := d.GetOk("disks"); ok {
-			log.Debugf("resourceComputeCreate: Create disks on ComputeID: %d", compId)
+			log.Debugf(ctx, "resourceComputeCreate: Create disks on ComputeID: %d", compId)
 			addedDisks := disks.([]interface{})
-			if len(addedDisks) > 0 {
-				for _, disk := range addedDisks {
-					diskConv := disk.(map[string]interface{})
-
-					urlValues.Add("computeId", d.Id())
-					urlValues.Add("diskName", diskConv["disk_name"].(string))
-					urlValues.Add("size", strconv.Itoa(diskConv["size"].(int)))
-					if diskConv["disk_type"].(string) != "" {
-						urlValues.Add("diskType", diskConv["disk_type"].(string))
-					}
-					if diskConv["sep_id"].(int) != 0 {
-						urlValues.Add("sepId", strconv.Itoa(diskConv["sep_id"].(int)))
-					}
-					if diskConv["pool"].(string) != "" {
-						urlValues.Add("pool", diskConv["pool"].(string))
-					}
-					if diskConv["desc"].(string) != "" {
-						urlValues.Add("desc", diskConv["desc"].(string))
-					}
-					if diskConv["image_id"].(int) != 0 {
-						urlValues.Add("imageId", strconv.Itoa(diskConv["image_id"].(int)))
-					}
-					_, err := c.DecortAPICall(ctx, "POST", ComputeDiskAddAPI, urlValues)
-					if err != nil {
-						cleanup = true
-						return diag.FromErr(err)
-					}
-					urlValues = &url.Values{}
-				}
+			err := utilityComputeDisksAdd(ctx, m, uint64(compId), addedDisks)
+			// IDs of created disks are kept in state, so that they are tracked as owned by this resource
+			d.Set("disks", addedDisks)
+			if err != nil {
+				cleanup = true
+				return diag.FromErr(err)
 			}
 		}
 
 		if ars, ok := d.GetOk("affinity_rules"); ok {
-			log.Debugf("resourceComputeCreate: Create affinity rules on ComputeID: %d", compId)
+			log.Debugf(ctx, "resourceComputeCreate: Create affinity rules on ComputeID: %d", compId)
 			addedAR := ars.([]interface{})
 			if len(addedAR) > 0 {
 				for _, ar := range addedAR {
@@ -294,7 +323,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 
 		if ars, ok := d.GetOk("anti_affinity_rules"); ok {
-			log.Debugf("resourceComputeCreate: Create anti affinity rules on ComputeID: %d", compId)
+			log.Debugf(ctx, "resourceComputeCreate: Create anti affinity rules on ComputeID: %d", compId)
 			addedAR := ars.([]interface{})
 			if len(addedAR) > 0 {
 				for _, ar := range addedAR {
@@ -317,7 +346,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if tags, ok := d.GetOk("tags"); ok {
-		log.Debugf("resourceComputeCreate: Create tags on ComputeID: %d", compId)
+		log.Debugf(ctx, "resourceComputeCreate: Create tags on ComputeID: %d", compId)
 		addedTags := tags.(*schema.Set).List()
 		if len(addedTags) > 0 {
 			for _, tagInterface := range addedTags {
@@ -336,7 +365,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if pfws, ok := d.GetOk("port_forwarding"); ok {
-		log.Debugf("resourceComputeCreate: Create port farwarding on ComputeID: %d", compId)
+		log.Debugf(ctx, "resourceComputeCreate: Create port farwarding on ComputeID: %d", compId)
 		addedPfws := pfws.(*schema.Set).List()
 		if len(addedPfws) > 0 {
 			for _, pfwInterface := range addedPfws {
@@ -356,7 +385,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 	if userAcess, ok := d.GetOk("user_access"); ok {
-		log.Debugf("resourceComputeCreate: Create user access on ComputeID: %d", compId)
+		log.Debugf(ctx, "resourceComputeCreate: Create user access on ComputeID: %d", compId)
 		usersAcess := userAcess.(*schema.Set).List()
 		if len(usersAcess) > 0 {
 			for _, userAcessInterface := range usersAcess {
@@ -375,7 +404,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if snapshotList, ok := d.GetOk("snapshot"); ok {
-		log.Debugf("resourceComputeCreate: Create snapshot on ComputeID: %d", compId)
+		log.Debugf(ctx, "resourceComputeCreate: Create snapshot on ComputeID: %d", compId)
 		snapshots := snapshotList.(*schema.Set).List()
 		if len(snapshots) > 0 {
 			for _, snapshotInterface := range snapshots {
@@ -393,7 +422,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if cdtList, ok := d.GetOk("cd"); ok {
-		log.Debugf("resourceComputeCreate: Create cd on ComputeID: %d", compId)
+		log.Debugf(ctx, "resourceComputeCreate: Create cd on ComputeID: %d", compId)
 		cds := cdtList.(*schema.Set).List()
 		if len(cds) > 0 {
 			urlValues = &url.Values{}
@@ -417,16 +446,7 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
-	if d.Get("pause").(bool) == true {
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		_, err := c.DecortAPICall(ctx, "POST", ComputePauseAPI, urlValues)
-		if err != nil {
-			warnings.Add(err)
-		}
-	}
-
-	log.Debugf("resourceComputeCreate: new Compute ID %d, name %s creation sequence complete", compId, d.Get("name").(string))
+	log.Debugf(ctx, "resourceComputeCreate: new Compute ID %d, name %s creation sequence complete", compId, d.Get("name").(string))
 
 	// We may reuse dataSourceComputeRead here as we maintain similarity
 	// between Compute resource and Compute data source schemas
@@ -437,10 +457,10 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceComputeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceComputeRead: called for Compute name %s, RG ID %d",
+	log.Debugf(ctx, "resourceComputeRead: called for Compute name %s, RG ID %d",
 		d.Get("name").(string), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	compute, err := utilityComputeCheckPresence(ctx, d, m)
 	if err != nil {
@@ -451,6 +471,9 @@ func resourceComputeRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	switch compute.Status {
 	case status.Deleted:
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "Compute", compute.Status)
+		}
 		urlValues := &url.Values{}
 		urlValues.Add("computeId", d.Id())
 		_, err := c.DecortAPICall(ctx, "POST", ComputeRestoreAPI, urlValues)
@@ -464,10 +487,13 @@ func resourceComputeRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 		hasChanged = true
 	case status.Destroyed:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "Compute", compute.Status)
+		}
 		d.SetId("")
 		return resourceComputeCreate(ctx, d, m)
 	case status.Disabled:
-		log.Debugf("The compute is in status: %s, troubles may occur with update. Please, enable compute first.", compute.Status)
+		log.Debugf(ctx, "The compute is in status: %s, troubles may occur with update. Please, enable compute first.", compute.Status)
 	case status.Redeploying:
 	case status.Deleting:
 	case status.Destroying:
@@ -484,34 +510,62 @@ func resourceComputeRead(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
-	if err = flattenCompute(d, compute); err != nil {
+	if err = flattenCompute(ctx, d, compute); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = utilityComputePCIDevicesRead(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Debugf("resourceComputeRead: after flattenCompute: Compute ID %s, name %q, RG ID %d",
+	if controller.OmitSecretsOf(m) {
+		// users are kept, so that only their passwords are left out of state
+		d.Set("os_users", flattens.OmitSecrets(d.Get("os_users")))
+	}
+
+	log.Debugf(ctx, "resourceComputeRead: after flattenCompute: Compute ID %s, name %q, RG ID %d",
 		d.Id(), d.Get("name").(string), d.Get("rg_id").(int))
 
 	return nil
 }
 
 func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceComputeUpdate: called for Compute ID %s / name %s, RGID %d",
+	log.Debugf(ctx, "resourceComputeUpdate: called for Compute ID %s / name %s, RGID %d",
 		d.Id(), d.Get("name").(string), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-
-	if !existRgID(ctx, d, m) {
-		return diag.Errorf("resourceComputeUpdate: can't update Compute bacause rgID %d not allowed or does not exist", d.Get("rg_id").(int))
+	haveRGID, err := existRgID(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !haveRGID {
+		return diag.Errorf("resourceComputeUpdate: can't update Compute because rgID %d not allowed or does not exist", d.Get("rg_id").(int))
 	}
 
-	if !existImageId(ctx, d, m) {
-		return diag.Errorf("resourceComputeUpdate: can't update Compute bacause imageID %d not allowed or does not exist", d.Get("image_id").(int))
+	// image is only checked when changed, as clones inherit image_id of their source, which may be removed since
+	if d.HasChange("image_id") {
+		haveImageID, err := existImageId(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !haveImageID {
+			return diag.Errorf("resourceComputeUpdate: can't update Compute because imageID %d not allowed or does not exist", d.Get("image_id").(int))
+		}
 	}
 
 	if _, ok := d.GetOk("network"); ok {
-		if vinsId, ok := existVinsId(ctx, d, m); !ok {
-			return diag.Errorf("resourceResgroupUpdate: can't update RG bacause vinsID %d not allowed or does not exist", vinsId)
+		vinsId, ok, err := existVinsId(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ok {
+			return diag.Errorf("resourceComputeUpdate: can't update Compute because vinsID %d not allowed or does not exist", vinsId)
+		}
+		extNetId, ok, err := existExtNetId(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ok {
+			return diag.Errorf("resourceComputeUpdate: can't update Compute because extnet ID %d not allowed or does not exist", extNetId)
 		}
 	}
 
@@ -520,16 +574,16 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("enabled") {
-		enabled := d.Get("enabled")
-		api := ComputeDisableAPI
-		if enabled.(bool) {
-			api = ComputeEnableAPI
-		}
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		log.Debugf("resourceComputeUpdate: enable=%t Compute ID %s after completing its resource configuration", d.Id(), enabled)
-		if _, err := c.DecortAPICall(ctx, "POST", api, urlValues); err != nil {
+	computeId, _ := strconv.ParseUint(d.Id(), 10, 64)
+	api := sdk.New(m.(controller.APICaller)).Compute()
+	idReq := computesdk.IDRequest{ComputeID: computeId}
+	// restarts of running compute are reported as warnings, so that they are visible in apply output
+	warnings := dc.Warnings{}
+
+	// compute is enabled before it may be started below and disabled after it has been stopped
+	if d.HasChange("enabled") && d.Get("enabled").(bool) {
+		log.Debugf(ctx, "resourceComputeUpdate: enabling Compute ID %s", d.Id())
+		if _, err := api.Enable(ctx, idReq); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -537,21 +591,17 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	// check compute statuses
 	switch compute.Status {
 	case status.Deleted:
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		_, err := c.DecortAPICall(ctx, "POST", ComputeRestoreAPI, urlValues)
-		if err != nil {
+		if _, err := api.Restore(ctx, idReq); err != nil {
 			return diag.FromErr(err)
 		}
-		_, err = c.DecortAPICall(ctx, "POST", ComputeEnableAPI, urlValues)
-		if err != nil {
+		if _, err := api.Enable(ctx, idReq); err != nil {
 			return diag.FromErr(err)
 		}
 	case status.Destroyed:
 		d.SetId("")
 		return resourceComputeCreate(ctx, d, m)
 	case status.Disabled:
-		log.Debugf("The compute is in status: %s, may troubles can be occured with update. Please, enable compute first.", compute.Status)
+		log.Debugf(ctx, "The compute is in status: %s, may troubles can be occured with update. Please, enable compute first.", compute.Status)
 	case status.Redeploying:
 	case status.Deleting:
 	case status.Destroying:
@@ -561,6 +611,7 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	/*
+		0. Move to another RG
 		1. Resize CPU/RAM
 		2. Resize (grow) boot disk
 		3. Update extra disks
@@ -568,34 +619,59 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		5. Start/stop
 	*/
 
-	// 1. Resize CPU/RAM
-	urlValues = &url.Values{}
-	doUpdate := false
-	urlValues.Add("computeId", d.Id())
-
-	oldCpu, newCpu := d.GetChange("cpu")
-	if oldCpu.(int) != newCpu.(int) {
-		urlValues.Add("cpu", fmt.Sprintf("%d", newCpu.(int)))
-		doUpdate = true
-	} else {
-		urlValues.Add("cpu", "0") // no change to CPU allocation
+	// 0. Move to another RG in place, running compute is stopped for the move and started again
+	if d.HasChange("rg_id") {
+		oldRg, newRg := d.GetChange("rg_id")
+		running := compute.TechStatus == techstatus.Started
+		log.Debugf(ctx, "resourceComputeUpdate: moving Compute ID %s from RG ID %d to RG ID %d", d.Id(), oldRg.(int), newRg.(int))
+		_, err := api.MoveToRG(ctx, computesdk.MoveToRGRequest{
+			ComputeID: computeId,
+			RGID:      uint64(newRg.(int)),
+			AutoStart: running,
+			ForceStop: d.Get("force_stop").(bool),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if running {
+			warnings.Add(fmt.Errorf("Compute ID %s was stopped and started to move it to RG ID %d", d.Id(), newRg.(int)))
+		}
 	}
 
+	// 1. Resize CPU/RAM
+	oldCpu, newCpu := d.GetChange("cpu")
 	oldRam, newRam := d.GetChange("ram")
-	if oldRam.(int) != newRam.(int) {
-		urlValues.Add("ram", fmt.Sprintf("%d", newRam.(int)))
-		doUpdate = true
-	} else {
-		urlValues.Add("ram", "0")
-	}
+	if oldCpu.(int) != newCpu.(int) || oldRam.(int) != newRam.(int) {
+		resizeMode, err := utilityComputeResizeMode(ctx, m, computeId,
+			oldCpu.(int), newCpu.(int), oldRam.(int), newRam.(int),
+			d.Get("allow_restart_for_resize").(bool))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	if doUpdate {
-		log.Debugf("resourceComputeUpdate: changing CPU %d -> %d and/or RAM %d -> %d",
+		log.Debugf(ctx, "resourceComputeUpdate: changing CPU %d -> %d and/or RAM %d -> %d, resize mode %s",
 			oldCpu.(int), newCpu.(int),
-			oldRam.(int), newRam.(int))
-		urlValues.Add("force", "true")
-		_, err := c.DecortAPICall(ctx, "POST", ComputeResizeAPI, urlValues)
-		if err != nil {
+			oldRam.(int), newRam.(int), resizeMode)
+
+		// 0 keeps current CPU or RAM allocation
+		resizeReq := computesdk.ResizeRequest{ComputeID: computeId, Force: true}
+		if oldCpu.(int) != newCpu.(int) {
+			resizeReq.CPU = uint64(newCpu.(int))
+		}
+		if oldRam.(int) != newRam.(int) {
+			resizeReq.RAM = uint64(newRam.(int))
+		}
+		resize := func() error {
+			_, err := api.Resize(ctx, resizeReq)
+			return err
+		}
+
+		if resizeMode == resizeModeRestart {
+			if _, err := utilityComputeStopped(ctx, m, computeId, d.Get("force_stop").(bool), resize); err != nil {
+				return diag.FromErr(err)
+			}
+			warnings.Add(fmt.Errorf("Compute ID %s was stopped and started to change CPU/RAM, as it cannot be resized while running", d.Id()))
+		} else if err := resize(); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -603,25 +679,27 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	// 2. Resize (grow) Boot disk
 	oldSize, newSize := d.GetChange("boot_disk_size")
 	if oldSize.(int) < newSize.(int) {
-		urlValues := &url.Values{}
+		var bootDiskId uint64
 		if diskId, ok := d.GetOk("boot_disk_id"); ok {
-			urlValues.Add("diskId", strconv.Itoa(diskId.(int)))
+			bootDiskId = uint64(diskId.(int))
 		} else {
 			bootDisk, err := utilityComputeBootDiskCheckPresence(ctx, d, m)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			urlValues.Add("diskId", strconv.FormatUint(bootDisk.ID, 10))
-		}
-		urlValues.Add("size", strconv.Itoa(newSize.(int)))
-		log.Debugf("resourceComputeUpdate: compute ID %s, boot disk ID %d resize %d -> %d",
-			d.Id(), d.Get("boot_disk_id").(int), oldSize.(int), newSize.(int))
-		_, err := c.DecortAPICall(ctx, "POST", DisksResizeAPI, urlValues)
+			bootDiskId = bootDisk.ID
+		}
+		log.Debugf(ctx, "resourceComputeUpdate: compute ID %s, boot disk ID %d resize %d -> %d",
+			d.Id(), bootDiskId, oldSize.(int), newSize.(int))
+		_, err := sdk.New(m.(controller.APICaller)).Disks().Resize(ctx, diskssdk.ResizeRequest{
+			DiskID: bootDiskId,
+			Size:   uint64(newSize.(int)),
+		})
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else if oldSize.(int) > newSize.(int) {
-		log.Warnf("resourceComputeUpdate: compute ID %s - shrinking boot disk is not allowed", d.Id())
+		log.Warnf(ctx, "resourceComputeUpdate: compute ID %s - shrinking boot disk is not allowed", d.Id())
 	}
 
 	// 3. Calculate and apply changes to data disks
@@ -630,6 +708,10 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		oldSet, newSet := d.GetChange("extra_disks")
+		if detachSet := oldSet.(*schema.Set).Difference(newSet.(*schema.Set)); detachSet.Len() > 0 && compute.TechStatus == techstatus.Started {
+			warnings.Add(fmt.Errorf("Compute ID %s was stopped and started to detach %d extra disk(s)", d.Id(), detachSet.Len()))
+		}
 	}
 
 	// 4. Calculate and apply changes to network connections
@@ -639,317 +721,81 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if d.HasChange("description") || d.HasChange("name") {
-		updateParams := &url.Values{}
-		updateParams.Add("computeId", d.Id())
-		updateParams.Add("name", d.Get("name").(string))
-		updateParams.Add("desc", d.Get("description").(string))
-		if _, err := c.DecortAPICall(ctx, "POST", ComputeUpdateAPI, updateParams); err != nil {
+		description := d.Get("description").(string)
+		_, err := api.Update(ctx, computesdk.UpdateRequest{
+			ComputeID:   computeId,
+			Name:        d.Get("name").(string),
+			Description: &description,
+		})
+		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
-	urlValues = &url.Values{}
 	if d.HasChange("disks") {
-		deletedDisks := make([]interface{}, 0)
-		addedDisks := make([]interface{}, 0)
-		updatedDisks := make([]interface{}, 0)
-
-		oldDisks, newDisks := d.GetChange("disks")
-		oldConv := oldDisks.([]interface{})
-		newConv := newDisks.([]interface{})
-
-		for _, el := range oldConv {
-			if !isContainsDisk(newConv, el) {
-				deletedDisks = append(deletedDisks, el)
-			}
-		}
-
-		for _, el := range newConv {
-			if !isContainsDisk(oldConv, el) {
-				addedDisks = append(addedDisks, el)
-			} else {
-				if isChangeDisk(oldConv, el) {
-					updatedDisks = append(updatedDisks, el)
-				}
-			}
-		}
-
-		if len(deletedDisks) > 0 {
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("force", "false")
-			_, err := c.DecortAPICall(ctx, "POST", ComputeStopAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			urlValues = &url.Values{}
-
-			for _, disk := range deletedDisks {
-				diskConv := disk.(map[string]interface{})
-				if diskConv["disk_name"].(string) == "bootdisk" {
-					continue
-				}
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("diskId", strconv.Itoa(diskConv["disk_id"].(int)))
-				urlValues.Add("permanently", strconv.FormatBool(diskConv["permanently"].(bool)))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeDiskDeleteAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
-
-				urlValues = &url.Values{}
-			}
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("altBootId", "0")
-			_, err = c.DecortAPICall(ctx, "POST", ComputeStartAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			urlValues = &url.Values{}
-		}
-
-		if len(addedDisks) > 0 {
-			for _, disk := range addedDisks {
-				diskConv := disk.(map[string]interface{})
-				if diskConv["disk_name"].(string) == "bootdisk" {
-					continue
-				}
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("diskName", diskConv["disk_name"].(string))
-				urlValues.Add("size", strconv.Itoa(diskConv["size"].(int)))
-				if diskConv["disk_type"].(string) != "" {
-					urlValues.Add("diskType", diskConv["disk_type"].(string))
-				}
-				if diskConv["sep_id"].(int) != 0 {
-					urlValues.Add("sepId", strconv.Itoa(diskConv["sep_id"].(int)))
-				}
-				if diskConv["pool"].(string) != "" {
-					urlValues.Add("pool", diskConv["pool"].(string))
-				}
-				if diskConv["desc"].(string) != "" {
-					urlValues.Add("desc", diskConv["desc"].(string))
-				}
-				if diskConv["image_id"].(int) != 0 {
-					urlValues.Add("imageId", strconv.Itoa(diskConv["image_id"].(int)))
-				}
-				_, err := c.DecortAPICall(ctx, "POST", ComputeDiskAddAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
-
-				urlValues = &url.Values{}
-			}
-		}
-
-		if len(updatedDisks) > 0 {
-			for _, disk := range updatedDisks {
-				diskConv := disk.(map[string]interface{})
-				if diskConv["disk_name"].(string) == "bootdisk" {
-					continue
-				}
-				urlValues = &url.Values{}
-				urlValues.Add("diskId", strconv.Itoa(diskConv["disk_id"].(int)))
-				urlValues.Add("size", strconv.Itoa(diskConv["size"].(int)))
-				_, err := c.DecortAPICall(ctx, "POST", DisksResizeAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
-			}
+		if err := utilityComputeDisksConfigure(ctx, d, m, &warnings); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	if d.HasChange("started") {
-		params := &url.Values{}
-		params.Add("computeId", d.Id())
-		if d.Get("started").(bool) {
-			if _, err := c.DecortAPICall(ctx, "POST", ComputeStartAPI, params); err != nil {
-				return diag.FromErr(err)
-			}
-		} else {
-			if _, err := c.DecortAPICall(ctx, "POST", ComputeStopAPI, params); err != nil {
-				return diag.FromErr(err)
-			}
+	if d.HasChanges("vgpu", "pci_device") {
+		if err := utilityComputeDevicesConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	if d.HasChange("affinity_label") {
-		affinityLabel := d.Get("affinity_label").(string)
-		urlValues.Add("computeId", d.Id())
-		if affinityLabel == "" {
-			_, err := c.DecortAPICall(ctx, "POST", ComputeAffinityLabelRemoveAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		}
-		urlValues.Add("affinityLabel", affinityLabel)
-		_, err := c.DecortAPICall(ctx, "POST", ComputeAffinityLabelSetAPI, urlValues)
-		if err != nil {
+	if d.HasChange("boot_order") && len(d.Get("boot_order").([]interface{})) > 0 {
+		if err := utilityComputeBootOrderSet(ctx, d, m); err != nil {
 			return diag.FromErr(err)
 		}
-		urlValues = &url.Values{}
 	}
 
-	if d.HasChange("affinity_rules") {
-		deletedAR := make([]interface{}, 0)
-		addedAR := make([]interface{}, 0)
-
-		oldAR, newAR := d.GetChange("affinity_rules")
-		oldConv := oldAR.([]interface{})
-		newConv := newAR.([]interface{})
-
-		if len(newConv) == 0 {
-			urlValues := &url.Values{}
-			urlValues.Add("computeId", d.Id())
-			_, err := c.DecortAPICall(ctx, "POST", ComputeAffinityRulesClearAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+	if d.HasChange("affinity_label") {
+		if affinityLabel := d.Get("affinity_label").(string); affinityLabel == "" {
+			_, err = api.AffinityLabelRemove(ctx, idReq)
 		} else {
-			for _, el := range oldConv {
-				if !isContainsAR(newConv, el) {
-					deletedAR = append(deletedAR, el)
-				}
-			}
-			for _, el := range newConv {
-				if !isContainsAR(oldConv, el) {
-					addedAR = append(addedAR, el)
-				}
-			}
-
-			if len(deletedAR) > 0 {
-				urlValues := &url.Values{}
-				for _, ar := range deletedAR {
-					arConv := ar.(map[string]interface{})
-					urlValues.Add("computeId", d.Id())
-					urlValues.Add("topology", arConv["topology"].(string))
-					urlValues.Add("policy", arConv["policy"].(string))
-					urlValues.Add("mode", arConv["mode"].(string))
-					urlValues.Add("key", arConv["key"].(string))
-					urlValues.Add("value", arConv["value"].(string))
-					_, err := c.DecortAPICall(ctx, "POST", ComputeAffinityRuleRemoveAPI, urlValues)
-					if err != nil {
-						return diag.FromErr(err)
-					}
-
-					urlValues = &url.Values{}
-				}
-			}
-			if len(addedAR) > 0 {
-				for _, ar := range addedAR {
-					arConv := ar.(map[string]interface{})
-					urlValues.Add("computeId", d.Id())
-					urlValues.Add("topology", arConv["topology"].(string))
-					urlValues.Add("policy", arConv["policy"].(string))
-					urlValues.Add("mode", arConv["mode"].(string))
-					urlValues.Add("key", arConv["key"].(string))
-					urlValues.Add("value", arConv["value"].(string))
-					_, err := c.DecortAPICall(ctx, "POST", ComputeAffinityRuleAddAPI, urlValues)
-					if err != nil {
-						return diag.FromErr(err)
-					}
-
-					urlValues = &url.Values{}
-				}
-			}
+			_, err = api.AffinityLabelSet(ctx, computesdk.AffinityLabelSetRequest{ComputeID: computeId, AffinityLabel: affinityLabel})
 		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	}
-
-	if d.HasChange("anti_affinity_rules") {
-		deletedAR := make([]interface{}, 0)
-		addedAR := make([]interface{}, 0)
-
-		oldAR, newAR := d.GetChange("anti_affinity_rules")
-		oldConv := oldAR.([]interface{})
-		newConv := newAR.([]interface{})
-
-		if len(newConv) == 0 {
-			urlValues := &url.Values{}
-			urlValues.Add("computeId", d.Id())
-			_, err := c.DecortAPICall(ctx, "POST", ComputeAntiAffinityRulesClearAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		} else {
-			for _, el := range oldConv {
-				if !isContainsAR(newConv, el) {
-					deletedAR = append(deletedAR, el)
-				}
-			}
-			for _, el := range newConv {
-				if !isContainsAR(oldConv, el) {
-					addedAR = append(addedAR, el)
-				}
-			}
-
-			if len(deletedAR) > 0 {
-				urlValues := &url.Values{}
-				for _, ar := range deletedAR {
-					arConv := ar.(map[string]interface{})
-					urlValues.Add("computeId", d.Id())
-					urlValues.Add("topology", arConv["topology"].(string))
-					urlValues.Add("policy", arConv["policy"].(string))
-					urlValues.Add("mode", arConv["mode"].(string))
-					urlValues.Add("key", arConv["key"].(string))
-					urlValues.Add("value", arConv["value"].(string))
-					_, err := c.DecortAPICall(ctx, "POST", ComputeAntiAffinityRuleRemoveAPI, urlValues)
-					if err != nil {
-						return diag.FromErr(err)
-					}
-
-					urlValues = &url.Values{}
-				}
-			}
-			if len(addedAR) > 0 {
-				for _, ar := range addedAR {
-					arConv := ar.(map[string]interface{})
-					urlValues.Add("computeId", d.Id())
-					urlValues.Add("topology", arConv["topology"].(string))
-					urlValues.Add("policy", arConv["policy"].(string))
-					urlValues.Add("mode", arConv["mode"].(string))
-					urlValues.Add("key", arConv["key"].(string))
-					urlValues.Add("value", arConv["value"].(string))
-					_, err := c.DecortAPICall(ctx, "POST", ComputeAntiAffinityRuleAddAPI, urlValues)
-					if err != nil {
-						return diag.FromErr(err)
-					}
-
-					urlValues = &url.Values{}
-				}
-			}
+	if d.HasChange("affinity_rules") {
+		if err := utilityComputeAffinityRulesUpdate(ctx, d, "affinity_rules",
+			api.AffinityRuleAdd, api.AffinityRuleRemove, api.AffinityRulesClear); err != nil {
+			return diag.FromErr(err)
 		}
+	}
 
+	if d.HasChange("anti_affinity_rules") {
+		if err := utilityComputeAffinityRulesUpdate(ctx, d, "anti_affinity_rules",
+			api.AntiAffinityRuleAdd, api.AntiAffinityRuleRemove, api.AntiAffinityRulesClear); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if d.HasChange("tags") {
 		oldSet, newSet := d.GetChange("tags")
 		deletedTags := (oldSet.(*schema.Set).Difference(newSet.(*schema.Set))).List()
-		if len(deletedTags) > 0 {
-			for _, tagInterface := range deletedTags {
-				urlValues := &url.Values{}
-				tagItem := tagInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("key", tagItem["key"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeTagRemoveAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, tagInterface := range deletedTags {
+			tagItem := tagInterface.(map[string]interface{})
+			_, err := api.TagRemove(ctx, computesdk.TagRemoveRequest{ComputeID: computeId, Key: tagItem["key"].(string)})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		addedTags := (newSet.(*schema.Set).Difference(oldSet.(*schema.Set))).List()
-		if len(addedTags) > 0 {
-			for _, tagInterface := range addedTags {
-				urlValues := &url.Values{}
-				tagItem := tagInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("key", tagItem["key"].(string))
-				urlValues.Add("value", tagItem["value"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeTagAddAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, tagInterface := range addedTags {
+			tagItem := tagInterface.(map[string]interface{})
+			_, err := api.TagAdd(ctx, computesdk.TagAddRequest{
+				ComputeID: computeId,
+				Key:       tagItem["key"].(string),
+				Value:     tagItem["value"].(string),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -957,42 +803,41 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if d.HasChange("port_forwarding") {
 		oldSet, newSet := d.GetChange("port_forwarding")
 		deletedPfws := (oldSet.(*schema.Set).Difference(newSet.(*schema.Set))).List()
-		if len(deletedPfws) > 0 {
-			for _, pfwInterface := range deletedPfws {
-				urlValues := &url.Values{}
-				pfwItem := pfwInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("publicPortStart", strconv.Itoa(pfwItem["public_port_start"].(int)))
-				if pfwItem["public_port_end"].(int) == -1 {
-					urlValues.Add("publicPortEnd", strconv.Itoa(pfwItem["public_port_start"].(int)))
-				} else {
-					urlValues.Add("publicPortEnd", strconv.Itoa(pfwItem["public_port_end"].(int)))
-				}
-				urlValues.Add("localBasePort", strconv.Itoa(pfwItem["local_port"].(int)))
-				urlValues.Add("proto", pfwItem["proto"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputePfwDelAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, pfwInterface := range deletedPfws {
+			pfwItem := pfwInterface.(map[string]interface{})
+			portEnd := pfwItem["public_port_end"].(int)
+			if portEnd == -1 {
+				portEnd = pfwItem["public_port_start"].(int)
+			}
+			_, err := api.PFWDel(ctx, computesdk.PFWDelRequest{
+				ComputeID:       computeId,
+				PublicPortStart: uint64(pfwItem["public_port_start"].(int)),
+				PublicPortEnd:   uint64(portEnd),
+				LocalBasePort:   uint64(pfwItem["local_port"].(int)),
+				Proto:           pfwItem["proto"].(string),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		addedPfws := (newSet.(*schema.Set).Difference(oldSet.(*schema.Set))).List()
-		if len(addedPfws) > 0 {
-			for _, pfwInterface := range addedPfws {
-				urlValues := &url.Values{}
-				pfwItem := pfwInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("publicPortStart", strconv.Itoa(pfwItem["public_port_start"].(int)))
-				urlValues.Add("publicPortEnd", strconv.Itoa(pfwItem["public_port_end"].(int)))
-				urlValues.Add("localBasePort", strconv.Itoa(pfwItem["local_port"].(int)))
-				urlValues.Add("proto", pfwItem["proto"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputePfwAddAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, pfwInterface := range addedPfws {
+			pfwItem := pfwInterface.(map[string]interface{})
+			// -1 forwards the single public port
+			var portEnd uint64
+			if end := pfwItem["public_port_end"].(int); end > 0 {
+				portEnd = uint64(end)
+			}
+			_, err := api.PFWAdd(ctx, computesdk.PFWAddRequest{
+				ComputeID:       computeId,
+				PublicPortStart: uint64(pfwItem["public_port_start"].(int)),
+				PublicPortEnd:   portEnd,
+				LocalBasePort:   uint64(pfwItem["local_port"].(int)),
+				Proto:           pfwItem["proto"].(string),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -1000,33 +845,27 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if d.HasChange("user_access") {
 		oldSet, newSet := d.GetChange("user_access")
 		deletedUserAcess := (oldSet.(*schema.Set).Difference(newSet.(*schema.Set))).List()
-		if len(deletedUserAcess) > 0 {
-			for _, userAcessInterface := range deletedUserAcess {
-				urlValues := &url.Values{}
-				userAccessItem := userAcessInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("userName", userAccessItem["username"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeUserRevokeAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, userAcessInterface := range deletedUserAcess {
+			userAccessItem := userAcessInterface.(map[string]interface{})
+			_, err := api.UserRevoke(ctx, computesdk.UserRevokeRequest{
+				ComputeID: computeId,
+				Username:  userAccessItem["username"].(string),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		addedUserAccess := (newSet.(*schema.Set).Difference(oldSet.(*schema.Set))).List()
-		if len(addedUserAccess) > 0 {
-			for _, userAccessInterface := range addedUserAccess {
-				urlValues := &url.Values{}
-				userAccessItem := userAccessInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("userName", userAccessItem["username"].(string))
-				urlValues.Add("accesstype", userAccessItem["access_type"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeUserGrantAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, userAccessInterface := range addedUserAccess {
+			userAccessItem := userAccessInterface.(map[string]interface{})
+			_, err := api.UserGrant(ctx, computesdk.UserGrantRequest{
+				ComputeID:  computeId,
+				Username:   userAccessItem["username"].(string),
+				AccessType: userAccessItem["access_type"].(string),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -1034,57 +873,37 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if d.HasChange("snapshot") {
 		oldSet, newSet := d.GetChange("snapshot")
 		deletedSnapshots := (oldSet.(*schema.Set).Difference(newSet.(*schema.Set))).List()
-		if len(deletedSnapshots) > 0 {
-			for _, snapshotInterface := range deletedSnapshots {
-				urlValues := &url.Values{}
-				snapshotItem := snapshotInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("label", snapshotItem["label"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeSnapshotDeleteAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, snapshotInterface := range deletedSnapshots {
+			snapshotItem := snapshotInterface.(map[string]interface{})
+			_, err := api.SnapshotDelete(ctx, computesdk.SnapshotRequest{ComputeID: computeId, Label: snapshotItem["label"].(string)})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		addedSnapshots := (newSet.(*schema.Set).Difference(oldSet.(*schema.Set))).List()
-		if len(addedSnapshots) > 0 {
-			for _, snapshotInterface := range addedSnapshots {
-				urlValues := &url.Values{}
-				snapshotItem := snapshotInterface.(map[string]interface{})
-
-				urlValues.Add("computeId", d.Id())
-				urlValues.Add("label", snapshotItem["label"].(string))
-				_, err := c.DecortAPICall(ctx, "POST", ComputeSnapshotCreateAPI, urlValues)
-				if err != nil {
-					return diag.FromErr(err)
-				}
+		for _, snapshotInterface := range addedSnapshots {
+			snapshotItem := snapshotInterface.(map[string]interface{})
+			_, err := api.SnapshotCreate(ctx, computesdk.SnapshotRequest{ComputeID: computeId, Label: snapshotItem["label"].(string)})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	if d.HasChange("rollback") {
 		if rollback, ok := d.GetOk("rollback"); ok {
-			urlValues := &url.Values{}
-
-			//Compute must be stopped before rollback
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("force", "false")
-			_, err := c.DecortAPICall(ctx, "POST", ComputeStopAPI, urlValues)
+			// compute must be stopped for rollback, it is brought back to its power state afterwards
+			rollbackItem := rollback.(*schema.Set).List()[0].(map[string]interface{})
+			powerState, err := utilityComputeStopped(ctx, m, computeId, d.Get("force_stop").(bool), func() error {
+				_, err := api.SnapshotRollback(ctx, computesdk.SnapshotRequest{ComputeID: computeId, Label: rollbackItem["label"].(string)})
+				return err
+			})
 			if err != nil {
 				return diag.FromErr(err)
 			}
-
-			urlValues = &url.Values{}
-			rollbackInterface := rollback.(*schema.Set).List()[0]
-			rollbackItem := rollbackInterface.(map[string]interface{})
-
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("label", rollbackItem["label"].(string))
-			_, err = c.DecortAPICall(ctx, "POST", ComputeSnapshotRollbackAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
+			if powerState != powerStateStopped {
+				warnings.Add(fmt.Errorf("Compute ID %s was stopped and started to roll back to snapshot %q", d.Id(), rollbackItem["label"].(string)))
 			}
 		}
 	}
@@ -1093,23 +912,15 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		oldSet, newSet := d.GetChange("cd")
 		deletedCd := (oldSet.(*schema.Set).Difference(newSet.(*schema.Set))).List()
 		if len(deletedCd) > 0 {
-			urlValues := &url.Values{}
-
-			urlValues.Add("computeId", d.Id())
-			_, err := c.DecortAPICall(ctx, "POST", ComputeCdEjectAPI, urlValues)
-			if err != nil {
+			if _, err := api.CdEject(ctx, idReq); err != nil {
 				return diag.FromErr(err)
 			}
 		}
 
 		addedCd := (newSet.(*schema.Set).Difference(oldSet.(*schema.Set))).List()
 		if len(addedCd) > 0 {
-			urlValues := &url.Values{}
 			cdItem := addedCd[0].(map[string]interface{})
-
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("cdromId", strconv.Itoa(cdItem["cdrom_id"].(int)))
-			_, err := c.DecortAPICall(ctx, "POST", ComputeCdInsertAPI, urlValues)
+			_, err := api.CdInsert(ctx, computesdk.CdInsertRequest{ComputeID: computeId, CDROMID: uint64(cdItem["cdrom_id"].(int))})
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1117,114 +928,73 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if d.HasChange("pin_to_stack") {
-		oldPin, newPin := d.GetChange("pin_to_stack")
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		if oldPin.(bool) == true && newPin.(bool) == false {
-			_, err := c.DecortAPICall(ctx, "POST", ComputeUnpinFromStackAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+		if d.Get("pin_to_stack").(bool) {
+			_, err = api.PinToStack(ctx, idReq)
+		} else {
+			_, err = api.UnpinFromStack(ctx, idReq)
 		}
-		if oldPin.(bool) == false && newPin.(bool) == true {
-			_, err := c.DecortAPICall(ctx, "POST", ComputePinToStackAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+		if err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	if d.HasChange("pause") {
-		oldPause, newPause := d.GetChange("pause")
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		if oldPause.(bool) == true && newPause.(bool) == false {
-			_, err := c.DecortAPICall(ctx, "POST", ComputeResumeAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+	// redeploy, compute is stopped for it and brought back to its power state afterwards
+	if d.HasChange("image_id") {
+		oldImage, newImage := d.GetChange("image_id")
+		log.Debugf(ctx, "resourceComputeUpdate: redeploying Compute ID %s from image ID %d to image ID %d", d.Id(), oldImage.(int), newImage.(int))
+		powerState, err := utilityComputeStopped(ctx, m, computeId, d.Get("force_stop").(bool), func() error {
+			_, err := api.Redeploy(ctx, computesdk.RedeployRequest{
+				ComputeID: computeId,
+				ImageID:   uint64(newImage.(int)),
+				DiskSize:  uint64(d.Get("boot_disk_size").(int)),
+				DataDisks: d.Get("data_disks").(string),
+				ForceStop: d.Get("force_stop").(bool),
+			})
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		if oldPause.(bool) == false && newPause.(bool) == true {
-			_, err := c.DecortAPICall(ctx, "POST", ComputePauseAPI, urlValues)
-			if err != nil {
+		if powerState == powerStateStopped && d.Get("auto_start").(bool) && utilityComputeTargetPowerState(d, false) == "" {
+			log.Debugf(ctx, "resourceComputeUpdate: starting redeployed Compute ID %s", d.Id())
+			if err := utilityComputeSetPowerState(ctx, d, m, powerStateRunning); err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	}
 
-	if d.HasChange("reset") {
-		oldReset, newReset := d.GetChange("reset")
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		if oldReset.(bool) == false && newReset.(bool) == true {
-			_, err := c.DecortAPICall(ctx, "POST", ComputeResetAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+	// 5. Start/stop, after all changes that need compute stopped are applied
+	if powerState := utilityComputeTargetPowerState(d, false); powerState != "" {
+		log.Debugf(ctx, "resourceComputeUpdate: bringing Compute ID %s to power state %s", d.Id(), powerState)
+		if err := utilityComputeSetPowerState(ctx, d, m, powerState); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	//redeploy
-	if d.HasChange("image_id") {
-		oldImage, newImage := d.GetChange("image_id")
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		urlValues.Add("force", "false")
-		_, err := c.DecortAPICall(ctx, "POST", ComputeStopAPI, urlValues)
-		if err != nil {
+	if d.HasChange("rescue_mode") {
+		if err := utilityComputeRescueModeConfigure(ctx, d, m); err != nil {
 			return diag.FromErr(err)
 		}
-
-		if oldImage.(int) != newImage.(int) {
-			urlValues := &url.Values{}
-
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("imageId", strconv.Itoa(newImage.(int)))
-			if diskSize, ok := d.GetOk("boot_disk_size"); ok {
-				urlValues.Add("diskSize", strconv.Itoa(diskSize.(int)))
-			}
-			if dataDisks, ok := d.GetOk("data_disks"); ok {
-				urlValues.Add("dataDisks", dataDisks.(string))
-			}
-			if autoStart, ok := d.GetOk("auto_start"); ok {
-				urlValues.Add("autoStart", strconv.FormatBool(autoStart.(bool)))
-			}
-			if forceStop, ok := d.GetOk("force_stop"); ok {
-				urlValues.Add("forceStop", strconv.FormatBool(forceStop.(bool)))
-			}
-			_, err := c.DecortAPICall(ctx, "POST", ComputeRedeployAPI, urlValues)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		}
 	}
 
-	// we may reuse dataSourceComputeRead here as we maintain similarity
-	// between Compute resource and Compute data source schemas
-	return resourceComputeRead(ctx, d, m)
-}
-
-func isChangeDisk(els []interface{}, el interface{}) bool {
-	for _, elOld := range els {
-		elOldConv := elOld.(map[string]interface{})
-		elConv := el.(map[string]interface{})
-		if elOldConv["disk_id"].(int) == elConv["disk_id"].(int) &&
-			elOldConv["size"].(int) != elConv["size"].(int) {
-			return true
+	if d.HasChange("enabled") && !d.Get("enabled").(bool) {
+		log.Debugf(ctx, "resourceComputeUpdate: disabling Compute ID %s", d.Id())
+		if _, err := api.Disable(ctx, idReq); err != nil {
+			return diag.FromErr(err)
 		}
 	}
-	return false
-}
 
-func isContainsDisk(els []interface{}, el interface{}) bool {
-	for _, elOld := range els {
-		elOldConv := elOld.(map[string]interface{})
-		elConv := el.(map[string]interface{})
-		if elOldConv["disk_name"].(string) == elConv["disk_name"].(string) {
-			return true
+	// reset is an action rather than a state, so that it is performed on every change of the trigger
+	oldReset, newReset := d.GetChange("reset")
+	if (d.HasChange("reset_trigger") && d.Get("reset_trigger").(string) != "") || (!oldReset.(bool) && newReset.(bool)) {
+		if err := utilityComputeReset(ctx, m, computeId); err != nil {
+			return diag.FromErr(err)
 		}
 	}
-	return false
+
+	// we may reuse dataSourceComputeRead here as we maintain similarity
+	// between Compute resource and Compute data source schemas
+	return append(warnings.Get(), resourceComputeRead(ctx, d, m)...)
 }
 
 func isContainsAR(els []interface{}, el interface{}) bool {
@@ -1247,17 +1017,16 @@ func resourceComputeDelete(ctx context.Context, d *schema.ResourceData, m interf
 	// there is no way to restore it.
 	// If compute being destroyed has some extra disks attached, they are
 	// detached from the compute
-	log.Debugf("resourceComputeDelete: called for Compute name %s, RG ID %d",
+	log.Debugf(ctx, "resourceComputeDelete: called for Compute name %s, RG ID %d",
 		d.Get("name").(string), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
-
-	params := &url.Values{}
-	params.Add("computeId", d.Id())
-	params.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
-	params.Add("detachDisks", strconv.FormatBool(d.Get("detach_disks").(bool)))
-
-	if _, err := c.DecortAPICall(ctx, "POST", ComputeDeleteAPI, params); err != nil {
+	computeId, _ := strconv.ParseUint(d.Id(), 10, 64)
+	_, err := sdk.New(m.(controller.APICaller)).Compute().Delete(ctx, computesdk.DeleteRequest{
+		ComputeID:   computeId,
+		Permanently: d.Get("permanently").(bool),
+		DetachDisks: d.Get("detach_disks").(bool),
+	})
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -1422,9 +1191,8 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 		"rg_id": {
 			Type:         schema.TypeInt,
 			Required:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.IntAtLeast(1),
-			Description:  "ID of the resource group where this compute should be deployed.",
+			Description:  "ID of the resource group where this compute should be deployed. Changing it moves the compute to another resource group of the same account in place; running compute is stopped for the move and started again.",
 		},
 
 		"driver": {
@@ -1451,10 +1219,45 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 		},
 
 		"image_id": {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"image_id", "clone_from"},
 			//ForceNew:    true, //REDEPLOY
-			Description: "ID of the OS image to base this compute instance on.",
+			Description: "ID of the OS image to base this compute instance on. Either image_id or clone_from must be specified.",
+		},
+
+		"clone_from": {
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"compute_id": {
+						Type:        schema.TypeInt,
+						Required:    true,
+						Description: "ID of the source compute. Clone is created in its resource group, which must match rg_id.",
+					},
+					"snapshot_timestamp": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						Description: "Timestamp of the source compute snapshot to clone. Current state of the source is cloned if neither snapshot_timestamp nor snapshot_name is set.",
+					},
+					"snapshot_name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Label of the source compute snapshot to clone.",
+					},
+					"force": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Allow to clone running source compute.",
+					},
+				},
+			},
+			Description: "Create this compute as a clone of existing compute instead of creating it from image. CPU, RAM and boot disk of the clone are resized to the configured values, networks, disks and other settings are applied as for a new compute. Network interfaces inherited from the source are detached, if network blocks are configured, and kept and read back into network otherwise. cloud_init and cloud_config are ignored for clones.",
 		},
 
 		"boot_disk_size": {
@@ -1547,9 +1350,11 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 		"disks": {
 			Type:     schema.TypeList,
 			Optional: true,
+			Computed: true, // only disks created by this resource are reported, see flattenComputeDisksDemo
 			Elem: &schema.Resource{
 				Schema: disksSubresourceSchemaMake(),
 			},
+			Description: "Optional data disks to create and attach to this compute, in the listed order. Disks are matched by name: removed disks are deleted (running compute is stopped and started again for that), new disks are created and resized disks are grown. Only disks created by this resource are tracked, so disks of extra_disks, decort_kvmvm_disk_attachment resources or disks attached outside of Terraform are neither reported nor deleted. Data disks of imported compute are not tracked.",
 		},
 
 		"sep_id": {
@@ -1575,32 +1380,21 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeInt,
 			},
-			Description: "Optional list of IDs of extra disks to attach to this compute. You may specify several extra disks.",
+			Description: "Optional list of IDs of extra disks to attach to this compute. You may specify several extra disks. Running compute is stopped and started again to detach disks.",
 		},
 
 		"network": {
 			Type:     schema.TypeSet,
 			Optional: true,
+			Computed: true, // interfaces may be managed by decort_kvmvm_network_interface resources instead
 			MinItems: 1,
 			MaxItems: constants.MaxNetworksPerCompute,
 			Elem: &schema.Resource{
 				Schema: networkSubresourceSchemaMake(),
 			},
-			Description: "Optional network connection(s) for this compute. You may specify several network blocks, one for each connection.",
+			Description: "Optional network connection(s) for this compute. You may specify several network blocks, one for each connection. Removing some blocks detaches their interfaces, but removing all of them leaves the interfaces intact and only reads them back, so that they may be managed by decort_kvmvm_network_interface resources.",
 		},
 
-		/*
-			"ssh_keys": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: MaxSshKeysPerCompute,
-				Elem: &schema.Resource{
-					Schema: sshSubresourceSchemaMake(),
-				},
-				Description: "SSH keys to authorize on this compute instance.",
-			},
-		*/
-
 		"tags": {
 			Type:     schema.TypeSet,
 			Optional: true,
@@ -1651,6 +1445,36 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 			},
 		},
 
+		"vgpu": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: vgpuSubresourceSchemaMake(),
+			},
+			Description: "vGPU(s) attached to this compute. Running or paused compute is stopped to change vGPUs and then brought back to its power state, even if the change fails. When vgpu blocks are omitted, vGPUs attached outside of this resource are left intact.",
+		},
+
+		"pci_device": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: pciDeviceSubresourceSchemaMake(),
+			},
+			Description: "PCI device(s) passed through to this compute. Running or paused compute is stopped to change PCI devices and then brought back to its power state, even if the change fails. Attached devices are only read back while pci_device blocks are present. When pci_device blocks are omitted, devices attached outside of this resource are left intact.",
+		},
+
+		"rescue_mode": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: rescueModeSubresourceSchemaMake(),
+			},
+			Description: "Run this compute from CD-ROM image, e.g. to install OS or to recover broken compute. Rescue mode lasts while this block is present: compute is restarted from the image when the block is added or changed, and the image stays inserted, so that the installer or rescue system may read it. The image is used for one boot only, so that compute restarted from within the guest or stopped and started by other changes boots from its boot devices, while the image stays inserted. Removing the block restarts running compute from its boot devices and ejects the image, unless it is held by cd block. Requires power_state running.",
+		},
+
 		"pin_to_stack": {
 			Type:     schema.TypeBool,
 			Optional: true,
@@ -1664,41 +1488,82 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 		},
 
 		"cloud_init": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Optional cloud_init parameters. Applied when creating new compute instance only, ignored in all other cases.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: cloudInitDiffSuppress,
+			Description:      "Optional cloud_init parameters. Applied when creating new compute instance only, ignored in all other cases. When cloud_config is set, it must be a #cloud-config document, which is merged with cloud_config.",
+		},
+
+		"cloud_config": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: cloudinit.SchemaMake(),
+			},
+			Description: "Optional cloud-init settings rendered into #cloud-config userdata. Lists are appended to the same keys of cloud_init document. Applied when creating new compute instance only, ignored in all other cases.",
 		},
 
 		"enabled": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Computed:    true,
-			Description: "If true - enable compute, else - disable",
+			Description: "If true - enable compute, else - disable. Compute is enabled before it is started and disabled after it is stopped, so that disabled compute must have power_state stopped.",
+		},
+
+		"power_state": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.StringInSlice([]string{powerStateRunning, powerStateStopped, powerStatePaused}, false),
+			ConflictsWith: []string{"started", "pause"},
+			Description:   "Power state of this compute: running, stopped or paused. Provider waits for the compute to reach this state on create and update.",
 		},
 
 		"pause": {
-			Type:     schema.TypeBool,
-			Optional: true,
-			Default:  false,
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Deprecated:  "use power_state instead",
+			Description: "Pause compute.",
 		},
 
 		"reset": {
-			Type:     schema.TypeBool,
-			Optional: true,
-			Default:  false,
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Deprecated:  "use reset_trigger instead",
+			Description: "Reset compute, when changed from false to true.",
+		},
+
+		"reset_trigger": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Arbitrary value, e.g. a timestamp. Any change of it to a non-empty value resets the compute, as if it was powered off and on, and waits for it to be running again. Compute must have power_state running. Compute is not reset on create.",
 		},
 
 		"auto_start": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
-			Description: "Flag for redeploy compute",
+			Description: "Start compute after redeploy on image_id change, even if it was stopped. Running or paused compute is always brought back to its power state after redeploy. Ignored, if power_state is changed along with image_id.",
 		},
 		"force_stop": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
-			Description: "Flag for redeploy compute",
+			Description: "Power off compute instead of graceful shutdown whenever this resource stops it: on redeploy, on snapshot rollback, on restart for resize, on moving to another resource group, on deleting data disks and on changing power_state to stopped.",
+		},
+		"allow_restart_for_resize": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allow to stop and start running compute to change its CPU/RAM, when its image does not support hot resize or CPU/RAM are reduced. If false, such changes fail at plan time.",
+		},
+		"resize_mode": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "How the last CPU/RAM change is applied: 'hot' for running compute with image supporting hot resize, 'restart' if compute is stopped and started for resize, 'cold' for stopped compute.",
 		},
 		"data_disks": {
 			Type:         schema.TypeString,
@@ -1711,6 +1576,7 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Computed:    true,
+			Deprecated:  "use power_state instead",
 			Description: "Is compute started.",
 		},
 		"detach_disks": {
@@ -1755,10 +1621,13 @@ func ResourceComputeSchemaMake() map[string]*schema.Schema {
 		},
 		"boot_order": {
 			Type:     schema.TypeList,
+			Optional: true,
 			Computed: true,
 			Elem: &schema.Schema{
-				Type: schema.TypeString,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"hd", "cdrom", "network"}, false),
 			},
+			Description: "Order of boot devices of this compute: hd, cdrom or network. Left as set by the platform, if not specified.",
 		},
 		"boot_disk_id": {
 			Type:        schema.TypeInt,
@@ -1962,6 +1831,11 @@ func ResourceCompute() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
 
+		Description: "Compute instance (virtual machine). Its network interfaces are managed either by network blocks " +
+			"or by decort_kvmvm_network_interface resources, not both: while network blocks are configured, they list " +
+			"all interfaces of the compute, and interfaces attached by decort_kvmvm_network_interface are detached " +
+			"on the next apply. Omit network blocks to manage interfaces with decort_kvmvm_network_interface.",
+
 		CreateContext: resourceComputeCreate,
 		ReadContext:   resourceComputeRead,
 		UpdateContext: resourceComputeUpdate,
@@ -1979,6 +1853,8 @@ func ResourceCompute() *schema.Resource {
 			Default: &constants.Timeout300s,
 		},
 
+		CustomizeDiff: resourceComputeCustomizeDiff,
+
 		Schema: ResourceComputeSchemaMake(),
 	}
 }
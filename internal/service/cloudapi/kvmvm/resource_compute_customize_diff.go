@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/cloudinit"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
+)
+
+// resourceComputeCustomizeDiff validates compute configuration at plan time, so that
+// errors are reported before a compute is half-created and then rolled back by Create
+func resourceComputeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	log.Debugf(ctx, "resourceComputeCustomizeDiff: called for Compute ID %q / name %q", d.Id(), d.Get("name").(string))
+
+	if d.NewValueKnown("cpu") {
+		if cpu := d.Get("cpu").(int); cpu < 1 || cpu > constants.MaxCpusPerCompute {
+			return fmt.Errorf("cpu must be between 1 and %d, got %d", constants.MaxCpusPerCompute, cpu)
+		}
+	}
+
+	if d.NewValueKnown("ram") {
+		if ram := d.Get("ram").(int); ram < constants.MinRamPerCompute {
+			return fmt.Errorf("ram must be at least %d MB, got %d", constants.MinRamPerCompute, ram)
+		}
+	}
+
+	if d.NewValueKnown("network") {
+		if count := d.Get("network").(*schema.Set).Len(); count > constants.MaxNetworksPerCompute {
+			return fmt.Errorf("at most %d network blocks may be specified, got %d", constants.MaxNetworksPerCompute, count)
+		}
+	}
+
+	if d.NewValueKnown("extra_disks") {
+		if count := d.Get("extra_disks").(*schema.Set).Len(); count > constants.MaxExtraDisksPerCompute {
+			return fmt.Errorf("at most %d extra disks may be attached, got %d", constants.MaxExtraDisksPerCompute, count)
+		}
+	}
+
+	// userdata is only passed on create, so that it is rendered there to report invalid documents early
+	if d.Id() == "" && d.NewValueKnown("cloud_init") && d.NewValueKnown("cloud_config") {
+		if _, err := cloudinit.Userdata(d.Get("cloud_init").(string), d.Get("cloud_config")); err != nil {
+			return err
+		}
+	}
+
+	// compute in rescue mode runs from CD-ROM image, so that other power states contradict it,
+	// as well as another image inserted by cd block
+	if rescueMode, ok := d.GetOk("rescue_mode"); ok {
+		if powerState := powerstate.Configured(d); powerState != "" && powerState != powerStateRunning {
+			return fmt.Errorf("rescue_mode requires power state %s, got %s", powerStateRunning, powerState)
+		}
+		cdromId := rescueMode.([]interface{})[0].(map[string]interface{})["cdrom_id"].(int)
+		for _, cd := range d.Get("cd").(*schema.Set).List() {
+			if cdId := cd.(map[string]interface{})["cdrom_id"].(int); cdId != cdromId {
+				return fmt.Errorf("rescue_mode runs compute from CD-ROM image ID %d, while cd block inserts image ID %d", cdromId, cdId)
+			}
+		}
+	}
+
+	// power state changes are ordered by Update, so that only combinations it cannot satisfy are rejected
+	if powerState := powerstate.Configured(d); powerState != "" {
+		if !d.Get("enabled").(bool) && d.NewValueKnown("enabled") && powerState != powerStateStopped {
+			return fmt.Errorf("disabled compute must be %s, got power state %s", powerStateStopped, powerState)
+		}
+
+		if d.Id() != "" && powerState != powerStateRunning {
+			oldReset, newReset := d.GetChange("reset")
+			if (d.HasChange("reset_trigger") && d.Get("reset_trigger").(string) != "") || (!oldReset.(bool) && newReset.(bool)) {
+				return fmt.Errorf("only running compute can be reset, got power state %s", powerState)
+			}
+			if d.HasChange("image_id") && d.Get("auto_start").(bool) {
+				return fmt.Errorf("auto_start starts compute after redeploy, while power state %s is configured", powerState)
+			}
+		}
+	}
+
+	if d.Id() != "" {
+		// boot disk can only grow: Update silently ignores a smaller size, so reject it here
+		if d.HasChange("boot_disk_size") && d.NewValueKnown("boot_disk_size") {
+			oldSize, newSize := d.GetChange("boot_disk_size")
+			if newSize.(int) < oldSize.(int) {
+				return fmt.Errorf("boot_disk_size cannot be decreased from %d to %d GB", oldSize.(int), newSize.(int))
+			}
+		}
+
+		// resize mode depends on actual state of the compute, so that it is decided and shown in the plan
+		// rather than found out by a failing apply. resize_mode restart tells that the compute will be
+		// stopped and started.
+		if d.HasChanges("cpu", "ram") && d.NewValueKnown("cpu") && d.NewValueKnown("ram") {
+			computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+			if err != nil {
+				return err
+			}
+			oldCpu, newCpu := d.GetChange("cpu")
+			oldRam, newRam := d.GetChange("ram")
+			resizeMode, err := utilityComputeResizeMode(ctx, m, computeId,
+				oldCpu.(int), newCpu.(int), oldRam.(int), newRam.(int),
+				d.Get("allow_restart_for_resize").(bool))
+			if err != nil {
+				return err
+			}
+			if err := d.SetNew("resize_mode", resizeMode); err != nil {
+				return err
+			}
+		}
+	}
+
+	// existence checks call the API, so they are only run for new or changed values
+	if d.HasChange("rg_id") && d.NewValueKnown("rg_id") {
+		haveRGID, err := existRgID(ctx, d, m)
+		if err != nil {
+			return err
+		}
+		if !haveRGID {
+			return fmt.Errorf("rgID %d not allowed or does not exist", d.Get("rg_id").(int))
+		}
+	}
+
+	if d.HasChange("image_id") && d.NewValueKnown("image_id") {
+		haveImageID, err := existImageId(ctx, d, m)
+		if err != nil {
+			return err
+		}
+		if !haveImageID {
+			return fmt.Errorf("imageID %d not allowed or does not exist", d.Get("image_id").(int))
+		}
+	}
+
+	if d.HasChange("vgpu") && d.NewValueKnown("vgpu") && d.NewValueKnown("rg_id") {
+		if err := utilityComputeVGPUsCheck(ctx, d, m); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("network") {
+		vinsId, ok, err := existVinsId(ctx, d, m)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("vins ID %d not allowed or does not exist", vinsId)
+		}
+		extNetId, ok, err := existExtNetId(ctx, d, m)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("extnet ID %d not allowed or does not exist", extNetId)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+)
+
+func resourceComputeDiskAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	computeId := uint64(d.Get("compute_id").(int))
+	diskId := uint64(d.Get("disk_id").(int))
+	log.Debugf(ctx, "resourceComputeDiskAttachmentCreate: attach disk ID %d to Compute ID %d", diskId, computeId)
+
+	_, err := sdk.New(m.(controller.APICaller)).Compute().DiskAttach(ctx, computesdk.DiskRequest{
+		ComputeID: computeId,
+		DiskID:    diskId,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(utilityComputeDiskAttachmentMakeId(computeId, diskId))
+
+	return resourceComputeDiskAttachmentRead(ctx, d, m)
+}
+
+func resourceComputeDiskAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceComputeDiskAttachmentRead: ID %s", d.Id())
+
+	disk, diags := utilityComputeDiskAttachmentCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if disk == nil {
+		log.Warnf(ctx, "resourceComputeDiskAttachmentRead: disk attachment %s was removed outside of Terraform", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	computeId, _, _ := utilityComputeDiskAttachmentParseId(d.Id())
+	d.Set("compute_id", computeId)
+	d.Set("disk_id", disk.ID)
+	d.Set("disk_name", disk.Name)
+	d.Set("pci_slot", disk.PCISlot)
+	d.Set("device_name", utilityComputeDiskDeviceName(disk.PCISlot))
+
+	return nil
+}
+
+func resourceComputeDiskAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// only detach policy may be changed, it is applied on delete
+	return resourceComputeDiskAttachmentRead(ctx, d, m)
+}
+
+func resourceComputeDiskAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceComputeDiskAttachmentDelete: ID %s", d.Id())
+
+	disk, diags := utilityComputeDiskAttachmentCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if disk == nil {
+		d.SetId("")
+		return nil
+	}
+
+	computeId, diskId, _ := utilityComputeDiskAttachmentParseId(d.Id())
+	err := utilityComputeDetachDisks(ctx, m, computeId, []uint64{diskId}, d.Get("hot_detach").(bool), d.Get("stop_on_detach").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeDiskAttachmentSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"compute_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the compute instance to attach disk to.",
+		},
+		"disk_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the disk to attach, e.g. of decort_disk resource.",
+		},
+		"hot_detach": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Detach disk from running compute without stopping it. Guest OS should support PCI hot unplug.",
+		},
+		"stop_on_detach": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Allow to stop running compute to detach disk, if hot_detach is not set. Compute is started again after detach. If false, detaching disk from running compute fails.",
+		},
+		"disk_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the attached disk.",
+		},
+		"pci_slot": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "PCI slot of the disk in the compute.",
+		},
+		"device_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Stable device path of the disk in guest OS, derived from PCI slot (e.g. for cloud-init disk_setup).",
+		},
+	}
+}
+
+func ResourceComputeDiskAttachment() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 1,
+
+		CreateContext: resourceComputeDiskAttachmentCreate,
+		ReadContext:   resourceComputeDiskAttachmentRead,
+		UpdateContext: resourceComputeDiskAttachmentUpdate,
+		DeleteContext: resourceComputeDiskAttachmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &constants.Timeout300s,
+			Read:    &constants.Timeout30s,
+			Update:  &constants.Timeout300s,
+			Delete:  &constants.Timeout300s,
+			Default: &constants.Timeout300s,
+		},
+
+		Schema: resourceComputeDiskAttachmentSchemaMake(),
+	}
+}
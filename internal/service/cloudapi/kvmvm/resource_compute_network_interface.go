@@ -0,0 +1,261 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/statefuncs"
+)
+
+func resourceComputeNetworkInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	computeId := uint64(d.Get("compute_id").(int))
+	netType := strings.ToUpper(d.Get("net_type").(string))
+	netId := d.Get("net_id").(int)
+	log.Debugf(ctx, "resourceComputeNetworkInterfaceCreate: attach %s ID %d to Compute ID %d", netType, netId, computeId)
+
+	haveNet, err := existNetId(ctx, m, netType, netId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !haveNet {
+		return diag.Errorf("resourceComputeNetworkInterfaceCreate: can't attach network because %s ID %d not allowed or does not exist", netType, netId)
+	}
+
+	computeAPI := sdk.New(m.(controller.APICaller)).Compute()
+	compute, err := computeAPI.Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	known := make(map[string]bool, len(compute.Interfaces))
+	for _, iface := range compute.Interfaces {
+		known[strings.ToLower(iface.MAC)] = true
+	}
+
+	attached, err := computeAPI.NetAttach(ctx, computesdk.NetAttachRequest{
+		ComputeID: computeId,
+		NetType:   netType,
+		NetID:     uint64(netId),
+		IPAddr:    d.Get("ip_address").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	compute, err = computeAPI.Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	iface := findAttachedInterface(compute.Interfaces, attached, known, netType, uint64(netId))
+	if iface == nil {
+		return diag.Errorf("resourceComputeNetworkInterfaceCreate: %s ID %d was attached to Compute ID %d, but its interface was not found", netType, netId, computeId)
+	}
+
+	d.SetId(utilityComputeNetworkInterfaceMakeId(computeId, strings.ToLower(iface.MAC)))
+	log.Debugf(ctx, "resourceComputeNetworkInterfaceCreate: attached interface %s", d.Id())
+
+	return resourceComputeNetworkInterfaceRead(ctx, d, m)
+}
+
+func resourceComputeNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceComputeNetworkInterfaceRead: ID %s", d.Id())
+
+	iface, diags := utilityComputeNetworkInterfaceCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if iface == nil {
+		log.Warnf(ctx, "resourceComputeNetworkInterfaceRead: interface %s was detached outside of Terraform", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	computeId, _, _ := utilityComputeNetworkInterfaceParseId(d.Id())
+	d.Set("compute_id", computeId)
+	d.Set("net_type", iface.NetType)
+	d.Set("net_id", iface.NetID)
+	d.Set("ip_address", iface.IPAddress)
+	d.Set("mac", iface.MAC)
+	d.Set("name", iface.Name)
+	d.Set("net_mask", iface.NetMask)
+	d.Set("def_gw", iface.DefGW)
+	d.Set("conn_id", iface.ConnID)
+	d.Set("conn_type", iface.ConnType)
+	d.Set("pci_slot", iface.PCISlot)
+
+	return nil
+}
+
+func resourceComputeNetworkInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceComputeNetworkInterfaceUpdate: ID %s", d.Id())
+
+	// IP address is changed in place, so the interface keeps its MAC and PCI slot
+	if d.HasChange("ip_address") && d.Get("ip_address").(string) != "" {
+		_, err := sdk.New(m.(controller.APICaller)).Compute().ChangeIP(ctx, computesdk.ChangeIPRequest{
+			ComputeID: uint64(d.Get("compute_id").(int)),
+			NetType:   strings.ToUpper(d.Get("net_type").(string)),
+			NetID:     uint64(d.Get("net_id").(int)),
+			IPAddr:    d.Get("ip_address").(string),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceComputeNetworkInterfaceRead(ctx, d, m)
+}
+
+func resourceComputeNetworkInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceComputeNetworkInterfaceDelete: ID %s", d.Id())
+
+	iface, diags := utilityComputeNetworkInterfaceCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if iface == nil {
+		d.SetId("")
+		return nil
+	}
+
+	computeId, mac, _ := utilityComputeNetworkInterfaceParseId(d.Id())
+	_, err := sdk.New(m.(controller.APICaller)).Compute().NetDetach(ctx, computesdk.NetDetachRequest{
+		ComputeID: computeId,
+		IPAddr:    iface.IPAddress,
+		MAC:       mac,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceComputeNetworkInterfaceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"compute_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the compute instance to attach network interface to.",
+		},
+		"net_type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			StateFunc:    statefuncs.StateFuncToUpper,
+			ValidateFunc: validation.StringInSlice([]string{"EXTNET", "VINS"}, false), // observe case while validating
+			Description:  "Type of the network for this connection, either EXTNET or VINS.",
+		},
+		"net_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the network for this connection.",
+		},
+		"ip_address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IP address to assign to this connection. It is allocated automatically if not set. Changing it does not detach the interface.",
+		},
+		"mac": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "MAC address of this connection. It is assigned automatically and identifies the interface.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the interface inside the compute.",
+		},
+		"net_mask": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"def_gw": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"conn_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"conn_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"pci_slot": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func ResourceComputeNetworkInterface() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 1,
+
+		Description: "Network interface of a compute instance, attached and detached independently of decort_kvmvm. " +
+			"The compute must be configured without network blocks, otherwise the interface is detached on the next " +
+			"apply of decort_kvmvm.",
+
+		CreateContext: resourceComputeNetworkInterfaceCreate,
+		ReadContext:   resourceComputeNetworkInterfaceRead,
+		UpdateContext: resourceComputeNetworkInterfaceUpdate,
+		DeleteContext: resourceComputeNetworkInterfaceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &constants.Timeout300s,
+			Read:    &constants.Timeout30s,
+			Update:  &constants.Timeout300s,
+			Delete:  &constants.Timeout300s,
+			Default: &constants.Timeout300s,
+		},
+
+		Schema: resourceComputeNetworkInterfaceSchemaMake(),
+	}
+}
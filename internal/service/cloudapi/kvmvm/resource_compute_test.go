@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/acctest"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/kvmvm"
+)
+
+const testComputeConfig = `
+resource "decort_resgroup" "rg" {
+  account_id = %d
+  gid        = %d
+  name       = "rg-test"
+}
+
+resource "decort_kvmvm" "vm" {
+  rg_id          = decort_resgroup.rg.id
+  name           = "vm-test"
+  driver         = "KVM_X86"
+  cpu            = %d
+  ram            = %d
+  image_id       = %d
+  boot_disk_size = 10
+  description    = %q
+}
+`
+
+func TestResourceCompute(t *testing.T) {
+	s := acctest.NewServer(t)
+	config := func(cpu, ram int, desc string) string {
+		return acctest.Config(s, testComputeConfig, fake.DefaultAccountID, fake.DefaultGridID,
+			cpu, ram, fake.DefaultImageID, desc)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckDestroyed(s, fake.KindCompute, "decort_kvmvm"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(1, 1024, "created"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_kvmvm.vm", "cpu", "1"),
+					resource.TestCheckResourceAttr("decort_kvmvm.vm", "ram", "1024"),
+					resource.TestCheckResourceAttr("decort_kvmvm.vm", "boot_disk_size", "10"),
+					resource.TestCheckResourceAttrSet("decort_kvmvm.vm", "boot_disk_id"),
+					acctest.CheckObject(s, fake.KindCompute, "decort_kvmvm.vm", "status", "ENABLED"),
+				),
+			},
+			{
+				Config: config(2, 2048, "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_kvmvm.vm", "cpu", "2"),
+					resource.TestCheckResourceAttr("decort_kvmvm.vm", "ram", "2048"),
+					acctest.CheckObject(s, fake.KindCompute, "decort_kvmvm.vm", "cpus", 2),
+					acctest.CheckObject(s, fake.KindCompute, "decort_kvmvm.vm", "ram", 2048),
+					acctest.CheckObject(s, fake.KindCompute, "decort_kvmvm.vm", "desc", "updated"),
+				),
+			},
+			{
+				ResourceName:      "decort_kvmvm.vm",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// arguments, which only control behaviour of the provider and are not returned by the platform
+				ImportStateVerifyIgnore: []string{
+					"allow_restart_for_resize", "auto_start", "data_disks", "detach_disks", "force_stop",
+					"pause", "permanently", "pin_to_stack", "reset", "resize_mode",
+				},
+			},
+		},
+	})
+}
+
+// TestResourceComputeHandlers runs the steps of TestResourceCompute through the resource
+// handlers, so that they are covered without Terraform CLI
+func TestResourceComputeHandlers(t *testing.T) {
+	s := acctest.NewServer(t)
+	m := s.Client()
+	ctx := context.Background()
+	r := kvmvm.ResourceCompute()
+
+	config := map[string]interface{}{
+		"rg_id":          acctest.NewRG(t, s),
+		"name":           "vm-test",
+		"driver":         "KVM_X86",
+		"cpu":            1,
+		"ram":            1024,
+		"image_id":       fake.DefaultImageID,
+		"boot_disk_size": 10,
+		"description":    "created",
+	}
+	d := acctest.ResourceData(t, r, nil, config, m)
+	if diags := r.CreateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("CreateContext() = %v", diags)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		t.Fatalf("resource ID %q: %v", d.Id(), err)
+	}
+	if obj, ok := s.Get(fake.KindCompute, id); !ok || obj["status"] != "ENABLED" {
+		t.Fatalf("compute %d = %v, want ENABLED", id, obj)
+	}
+	if d.Get("boot_disk_size") != 10 || d.Get("boot_disk_id") == 0 {
+		t.Errorf("boot_disk_size = %v, boot_disk_id = %v, want 10 and boot disk set", d.Get("boot_disk_size"), d.Get("boot_disk_id"))
+	}
+
+	config["cpu"] = 2
+	config["ram"] = 2048
+	config["description"] = "updated"
+	d = acctest.ResourceData(t, r, d, config, m)
+	if diags := r.UpdateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("UpdateContext() = %v", diags)
+	}
+	if obj, _ := s.Get(fake.KindCompute, id); obj["cpus"] != 2 || obj["ram"] != 2048 || obj["desc"] != "updated" {
+		t.Errorf("compute cpus = %v, ram = %v, desc = %v, want 2, 2048, updated", obj["cpus"], obj["ram"], obj["desc"])
+	}
+
+	imported := acctest.Imported(r, d.Id())
+	if diags := r.ReadContext(ctx, imported, m); diags.HasError() {
+		t.Fatalf("ReadContext() = %v", diags)
+	}
+	for _, key := range []string{"name", "rg_id", "driver", "cpu", "ram", "image_id", "boot_disk_size", "description"} {
+		if got, want := imported.Get(key), d.Get(key); got != want {
+			t.Errorf("imported %s = %v, want %v", key, got, want)
+		}
+	}
+
+	if diags := r.DeleteContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("DeleteContext() = %v", diags)
+	}
+	if obj, ok := s.Get(fake.KindCompute, id); ok && obj["status"] != "DELETED" && obj["status"] != "DESTROYED" {
+		t.Errorf("compute status = %v after delete", obj["status"])
+	}
+}
+
+// TestResourceComputeCloneUpdate checks that clone is updated after the image, which it inherited
+// from the source compute, is removed
+func TestResourceComputeCloneUpdate(t *testing.T) {
+	s := acctest.NewServer(t)
+	m := s.Client()
+	ctx := context.Background()
+	r := kvmvm.ResourceCompute()
+
+	imageId := s.NewID()
+	s.Put(fake.KindImage, imageId, fake.Object{"id": imageId, "name": "golden", "status": "CREATED", "hotResize": true})
+
+	rgId := acctest.NewRG(t, s)
+	source := acctest.ResourceData(t, r, nil, map[string]interface{}{
+		"rg_id":    rgId,
+		"name":     "vm-source",
+		"driver":   "KVM_X86",
+		"cpu":      1,
+		"ram":      1024,
+		"image_id": imageId,
+	}, m)
+	if diags := r.CreateContext(ctx, source, m); diags.HasError() {
+		t.Fatalf("CreateContext() of source = %v", diags)
+	}
+	sourceId, _ := strconv.Atoi(source.Id())
+
+	config := map[string]interface{}{
+		"rg_id":       rgId,
+		"name":        "vm-clone",
+		"driver":      "KVM_X86",
+		"cpu":         1,
+		"ram":         1024,
+		"clone_from":  []interface{}{map[string]interface{}{"compute_id": sourceId, "force": true}},
+		"description": "created",
+	}
+	d := acctest.ResourceData(t, r, nil, config, m)
+	if diags := r.CreateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("CreateContext() of clone = %v", diags)
+	}
+	if got := d.Get("image_id"); got != imageId {
+		t.Fatalf("clone image_id = %v, want %d inherited from source", got, imageId)
+	}
+
+	s.Delete(fake.KindImage, imageId)
+	// new client does not share lookup cache, which still holds the image, as in a later run
+	m = s.Client()
+	config["description"] = "updated"
+	d = acctest.ResourceData(t, r, d, config, m)
+	if diags := r.UpdateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("UpdateContext() = %v", diags)
+	}
+	id, _ := strconv.Atoi(d.Id())
+	if obj, _ := s.Get(fake.KindCompute, id); obj["desc"] != "updated" {
+		t.Errorf("clone desc = %v, want updated", obj["desc"])
+	}
+}
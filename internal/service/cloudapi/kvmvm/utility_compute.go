@@ -34,12 +34,13 @@ package kvmvm
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -53,9 +54,9 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 
 	// Note that this function will not abort on API errors, but will continue to configure (attach / detach) other individual
 	// disks via atomic API calls. However, it will not retry failed manipulation on the same disk.
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
-	log.Debugf("utilityComputeExtraDisksConfigure: called for Compute ID %s with do_delta = %t", d.Id(), do_delta)
+	log.Debugf(ctx, "utilityComputeExtraDisksConfigure: called for Compute ID %s with do_delta = %t", d.Id(), do_delta)
 
 	// NB: as of rc-1.25 "extra_disks" are TypeSet with the elem of TypeInt
 	old_set, new_set := d.GetChange("extra_disks")
@@ -81,7 +82,7 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 		}
 
 		if apiErrCount > 0 {
-			log.Errorf("utilityComputeExtraDisksConfigure: there were %d error(s) when attaching disks to Compute ID %s. Last error was: %s",
+			log.Errorf(ctx, "utilityComputeExtraDisksConfigure: there were %d error(s) when attaching disks to Compute ID %s. Last error was: %s",
 				apiErrCount, d.Id(), lastSavedError)
 			return lastSavedError
 		}
@@ -90,39 +91,23 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 	}
 
 	detach_set := old_set.(*schema.Set).Difference(new_set.(*schema.Set))
-	log.Debugf("utilityComputeExtraDisksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeExtraDisksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
 
 	if detach_set.Len() > 0 {
-		urlValues := &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		urlValues.Add("force", "false")
-		_, err := c.DecortAPICall(ctx, "POST", ComputeStopAPI, urlValues)
-		if err != nil {
-			return err
-		}
+		computeId, _ := strconv.ParseUint(d.Id(), 10, 64)
+		diskIds := make([]uint64, 0, detach_set.Len())
 		for _, diskId := range detach_set.List() {
-			urlValues := &url.Values{}
-			urlValues.Add("computeId", d.Id())
-			urlValues.Add("diskId", fmt.Sprintf("%d", diskId.(int)))
-			_, err := c.DecortAPICall(ctx, "POST", ComputeDiskDetachAPI, urlValues)
-			if err != nil {
-				// failed to detach disk - there will be partial resource update
-				log.Errorf("utilityComputeExtraDisksConfigure: failed to detach disk ID %d from Compute ID %s: %s", diskId.(int), d.Id(), err)
-				apiErrCount++
-				lastSavedError = err
-			}
+			diskIds = append(diskIds, uint64(diskId.(int)))
 		}
-		urlValues = &url.Values{}
-		urlValues.Add("computeId", d.Id())
-		urlValues.Add("altBootId", "0")
-		_, err = c.DecortAPICall(ctx, "POST", ComputeStartAPI, urlValues)
-		if err != nil {
-			return err
+		// compute is stopped only if it is running and started again afterwards
+		if err := utilityComputeDetachDisks(ctx, m, computeId, diskIds, false, true); err != nil {
+			apiErrCount++
+			lastSavedError = err
 		}
 	}
 
 	attach_set := new_set.(*schema.Set).Difference(old_set.(*schema.Set))
-	log.Debugf("utilityComputeExtraDisksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeExtraDisksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
 	for _, diskId := range attach_set.List() {
 		urlValues := &url.Values{}
 		urlValues.Add("computeId", d.Id())
@@ -130,14 +115,14 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 		_, err := c.DecortAPICall(ctx, "POST", ComputeDiskAttachAPI, urlValues)
 		if err != nil {
 			// failed to attach disk - there will be partial resource update
-			log.Errorf("utilityComputeExtraDisksConfigure: failed to attach disk ID %d to Compute ID %s: %s", diskId.(int), d.Id(), err)
+			log.Errorf(ctx, "utilityComputeExtraDisksConfigure: failed to attach disk ID %d to Compute ID %s: %s", diskId.(int), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
 		}
 	}
 
 	if apiErrCount > 0 {
-		log.Errorf("utilityComputeExtraDisksConfigure: there were %d error(s) when managing disks of Compute ID %s. Last error was: %s",
+		log.Errorf(ctx, "utilityComputeExtraDisksConfigure: there were %d error(s) when managing disks of Compute ID %s. Last error was: %s",
 			apiErrCount, d.Id(), lastSavedError)
 		return lastSavedError
 	}
@@ -152,7 +137,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	// Otherwise it will apply whatever is found in the new set of "network" right away.
 	// Primary use of do_delta=false is when calling this function from compute Create handler.
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	old_set, new_set := d.GetChange("network")
 
@@ -186,7 +171,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 		}
 
 		if apiErrCount > 0 {
-			log.Errorf("utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
+			log.Errorf(ctx, "utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
 				apiErrCount, d.Id(), lastSavedError)
 			return lastSavedError
 		}
@@ -194,7 +179,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	}
 
 	detach_set := old_set.(*schema.Set).Difference(new_set.(*schema.Set))
-	log.Debugf("utilityComputeNetworksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeNetworksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
 	for _, runner := range detach_set.List() {
 		urlValues := &url.Values{}
 		net_data := runner.(map[string]interface{})
@@ -204,7 +189,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 		_, err := c.DecortAPICall(ctx, "POST", ComputeNetDetachAPI, urlValues)
 		if err != nil {
 			// failed to detach this network - there will be partial resource update
-			log.Errorf("utilityComputeNetworksConfigure: failed to detach net ID %d of type %s from Compute ID %s: %s",
+			log.Errorf(ctx, "utilityComputeNetworksConfigure: failed to detach net ID %d of type %s from Compute ID %s: %s",
 				net_data["net_id"].(int), net_data["net_type"].(string), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
@@ -212,7 +197,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	}
 
 	attach_set := new_set.(*schema.Set).Difference(old_set.(*schema.Set))
-	log.Debugf("utilityComputeNetworksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeNetworksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
 	for _, runner := range attach_set.List() {
 		urlValues := &url.Values{}
 		net_data := runner.(map[string]interface{})
@@ -225,7 +210,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 		_, err := c.DecortAPICall(ctx, "POST", ComputeNetAttachAPI, urlValues)
 		if err != nil {
 			// failed to attach this network - there will be partial resource update
-			log.Errorf("utilityComputeNetworksConfigure: failed to attach net ID %d of type %s to Compute ID %s: %s",
+			log.Errorf(ctx, "utilityComputeNetworksConfigure: failed to attach net ID %d of type %s to Compute ID %s: %s",
 				net_data["net_id"].(int), net_data["net_type"].(string), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
@@ -233,7 +218,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	}
 
 	if apiErrCount > 0 {
-		log.Errorf("utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
+		log.Errorf(ctx, "utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
 			apiErrCount, d.Id(), lastSavedError)
 		return lastSavedError
 	}
@@ -242,19 +227,61 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 }
 
 func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (RecordCompute, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	compute := &RecordCompute{}
-
-	urlValues.Add("computeId", d.Id())
-	computeRaw, err := c.DecortAPICall(ctx, "POST", ComputeGetAPI, urlValues)
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
 	if err != nil {
-		return *compute, err
+		return RecordCompute{}, err
 	}
 
-	err = json.Unmarshal([]byte(computeRaw), &compute)
+	compute, err := sdk.New(m.(controller.APICaller)).Compute().Get(ctx, computesdk.GetRequest{ComputeID: computeId})
 	if err != nil {
-		return *compute, err
+		return RecordCompute{}, err
 	}
 	return *compute, nil
 }
+
+// utilityComputeAffinityRulesUpdate applies changes of affinity_rules or anti_affinity_rules
+// with the given add, remove and clear endpoints. Rules are cleared at once, when all of them
+// are removed from the configuration.
+func utilityComputeAffinityRulesUpdate(ctx context.Context, d *schema.ResourceData, key string,
+	add, remove func(context.Context, computesdk.AffinityRuleRequest) (bool, error),
+	clear func(context.Context, computesdk.IDRequest) (bool, error)) error {
+	computeId, _ := strconv.ParseUint(d.Id(), 10, 64)
+
+	oldAR, newAR := d.GetChange(key)
+	oldConv := oldAR.([]interface{})
+	newConv := newAR.([]interface{})
+
+	if len(newConv) == 0 {
+		_, err := clear(ctx, computesdk.IDRequest{ComputeID: computeId})
+		return err
+	}
+
+	affinityRuleRequest := func(ar interface{}) computesdk.AffinityRuleRequest {
+		arConv := ar.(map[string]interface{})
+		return computesdk.AffinityRuleRequest{
+			ComputeID: computeId,
+			Topology:  arConv["topology"].(string),
+			Policy:    arConv["policy"].(string),
+			Mode:      arConv["mode"].(string),
+			Key:       arConv["key"].(string),
+			Value:     arConv["value"].(string),
+		}
+	}
+
+	for _, el := range oldConv {
+		if !isContainsAR(newConv, el) {
+			if _, err := remove(ctx, affinityRuleRequest(el)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, el := range newConv {
+		if !isContainsAR(oldConv, el) {
+			if _, err := add(ctx, affinityRuleRequest(el)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
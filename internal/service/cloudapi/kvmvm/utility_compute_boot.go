@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+func rescueModeSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cdrom_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			Description: "ID of CD-ROM image to boot compute from, e.g. OS installation or rescue ISO.",
+		},
+	}
+}
+
+// utilityComputeBootOrderSet sets order of compute boot devices as configured in boot_order
+func utilityComputeBootOrderSet(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	order := make([]string, 0)
+	for _, device := range d.Get("boot_order").([]interface{}) {
+		order = append(order, device.(string))
+	}
+	log.Debugf(ctx, "utilityComputeBootOrderSet: setting boot order %v for Compute ID %d", order, computeId)
+
+	api := sdk.New(m.(controller.APICaller)).Compute()
+	_, err = api.BootOrderSet(ctx, computesdk.BootOrderSetRequest{ComputeID: computeId, Order: order})
+	return err
+}
+
+// utilityComputeRescueBoot restarts compute from CD-ROM image once. The image is used for this boot
+// only, next start of compute goes by its boot order, but the image stays inserted.
+func utilityComputeRescueBoot(ctx context.Context, d *schema.ResourceData, m interface{}, cdromId uint64) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Compute()
+
+	if err := utilityComputeSetPowerState(ctx, d, m, powerStateStopped); err != nil {
+		return err
+	}
+
+	log.Debugf(ctx, "utilityComputeRescueBoot: starting Compute ID %d from CD-ROM image ID %d", computeId, cdromId)
+	if _, err := api.Start(ctx, computesdk.StartRequest{ComputeID: computeId, AltBootID: cdromId}); err != nil {
+		return err
+	}
+	_, err = api.WaitTechStatus(ctx, computeId, techstatus.Started)
+	return err
+}
+
+// utilityComputeRescueModeConfigure applies changes of rescue_mode block. When the block is removed,
+// running compute is restarted from its boot devices and CD-ROM image it was booted from is ejected,
+// unless the image is held by cd block.
+func utilityComputeRescueModeConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	oldList, newList := d.GetChange("rescue_mode")
+	if newRescueMode := newList.([]interface{}); len(newRescueMode) > 0 && newRescueMode[0] != nil {
+		cdromId := newRescueMode[0].(map[string]interface{})["cdrom_id"].(int)
+		return utilityComputeRescueBoot(ctx, d, m, uint64(cdromId))
+	}
+
+	oldRescueMode := oldList.([]interface{})
+	if len(oldRescueMode) == 0 || oldRescueMode[0] == nil {
+		return nil
+	}
+	cdromId := uint64(oldRescueMode[0].(map[string]interface{})["cdrom_id"].(int))
+
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Compute()
+
+	compute, err := api.Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+
+	if compute.TechStatus == techstatus.Started {
+		log.Debugf(ctx, "utilityComputeRescueModeConfigure: restarting Compute ID %d from its boot devices", computeId)
+		if err := utilityComputeSetPowerState(ctx, d, m, powerStateStopped); err != nil {
+			return err
+		}
+		if err := utilityComputeSetPowerState(ctx, d, m, powerStateRunning); err != nil {
+			return err
+		}
+		if compute, err = api.Get(ctx, computesdk.GetRequest{ComputeID: computeId}); err != nil {
+			return err
+		}
+	}
+
+	if compute.CdImageID != cdromId {
+		return nil
+	}
+	for _, cd := range d.Get("cd").(*schema.Set).List() {
+		if uint64(cd.(map[string]interface{})["cdrom_id"].(int)) == cdromId {
+			return nil
+		}
+	}
+	log.Debugf(ctx, "utilityComputeRescueModeConfigure: ejecting CD-ROM image ID %d from Compute ID %d", cdromId, computeId)
+	_, err = api.CdEject(ctx, computesdk.IDRequest{ComputeID: computeId})
+	return err
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+)
+
+// utilityComputeCreateClone creates compute as a clone of the one referred by clone_from block
+// instead of creating it from image. Platform creates the clone stopped in the resource group
+// of the source compute, so rg_id must match it.
+func utilityComputeCreateClone(ctx context.Context, d *schema.ResourceData, m interface{}) (uint64, error) {
+	cloneFrom := d.Get("clone_from").([]interface{})[0].(map[string]interface{})
+	sourceId := uint64(cloneFrom["compute_id"].(int))
+
+	computeAPI := sdk.New(m.(controller.APICaller)).Compute()
+	source, err := computeAPI.Get(ctx, computesdk.GetRequest{ComputeID: sourceId})
+	if err != nil {
+		return 0, err
+	}
+	if source.RGID != uint64(d.Get("rg_id").(int)) {
+		return 0, fmt.Errorf("clone of Compute ID %d is created in its resource group ID %d, but rg_id is %d",
+			sourceId, source.RGID, d.Get("rg_id").(int))
+	}
+
+	log.Debugf(ctx, "utilityComputeCreateClone: cloning Compute ID %d into %q", sourceId, d.Get("name").(string))
+	return computeAPI.Clone(ctx, computesdk.CloneRequest{
+		ComputeID:         sourceId,
+		Name:              d.Get("name").(string),
+		SnapshotTimestamp: uint64(cloneFrom["snapshot_timestamp"].(int)),
+		SnapshotName:      cloneFrom["snapshot_name"].(string),
+		Force:             cloneFrom["force"].(bool),
+	})
+}
+
+// utilityComputeCloneConfigure brings new clone to the resource configuration before the rest of
+// Create pipeline runs. CPU, RAM and boot disk inherited from the source are resized as needed.
+// If network blocks are configured, inherited interfaces are detached, so that the compute ends
+// up with configured networks only. Otherwise inherited interfaces are kept and read back into
+// network, same as interfaces attached outside of the resource.
+func utilityComputeCloneConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+	computeAPI := sdk.New(c).Compute()
+
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	compute, err := computeAPI.Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+
+	cpu, ram := uint64(d.Get("cpu").(int)), uint64(d.Get("ram").(int))
+	if cpu != compute.CPU || ram != compute.RAM {
+		log.Debugf(ctx, "utilityComputeCloneConfigure: resizing clone ID %d to CPU %d, RAM %d", computeId, cpu, ram)
+		// clone is stopped, so that it is resized without restart
+		_, err := computeAPI.Resize(ctx, computesdk.ResizeRequest{ComputeID: computeId, CPU: cpu, RAM: ram})
+		if err != nil {
+			return err
+		}
+	}
+
+	if size, ok := d.GetOk("boot_disk_size"); ok {
+		bootDisk := findBootDisk(compute.Disks)
+		if bootDisk != nil && uint64(size.(int)) > bootDisk.SizeMax {
+			urlValues := &url.Values{}
+			urlValues.Add("diskId", strconv.FormatUint(bootDisk.ID, 10))
+			urlValues.Add("size", strconv.Itoa(size.(int)))
+			if _, err := c.DecortAPICall(ctx, "POST", DisksResizeAPI, urlValues); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, ok := d.GetOk("network"); ok {
+		for _, iface := range compute.Interfaces {
+			log.Debugf(ctx, "utilityComputeCloneConfigure: detaching inherited interface %s of clone ID %d", iface.MAC, computeId)
+			_, err := computeAPI.NetDetach(ctx, computesdk.NetDetachRequest{ComputeID: computeId, IPAddr: iface.IPAddress, MAC: iface.MAC})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/computedevices"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	rgsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/rg"
+	vgpusdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vgpu"
+)
+
+func vgpuSubresourceSchemaMake() map[string]*schema.Schema {
+	return computedevices.VGPUSchemaMake("ID of vGPU to attach to this compute. It must belong to the account of the compute and be free.")
+}
+
+func pciDeviceSubresourceSchemaMake() map[string]*schema.Schema {
+	return computedevices.PCIDeviceSchemaMake()
+}
+
+// utilityComputePCIDevicesRead sets pci_device blocks from devices actually attached to compute
+func utilityComputePCIDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	return computedevices.PCIDevicesRead(ctx, sdk.New(m.(controller.APICaller)).Compute(), d, computeId)
+}
+
+// utilityComputeDevicesConfigure attaches and detaches vGPUs and PCI devices of stopped compute
+func utilityComputeDevicesConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	return computedevices.Configure(ctx, sdk.New(m.(controller.APICaller)).Compute(), d, computeId)
+}
+
+// utilityComputeVGPUsCheck validates vGPUs added to compute against vGPUs of the account
+// the compute belongs to
+func utilityComputeVGPUsCheck(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	added := computedevices.AddedVGPUs(d)
+	if len(added) == 0 {
+		return nil
+	}
+
+	client := sdk.New(m.(controller.APICaller))
+	rg, err := client.RG().Get(ctx, rgsdk.GetRequest{RGID: uint64(d.Get("rg_id").(int))})
+	if err != nil {
+		return err
+	}
+	vgpus, err := client.VGPU().ListInAccount(ctx, vgpusdk.ListInAccountRequest{AccountID: rg.AccountID})
+	if err != nil {
+		return err
+	}
+
+	computeId, _ := strconv.ParseUint(d.Id(), 10, 64)
+	return computedevices.CheckVGPUs(vgpus, added, computeId)
+}
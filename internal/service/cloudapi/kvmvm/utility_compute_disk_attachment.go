@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+// Disk attachment resource ID has the form <compute_id>#<disk_id>
+func utilityComputeDiskAttachmentMakeId(computeId, diskId uint64) string {
+	return strconv.FormatUint(computeId, 10) + "#" + strconv.FormatUint(diskId, 10)
+}
+
+func utilityComputeDiskAttachmentParseId(id string) (uint64, uint64, error) {
+	parameters := strings.Split(id, "#")
+	if len(parameters) != 2 {
+		return 0, 0, fmt.Errorf("invalid disk attachment ID %q, expected <compute_id>#<disk_id>", id)
+	}
+	computeId, err := strconv.ParseUint(parameters[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid compute ID in disk attachment ID %q: %w", id, err)
+	}
+	diskId, err := strconv.ParseUint(parameters[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid disk ID in disk attachment ID %q: %w", id, err)
+	}
+	return computeId, diskId, nil
+}
+
+func findComputeDisk(disks ListComputeDisks, diskId uint64) *ItemComputeDisk {
+	for i := range disks {
+		if disks[i].ID == diskId {
+			return &disks[i]
+		}
+	}
+	return nil
+}
+
+// utilityComputeDiskDeviceName returns stable guest device path of the disk attached to the
+// given PCI slot of the first PCI bus, as created by udev for virtio disks
+func utilityComputeDiskDeviceName(pciSlot uint64) string {
+	if pciSlot == 0 {
+		return ""
+	}
+	return fmt.Sprintf("/dev/disk/by-path/pci-0000:00:%02x.0", pciSlot)
+}
+
+// utilityComputeDiskAttachmentCheckPresence returns the disk identified by resource ID or nil,
+// if it is not attached to the compute anymore. If the compute itself is gone, the resource is
+// removed from the state and diagnostics explaining why are returned instead.
+func utilityComputeDiskAttachmentCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*ItemComputeDisk, diag.Diagnostics) {
+	computeId, diskId, err := utilityComputeDiskAttachmentParseId(d.Id())
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	compute, err := sdk.New(m.(controller.APICaller)).Compute().Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		if controller.IsNotFound(err) {
+			return nil, dc.ParentGone(d, "Disk attachment", "compute", status.Destroyed)
+		}
+		return nil, diag.FromErr(err)
+	}
+
+	switch compute.Status {
+	case status.Destroyed:
+		return nil, dc.ParentGone(d, "Disk attachment", "compute", compute.Status)
+	case status.Deleted:
+		// compute resource restores deleted compute with its disks, unless drift policy forbids it
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return nil, dc.ParentGone(d, "Disk attachment", "compute", compute.Status)
+		}
+	}
+
+	return findComputeDisk(compute.Disks, diskId), nil
+}
+
+// utilityComputeDetachDisks detaches disks from compute. With hotDetach disks are detached from
+// running compute as is. Otherwise running compute is stopped for detach and started afterwards,
+// if stopOnDetach allows it, or an error is returned. Stopped compute is never started.
+// Like other configure utilities it does not abort on a failed disk, but returns the last error.
+func utilityComputeDetachDisks(ctx context.Context, m interface{}, computeId uint64, diskIds []uint64, hotDetach bool, stopOnDetach bool) error {
+	if len(diskIds) == 0 {
+		return nil
+	}
+
+	computeAPI := sdk.New(m.(controller.APICaller)).Compute()
+
+	detach := func() error {
+		var lastSavedError error
+		for _, diskId := range diskIds {
+			_, err := computeAPI.DiskDetach(ctx, computesdk.DiskRequest{ComputeID: computeId, DiskID: diskId})
+			if err != nil {
+				// failed to detach disk - there will be partial resource update
+				log.Errorf(ctx, "utilityComputeDetachDisks: failed to detach disk ID %d from Compute ID %d: %s", diskId, computeId, err)
+				lastSavedError = err
+			}
+		}
+		return lastSavedError
+	}
+
+	if hotDetach {
+		return detach()
+	}
+
+	compute, err := computeAPI.Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+	if compute.TechStatus != techstatus.Stopped && !stopOnDetach {
+		return fmt.Errorf("can't detach disks from running Compute ID %d: either stop it, allow hot detach or allow to stop it on detach", computeId)
+	}
+	log.Debugf(ctx, "utilityComputeDetachDisks: detaching disks from stopped Compute ID %d", computeId)
+	_, err = utilityComputeStopped(ctx, m, computeId, false, detach)
+	return err
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+)
+
+// TestComputeDiskAttachmentParentGone checks that disk attachments of destroyed compute are
+// removed from the state with a warning on refresh and destroy, and those of deleted compute
+// are kept until restore
+func TestComputeDiskAttachmentParentGone(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		exists   bool
+		wantGone bool
+	}{
+		{name: "not found", wantGone: true},
+		{name: "destroyed", status: "DESTROYED", exists: true, wantGone: true},
+		{name: "deleted", status: "DELETED", exists: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			t.Cleanup(s.Close)
+			m := s.Client()
+
+			computeId := s.NewID()
+			if tt.exists {
+				s.Put(fake.KindCompute, computeId, fake.Object{
+					"id":         computeId,
+					"status":     tt.status,
+					"techStatus": "STOPPED",
+					"disks":      []fake.Object{{"id": 7, "name": "data", "type": "D"}},
+				})
+			}
+			id := utilityComputeDiskAttachmentMakeId(uint64(computeId), 7)
+
+			for name, op := range map[string]func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics{
+				"read":   resourceComputeDiskAttachmentRead,
+				"delete": resourceComputeDiskAttachmentDelete,
+			} {
+				d := schema.TestResourceDataRaw(t, resourceComputeDiskAttachmentSchemaMake(), map[string]interface{}{
+					"compute_id": computeId,
+					"disk_id":    7,
+				})
+				d.SetId(id)
+				diags := op(context.Background(), d, m)
+				if tt.wantGone {
+					if len(diags) != 1 || diags[0].Severity != diag.Warning || d.Id() != "" {
+						t.Errorf("%s: diags = %v, ID = %q, want a warning and empty ID", name, diags, d.Id())
+					}
+					continue
+				}
+				if diags.HasError() {
+					t.Errorf("%s: diags = %v, want no errors", name, diags)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	diskssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/disks"
+)
+
+// utilityComputeDisksAdd creates data disks of disks attribute in the order they are listed and
+// records ID of every created disk in its item, so that the disk is tracked as owned by this resource.
+func utilityComputeDisksAdd(ctx context.Context, m interface{}, computeId uint64, disks []interface{}) error {
+	api := sdk.New(m.(controller.APICaller)).Compute()
+	for _, disk := range disks {
+		diskConv := disk.(map[string]interface{})
+		diskId, err := api.DiskAdd(ctx, computesdk.DiskAddRequest{
+			ComputeID:   computeId,
+			DiskName:    diskConv["disk_name"].(string),
+			Size:        uint64(diskConv["size"].(int)),
+			DiskType:    diskConv["disk_type"].(string),
+			SEPID:       uint64(diskConv["sep_id"].(int)),
+			Pool:        diskConv["pool"].(string),
+			Description: diskConv["desc"].(string),
+			ImageID:     uint64(diskConv["image_id"].(int)),
+		})
+		if err != nil {
+			return err
+		}
+		log.Debugf(ctx, "utilityComputeDisksAdd: created disk ID %d on Compute ID %d", diskId, computeId)
+		diskConv["disk_id"] = int(diskId)
+	}
+	return nil
+}
+
+// utilityComputeDisksFind returns item of disks attribute with the given disk name, or nil
+func utilityComputeDisksFind(disks []interface{}, name string) map[string]interface{} {
+	for _, disk := range disks {
+		diskConv := disk.(map[string]interface{})
+		if diskConv["disk_name"].(string) == name {
+			return diskConv
+		}
+	}
+	return nil
+}
+
+// utilityComputeDisksConfigure applies changes of disks attribute. Disks are matched by name: disks
+// removed from the list are deleted, new ones are created and disks with changed size are resized.
+// Only disks which IDs are tracked in state are deleted or resized, i.e. disks created by this
+// resource, so disks attached by other means are never touched. Running compute is stopped to
+// delete disks and then brought back to its power state. The resulting list, in the configured
+// order and with disk IDs, is saved to d, also when configuration fails halfway.
+func utilityComputeDisksConfigure(ctx context.Context, d *schema.ResourceData, m interface{}, warnings *dc.Warnings) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Compute()
+
+	oldDisks, newDisks := d.GetChange("disks")
+	oldConv := oldDisks.([]interface{})
+	newConv := newDisks.([]interface{})
+
+	deletedDisks := make([]map[string]interface{}, 0)
+	for _, disk := range oldConv {
+		diskConv := disk.(map[string]interface{})
+		if diskConv["disk_id"].(int) == 0 {
+			continue
+		}
+		if utilityComputeDisksFind(newConv, diskConv["disk_name"].(string)) == nil {
+			deletedDisks = append(deletedDisks, diskConv)
+		}
+	}
+
+	addedDisks := make([]interface{}, 0)
+	resizedDisks := make([]map[string]interface{}, 0)
+	for _, disk := range newConv {
+		diskConv := disk.(map[string]interface{})
+		old := utilityComputeDisksFind(oldConv, diskConv["disk_name"].(string))
+		if old == nil || old["disk_id"].(int) == 0 {
+			addedDisks = append(addedDisks, diskConv)
+			continue
+		}
+		diskConv["disk_id"] = old["disk_id"]
+		if diskConv["size"].(int) != old["size"].(int) {
+			resizedDisks = append(resizedDisks, diskConv)
+		}
+	}
+
+	// keep track of disks in state even if configuration fails halfway, otherwise disks created
+	// so far would not be reported and those not yet deleted would not be deleted on next apply
+	defer func() {
+		disks := make([]interface{}, 0, len(newConv)+len(deletedDisks))
+		for _, disk := range newConv {
+			if disk.(map[string]interface{})["disk_id"].(int) != 0 {
+				disks = append(disks, disk)
+			}
+		}
+		for _, diskConv := range deletedDisks {
+			disks = append(disks, diskConv)
+		}
+		d.Set("disks", disks)
+	}()
+
+	if len(deletedDisks) > 0 {
+		deleted := 0
+		powerState, err := utilityComputeStopped(ctx, m, computeId, d.Get("force_stop").(bool), func() error {
+			for len(deletedDisks) > 0 {
+				diskConv := deletedDisks[0]
+				log.Debugf(ctx, "utilityComputeDisksConfigure: deleting disk ID %d of Compute ID %d", diskConv["disk_id"].(int), computeId)
+				_, err := api.DiskDel(ctx, computesdk.DiskDelRequest{
+					ComputeID:   computeId,
+					DiskID:      uint64(diskConv["disk_id"].(int)),
+					Permanently: diskConv["permanently"].(bool),
+				})
+				if err != nil && !controller.IsNotFound(err) {
+					return err
+				}
+				deletedDisks = deletedDisks[1:]
+				deleted++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if powerState != powerStateStopped {
+			warnings.Add(fmt.Errorf("Compute ID %s was stopped and started to delete %d disk(s)", d.Id(), deleted))
+		}
+	}
+
+	if err := utilityComputeDisksAdd(ctx, m, computeId, addedDisks); err != nil {
+		return err
+	}
+
+	for _, diskConv := range resizedDisks {
+		_, err := sdk.New(m.(controller.APICaller)).Disks().Resize(ctx, diskssdk.ResizeRequest{
+			DiskID: uint64(diskConv["disk_id"].(int)),
+			Size:   uint64(diskConv["size"].(int)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -42,7 +42,7 @@ import (
 )
 
 func utilityComputeGetConsoleUrlCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (string, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
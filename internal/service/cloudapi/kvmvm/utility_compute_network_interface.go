@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+)
+
+// Network interface resource ID has the form <compute_id>#<mac>. MAC address is assigned by
+// the platform on attach and does not change with IP address, so it identifies the interface
+// regardless of the order of compute interfaces.
+func utilityComputeNetworkInterfaceMakeId(computeId uint64, mac string) string {
+	return strconv.FormatUint(computeId, 10) + "#" + mac
+}
+
+func utilityComputeNetworkInterfaceParseId(id string) (uint64, string, error) {
+	parameters := strings.SplitN(id, "#", 2)
+	if len(parameters) != 2 || parameters[1] == "" {
+		return 0, "", fmt.Errorf("invalid network interface ID %q, expected <compute_id>#<mac>", id)
+	}
+	computeId, err := strconv.ParseUint(parameters[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid compute ID in network interface ID %q: %w", id, err)
+	}
+	return computeId, strings.ToLower(parameters[1]), nil
+}
+
+func findInterfaceByMAC(interfaces ListInterfaces, mac string) *ItemVNFInterface {
+	for i := range interfaces {
+		if strings.EqualFold(interfaces[i].MAC, mac) {
+			return &interfaces[i]
+		}
+	}
+	return nil
+}
+
+// findAttachedInterface looks for the interface created by compute/netAttach among interfaces
+// returned by compute/get. MAC from netAttach response is used when present, otherwise the
+// interface is matched by network and IP address among those, which did not exist before attach.
+func findAttachedInterface(interfaces ListInterfaces, attached *computesdk.RecordNetAttach, known map[string]bool, netType string, netId uint64) *ItemVNFInterface {
+	if attached != nil && attached.MAC != "" {
+		return findInterfaceByMAC(interfaces, attached.MAC)
+	}
+	for i, iface := range interfaces {
+		if known[strings.ToLower(iface.MAC)] || iface.NetType != netType || iface.NetID != netId {
+			continue
+		}
+		if attached != nil && attached.IPAddress != "" && iface.IPAddress != attached.IPAddress {
+			continue
+		}
+		return &interfaces[i]
+	}
+	return nil
+}
+
+// utilityComputeNetworkInterfaceCheckPresence returns the interface identified by resource ID
+// or nil, if compute does not have it anymore. If the compute itself is gone, the resource is
+// removed from the state and diagnostics explaining why are returned instead.
+func utilityComputeNetworkInterfaceCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*ItemVNFInterface, diag.Diagnostics) {
+	computeId, mac, err := utilityComputeNetworkInterfaceParseId(d.Id())
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	compute, err := sdk.New(m.(controller.APICaller)).Compute().Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		if controller.IsNotFound(err) {
+			return nil, dc.ParentGone(d, "Network interface", "compute", status.Destroyed)
+		}
+		return nil, diag.FromErr(err)
+	}
+
+	switch compute.Status {
+	case status.Destroyed:
+		return nil, dc.ParentGone(d, "Network interface", "compute", compute.Status)
+	case status.Deleted:
+		// compute resource restores deleted compute with its interfaces, unless drift policy forbids it
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return nil, dc.ParentGone(d, "Network interface", "compute", compute.Status)
+		}
+	}
+
+	return findInterfaceByMAC(compute.Interfaces, mac), nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+)
+
+// Power states of compute, reported by power_state attribute
+const (
+	powerStateRunning = powerstate.Running
+	powerStateStopped = powerstate.Stopped
+	powerStatePaused  = powerstate.Paused
+)
+
+// utilityComputeTargetPowerState returns power state compute should be brought to, or empty
+// string if power state is not changed. New compute is left stopped, unless configured otherwise.
+func utilityComputeTargetPowerState(d *schema.ResourceData, isNew bool) string {
+	return powerstate.Target(d, isNew, powerStateStopped)
+}
+
+// utilityComputeSetPowerState brings compute to the target power state and waits for it to settle,
+// stopping it gracefully or forcibly as configured by force_stop
+func utilityComputeSetPowerState(ctx context.Context, d *schema.ResourceData, m interface{}, target string) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = powerstate.Set(ctx, sdk.New(m.(controller.APICaller)).Compute(), computeId, target, d.Get("force_stop").(bool))
+	return err
+}
+
+// utilityComputeStopped runs op against stopped compute and brings compute back to its previous
+// power state, which is returned
+func utilityComputeStopped(ctx context.Context, m interface{}, computeId uint64, force bool, op func() error) (string, error) {
+	return powerstate.WithStopped(ctx, sdk.New(m.(controller.APICaller)).Compute(), computeId, force, op)
+}
+
+// utilityComputeReset resets running compute and waits for it to be running again
+func utilityComputeReset(ctx context.Context, m interface{}, computeId uint64) error {
+	return powerstate.Reset(ctx, sdk.New(m.(controller.APICaller)).Compute(), computeId)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+)
+
+// Ways to change CPU/RAM of existing compute, reported by resize_mode attribute
+const (
+	// compute is stopped, it is resized as is
+	resizeModeCold = "cold"
+	// compute is running and its image supports hot resize
+	resizeModeHot = "hot"
+	// compute is running, it is stopped for resize and started again
+	resizeModeRestart = "restart"
+)
+
+func utilityImageHotResize(ctx context.Context, m interface{}, imageId uint64) (bool, error) {
+	c := m.(controller.APICaller)
+	urlValues := &url.Values{}
+	urlValues.Add("imageId", strconv.FormatUint(imageId, 10))
+	resp, err := c.DecortAPICall(ctx, "POST", ImageGetAPI, urlValues)
+	if err != nil {
+		return false, err
+	}
+
+	image := struct {
+		HotResize bool `json:"hotResize"`
+	}{}
+	if err := json.Unmarshal([]byte(resp), &image); err != nil {
+		return false, err
+	}
+	return image.HotResize, nil
+}
+
+// utilityComputeResizeMode decides how CPU/RAM of the compute should be changed. Running compute
+// is resized hot only if its image supports it and resources are not reduced, otherwise it has
+// to be restarted, which is allowed by allow_restart_for_resize only.
+func utilityComputeResizeMode(ctx context.Context, m interface{}, computeId uint64, oldCpu, newCpu, oldRam, newRam int, allowRestart bool) (string, error) {
+	compute, err := sdk.New(m.(controller.APICaller)).Compute().Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return "", err
+	}
+
+	if compute.TechStatus != techstatus.Started {
+		return resizeModeCold, nil
+	}
+
+	if newCpu >= oldCpu && newRam >= oldRam {
+		hotResize, err := utilityImageHotResize(ctx, m, compute.ImageID)
+		if err != nil {
+			return "", err
+		}
+		if hotResize {
+			return resizeModeHot, nil
+		}
+	}
+
+	if !allowRestart {
+		return "", fmt.Errorf("Compute ID %d is running and can't be resized hot: its image ID %d does not support hot resize or CPU/RAM are reduced. "+
+			"Set allow_restart_for_resize to stop and start it for resize or stop it beforehand", computeId, compute.ImageID)
+	}
+	return resizeModeRestart, nil
+}
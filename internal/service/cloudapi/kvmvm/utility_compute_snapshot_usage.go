@@ -11,7 +11,7 @@ import (
 )
 
 func utilityComputeSnapshotUasgeCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ListUsageSnapshots, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	UsageSnapshotList := &ListUsageSnapshots{}
 
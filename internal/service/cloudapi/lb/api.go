@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package lb
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const lbListAPI = "/restmachine/cloudapi/lb/list"
 const lbListDeletedAPI = "/restmachine/cloudapi/lb/listDeleted"
 const lbGetAPI = "/restmachine/cloudapi/lb/get"
@@ -56,3 +58,5 @@ const lbFrontendDeleteAPI = "/restmachine/cloudapi/lb/frontendDelete"
 const lbFrontendBindAPI = "/restmachine/cloudapi/lb/frontendBind"
 const lbFrontendBindDeleteAPI = "/restmachine/cloudapi/lb/frontendBindDelete"
 const lbFrontendBindUpdateAPI = "/restmachine/cloudapi/lb/frontendBindingUpdate"
+
+var log = logging.New("lb")
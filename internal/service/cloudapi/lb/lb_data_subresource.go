@@ -95,8 +95,9 @@ func dsLBListSchemaMake() map[string]*schema.Schema {
 func dsLBItemSchemaMake() map[string]*schema.Schema {
 	sch := createLBSchema()
 	sch["dp_api_password"] = &schema.Schema{
-		Type:     schema.TypeString,
-		Computed: true,
+		Type:      schema.TypeString,
+		Sensitive: true,
+		Computed:  true,
 	}
 	return sch
 }
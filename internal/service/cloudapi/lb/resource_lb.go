@@ -39,14 +39,16 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	lbsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/lb"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 )
 
 func resourceLBCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBCreate")
+	log.Debugf(ctx, "resourceLBCreate")
 
 	haveRGID, err := existRGID(ctx, d, m)
 	if err != nil {
@@ -75,7 +77,7 @@ func resourceLBCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.Errorf("resourceLBCreate: can't create LB because ViNSID %d is not allowed or does not exist", d.Get("vins_id").(int))
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("rgId", strconv.Itoa(d.Get("rg_id").(int)))
@@ -126,9 +128,7 @@ func resourceLBCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceLBRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBRead")
-
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceLBRead")
 
 	lb, err := utilityLBCheckPresence(ctx, d, m)
 	if lb == nil {
@@ -136,6 +136,9 @@ func resourceLBRead(ctx context.Context, d *schema.ResourceData, m interface{})
 		return diag.FromErr(err)
 	}
 
+	api := sdk.New(m.(controller.APICaller)).LB()
+	idReq := lbsdk.IDRequest{LBID: lb.ID}
+
 	hasChanged := false
 
 	switch lb.Status {
@@ -145,14 +148,14 @@ func resourceLBRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	case status.Created:
 	case status.Deleting:
 	case status.Deleted:
-		urlValues := &url.Values{}
-		urlValues.Add("lbId", d.Id())
-
-		_, err := c.DecortAPICall(ctx, "POST", lbRestoreAPI, urlValues)
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "LB", lb.Status)
+		}
+		_, err := api.Restore(ctx, idReq)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		_, err = c.DecortAPICall(ctx, "POST", lbEnableAPI, urlValues)
+		_, err = api.Enable(ctx, idReq)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -161,13 +164,16 @@ func resourceLBRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	case status.Destroying:
 		return diag.Errorf("The LB is in progress with status: %s", lb.Status)
 	case status.Destroyed:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "LB", lb.Status)
+		}
 		d.SetId("")
 		return resourceLBCreate(ctx, d, m)
 	case status.Enabled:
 	case status.Enabling:
 	case status.Disabling:
 	case status.Disabled:
-		log.Debugf("The LB is in status: %s, troubles may occur with update. Please, enable LB first.", lb.Status)
+		log.Debugf(ctx, "The LB is in status: %s, troubles may occur with update. Please, enable LB first.", lb.Status)
 	case status.Restoring:
 	}
 
@@ -209,7 +215,7 @@ func resourceLBRead(ctx context.Context, d *schema.ResourceData, m interface{})
 }
 
 func resourceLBDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBDelete")
+	log.Debugf(ctx, "resourceLBDelete")
 
 	lb, err := utilityLBCheckPresence(ctx, d, m)
 	if lb == nil {
@@ -219,15 +225,10 @@ func resourceLBDelete(ctx context.Context, d *schema.ResourceData, m interface{}
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-
-	if permanently, ok := d.GetOk("permanently"); ok {
-		urlValues.Add("permanently", strconv.FormatBool(permanently.(bool)))
-	}
-
-	_, err = c.DecortAPICall(ctx, "POST", lbDeleteAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().Delete(ctx, lbsdk.DeleteRequest{
+		LBID:        uint64(d.Get("lb_id").(int)),
+		Permanently: d.Get("permanently").(bool),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -237,9 +238,7 @@ func resourceLBDelete(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceLBUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBEdit")
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
+	log.Debugf(ctx, "resourceLBEdit")
 
 	haveRGID, err := existRGID(ctx, d, m)
 	if err != nil {
@@ -274,6 +273,9 @@ func resourceLBUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.FromErr(err)
 	}
 
+	api := sdk.New(m.(controller.APICaller)).LB()
+	idReq := lbsdk.IDRequest{LBID: lb.ID}
+
 	hasChanged := false
 
 	switch lb.Status {
@@ -283,14 +285,11 @@ func resourceLBUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	case status.Created:
 	case status.Deleting:
 	case status.Deleted:
-		urlValues := &url.Values{}
-		urlValues.Add("lbId", d.Id())
-
-		_, err := c.DecortAPICall(ctx, "POST", lbRestoreAPI, urlValues)
+		_, err := api.Restore(ctx, idReq)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		_, err = c.DecortAPICall(ctx, "POST", lbEnableAPI, urlValues)
+		_, err = api.Enable(ctx, idReq)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -305,7 +304,7 @@ func resourceLBUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	case status.Enabling:
 	case status.Disabling:
 	case status.Disabled:
-		log.Debugf("The LB is in status: %s, troubles may occur with update. Please, enable LB first.", lb.Status)
+		log.Debugf(ctx, "The LB is in status: %s, troubles may occur with update. Please, enable LB first.", lb.Status)
 	case status.Restoring:
 	}
 
@@ -318,85 +317,63 @@ func resourceLBUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 
 	if d.HasChange("enable") {
-		api := lbDisableAPI
-		enable := d.Get("enable").(bool)
-		if enable {
-			api = lbEnableAPI
+		enable := api.Disable
+		if d.Get("enable").(bool) {
+			enable = api.Enable
 		}
-		urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
 
-		_, err := c.DecortAPICall(ctx, "POST", api, urlValues)
+		_, err := enable(ctx, idReq)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		urlValues = &url.Values{}
 	}
 
 	if d.HasChange("start") {
-		api := lbStopAPI
-		start := d.Get("start").(bool)
-		if start {
-			api = lbStartAPI
+		start := api.Stop
+		if d.Get("start").(bool) {
+			start = api.Start
 		}
-		urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
 
-		_, err := c.DecortAPICall(ctx, "POST", api, urlValues)
+		_, err := start(ctx, idReq)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		urlValues = &url.Values{}
 	}
 
 	if d.HasChange("desc") {
-		urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-		urlValues.Add("desc", d.Get("desc").(string))
-
-		_, err := c.DecortAPICall(ctx, "POST", lbUpdateAPI, urlValues)
+		_, err := api.Update(ctx, lbsdk.UpdateRequest{
+			LBID:        idReq.LBID,
+			Description: d.Get("desc").(string),
+		})
 		if err != nil {
 			return diag.FromErr(err)
 		}
-
-		urlValues = &url.Values{}
 	}
 
 	if d.HasChange("restart") {
-		restart := d.Get("restart").(bool)
-		if restart {
-			urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-			_, err := c.DecortAPICall(ctx, "POST", lbRestartAPI, urlValues)
+		if d.Get("restart").(bool) {
+			_, err := api.Restart(ctx, idReq)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-
-			urlValues = &url.Values{}
 		}
 	}
 
 	if d.HasChange("restore") {
-		restore := d.Get("restore").(bool)
-		if restore {
-			urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-			_, err := c.DecortAPICall(ctx, "POST", lbRestoreAPI, urlValues)
+		if d.Get("restore").(bool) {
+			_, err := api.Restore(ctx, idReq)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-
-			urlValues = &url.Values{}
 		}
 	}
 
 	if d.HasChange("config_reset") {
-		cfgReset := d.Get("config_reset").(bool)
-		if cfgReset {
-			urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-			_, err := c.DecortAPICall(ctx, "POST", lbConfigResetAPI, urlValues)
+		if d.Get("config_reset").(bool) {
+			_, err := api.ConfigReset(ctx, idReq)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-
-			urlValues = &url.Values{}
 		}
 	}
 
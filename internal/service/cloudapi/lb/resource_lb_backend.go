@@ -34,20 +34,20 @@ package lb
 
 import (
 	"context"
-	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	lbsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/lb"
 )
 
 func resourceLBBackendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendCreate")
+	log.Debugf(ctx, "resourceLBBackendCreate")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -58,40 +58,13 @@ func resourceLBBackendCreate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.Errorf("resourceLBBackendCreate: can't create LB backend because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("backendName", d.Get("name").(string))
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-
-	if algorithm, ok := d.GetOk("algorithm"); ok {
-		urlValues.Add("algorithm", algorithm.(string))
-	}
-	if inter, ok := d.GetOk("inter"); ok {
-		urlValues.Add("inter", strconv.Itoa(inter.(int)))
-	}
-	if downinter, ok := d.GetOk("downinter"); ok {
-		urlValues.Add("downinter", strconv.Itoa(downinter.(int)))
-	}
-	if rise, ok := d.GetOk("rise"); ok {
-		urlValues.Add("rise", strconv.Itoa(rise.(int)))
-	}
-	if fall, ok := d.GetOk("fall"); ok {
-		urlValues.Add("fall", strconv.Itoa(fall.(int)))
-	}
-	if slowstart, ok := d.GetOk("slowstart"); ok {
-		urlValues.Add("slowstart", strconv.Itoa(slowstart.(int)))
-	}
-	if maxconn, ok := d.GetOk("maxconn"); ok {
-		urlValues.Add("maxconn", strconv.Itoa(maxconn.(int)))
-	}
-	if maxqueue, ok := d.GetOk("maxqueue"); ok {
-		urlValues.Add("maxqueue", strconv.Itoa(maxqueue.(int)))
-	}
-	if weight, ok := d.GetOk("weight"); ok {
-		urlValues.Add("weight", strconv.Itoa(weight.(int)))
-	}
-
-	_, err = c.DecortAPICall(ctx, "POST", lbBackendCreateAPI, urlValues)
+	api := sdk.New(m.(controller.APICaller)).LB()
+	_, err = api.BackendCreate(ctx, lbsdk.BackendRequest{
+		LBID:                uint64(d.Get("lb_id").(int)),
+		BackendName:         d.Get("name").(string),
+		Algorithm:           d.Get("algorithm").(string),
+		HealthCheckSettings: utilityLBHealthCheckSettings(d, utilityLBConfigured(d)),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -112,7 +85,7 @@ func resourceLBBackendCreate(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceLBBackendRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendRead")
+	log.Debugf(ctx, "resourceLBBackendRead")
 
 	b, err := utilityLBBackendCheckPresence(ctx, d, m)
 	if b == nil {
@@ -140,7 +113,7 @@ func resourceLBBackendRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceLBBackendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendDelete")
+	log.Debugf(ctx, "resourceLBBackendDelete")
 
 	lb, err := utilityLBBackendCheckPresence(ctx, d, m)
 	if lb == nil {
@@ -150,12 +123,10 @@ func resourceLBBackendDelete(ctx context.Context, d *schema.ResourceData, m inte
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-	urlValues.Add("backendName", d.Get("name").(string))
-
-	_, err = c.DecortAPICall(ctx, "POST", lbBackendDeleteAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().BackendDelete(ctx, lbsdk.BackendDeleteRequest{
+		LBID:        uint64(d.Get("lb_id").(int)),
+		BackendName: d.Get("name").(string),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -165,9 +136,7 @@ func resourceLBBackendDelete(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceLBBackendUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendEdit")
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
+	log.Debugf(ctx, "resourceLBBackendEdit")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -178,38 +147,16 @@ func resourceLBBackendUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.Errorf("resourceLBBackendUpdate: can't update LB backend because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	urlValues.Add("backendName", d.Get("name").(string))
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-
-	if d.HasChange("algorithm") {
-		urlValues.Add("algorithm", d.Get("algorithm").(string))
-	}
-	if d.HasChange("inter") {
-		urlValues.Add("inter", strconv.Itoa(d.Get("inter").(int)))
-	}
-	if d.HasChange("downinter") {
-		urlValues.Add("downinter", strconv.Itoa(d.Get("downinter").(int)))
-	}
-	if d.HasChange("rise") {
-		urlValues.Add("rise", strconv.Itoa(d.Get("rise").(int)))
+	req := lbsdk.BackendRequest{
+		LBID:                uint64(d.Get("lb_id").(int)),
+		BackendName:         d.Get("name").(string),
+		HealthCheckSettings: utilityLBHealthCheckSettings(d, d.HasChange),
 	}
-	if d.HasChange("fall") {
-		urlValues.Add("fall", strconv.Itoa(d.Get("fall").(int)))
-	}
-	if d.HasChange("slowstart") {
-		urlValues.Add("slowstart", strconv.Itoa(d.Get("slowstart").(int)))
-	}
-	if d.HasChange("maxconn") {
-		urlValues.Add("maxconn", strconv.Itoa(d.Get("maxconn").(int)))
-	}
-	if d.HasChange("maxqueue") {
-		urlValues.Add("maxqueue", strconv.Itoa(d.Get("maxqueue").(int)))
-	}
-	if d.HasChange("weight") {
-		urlValues.Add("weight", strconv.Itoa(d.Get("weight").(int)))
+	if d.HasChange("algorithm") {
+		req.Algorithm = d.Get("algorithm").(string)
 	}
 
-	_, err = c.DecortAPICall(ctx, "POST", lbBackendUpdateAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().BackendUpdate(ctx, req)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -34,20 +34,20 @@ package lb
 
 import (
 	"context"
-	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	lbsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/lb"
 )
 
 func resourceLBBackendServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendServerCreate")
+	log.Debugf(ctx, "resourceLBBackendServerCreate")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -58,44 +58,15 @@ func resourceLBBackendServerCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("resourceLBBackendServerCreate: can't create LB backend server because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("backendName", d.Get("backend_name").(string))
-	urlValues.Add("serverName", d.Get("name").(string))
-	urlValues.Add("address", d.Get("address").(string))
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-	urlValues.Add("port", strconv.Itoa(d.Get("port").(int)))
-
-	if check, ok := d.GetOk("check"); ok {
-		urlValues.Add("check", check.(string))
-	}
-
-	if inter, ok := d.GetOk("inter"); ok {
-		urlValues.Add("inter", strconv.Itoa(inter.(int)))
-	}
-	if downinter, ok := d.GetOk("downinter"); ok {
-		urlValues.Add("downinter", strconv.Itoa(downinter.(int)))
-	}
-	if rise, ok := d.GetOk("rise"); ok {
-		urlValues.Add("rise", strconv.Itoa(rise.(int)))
-	}
-	if fall, ok := d.GetOk("fall"); ok {
-		urlValues.Add("fall", strconv.Itoa(fall.(int)))
-	}
-	if slowstart, ok := d.GetOk("slowstart"); ok {
-		urlValues.Add("slowstart", strconv.Itoa(slowstart.(int)))
-	}
-	if maxconn, ok := d.GetOk("maxconn"); ok {
-		urlValues.Add("maxconn", strconv.Itoa(maxconn.(int)))
-	}
-	if maxqueue, ok := d.GetOk("maxqueue"); ok {
-		urlValues.Add("maxqueue", strconv.Itoa(maxqueue.(int)))
-	}
-	if weight, ok := d.GetOk("weight"); ok {
-		urlValues.Add("weight", strconv.Itoa(weight.(int)))
-	}
-
-	_, err = c.DecortAPICall(ctx, "POST", lbBackendServerAddAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().BackendServerAdd(ctx, lbsdk.BackendServerRequest{
+		LBID:                uint64(d.Get("lb_id").(int)),
+		BackendName:         d.Get("backend_name").(string),
+		ServerName:          d.Get("name").(string),
+		Address:             d.Get("address").(string),
+		Port:                uint64(d.Get("port").(int)),
+		Check:               d.Get("check").(string),
+		HealthCheckSettings: utilityLBHealthCheckSettings(d, utilityLBConfigured(d)),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -116,7 +87,7 @@ func resourceLBBackendServerCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceLBBackendServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendServerRead")
+	log.Debugf(ctx, "resourceLBBackendServerRead")
 
 	s, err := utilityLBBackendServerCheckPresence(ctx, d, m)
 	if s == nil {
@@ -147,7 +118,7 @@ func resourceLBBackendServerRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceLBBackendServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendServerDelete")
+	log.Debugf(ctx, "resourceLBBackendServerDelete")
 
 	lb, err := utilityLBBackendServerCheckPresence(ctx, d, m)
 	if lb == nil {
@@ -157,13 +128,11 @@ func resourceLBBackendServerDelete(ctx context.Context, d *schema.ResourceData,
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-	urlValues.Add("serverName", d.Get("name").(string))
-	urlValues.Add("backendName", d.Get("backend_name").(string))
-
-	_, err = c.DecortAPICall(ctx, "POST", lbBackendServerDeleteAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().BackendServerDelete(ctx, lbsdk.BackendServerDeleteRequest{
+		LBID:        uint64(d.Get("lb_id").(int)),
+		BackendName: d.Get("backend_name").(string),
+		ServerName:  d.Get("name").(string),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -173,9 +142,7 @@ func resourceLBBackendServerDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceLBBackendServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBBackendServerEdit")
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
+	log.Debugf(ctx, "resourceLBBackendServerEdit")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -186,41 +153,19 @@ func resourceLBBackendServerUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("resourceLBBackendServerUpdate: can't update LB backend server because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	urlValues.Add("backendName", d.Get("backend_name").(string))
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-	urlValues.Add("serverName", d.Get("name").(string))
-	urlValues.Add("address", d.Get("address").(string))
-	urlValues.Add("port", strconv.Itoa(d.Get("port").(int)))
-
-	if d.HasChange("check") {
-		urlValues.Add("check", d.Get("check").(string))
-	}
-	if d.HasChange("inter") {
-		urlValues.Add("inter", strconv.Itoa(d.Get("inter").(int)))
-	}
-	if d.HasChange("downinter") {
-		urlValues.Add("downinter", strconv.Itoa(d.Get("downinter").(int)))
-	}
-	if d.HasChange("rise") {
-		urlValues.Add("rise", strconv.Itoa(d.Get("rise").(int)))
+	req := lbsdk.BackendServerRequest{
+		LBID:                uint64(d.Get("lb_id").(int)),
+		BackendName:         d.Get("backend_name").(string),
+		ServerName:          d.Get("name").(string),
+		Address:             d.Get("address").(string),
+		Port:                uint64(d.Get("port").(int)),
+		HealthCheckSettings: utilityLBHealthCheckSettings(d, d.HasChange),
 	}
-	if d.HasChange("fall") {
-		urlValues.Add("fall", strconv.Itoa(d.Get("fall").(int)))
-	}
-	if d.HasChange("slowstart") {
-		urlValues.Add("slowstart", strconv.Itoa(d.Get("slowstart").(int)))
-	}
-	if d.HasChange("maxconn") {
-		urlValues.Add("maxconn", strconv.Itoa(d.Get("maxconn").(int)))
-	}
-	if d.HasChange("maxqueue") {
-		urlValues.Add("maxqueue", strconv.Itoa(d.Get("maxqueue").(int)))
-	}
-	if d.HasChange("weight") {
-		urlValues.Add("weight", strconv.Itoa(d.Get("weight").(int)))
+	if d.HasChange("check") {
+		req.Check = d.Get("check").(string)
 	}
 
-	_, err = c.DecortAPICall(ctx, "POST", lbBackendServerUpdateAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().BackendServerUpdate(ctx, req)
 	if err != nil {
 		return diag.FromErr(err)
 	}
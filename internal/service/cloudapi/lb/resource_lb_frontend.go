@@ -40,13 +40,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 func resourceLBFrontendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendCreate")
+	log.Debugf(ctx, "resourceLBFrontendCreate")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -57,7 +56,7 @@ func resourceLBFrontendCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.Errorf("resourceLBFrontendCreate: can't create LB frontend because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("backendName", d.Get("backend_name").(string))
 	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
@@ -84,7 +83,7 @@ func resourceLBFrontendCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceLBFrontendRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendRead")
+	log.Debugf(ctx, "resourceLBFrontendRead")
 
 	f, err := utilityLBFrontendCheckPresence(ctx, d, m)
 	if f == nil {
@@ -103,7 +102,7 @@ func resourceLBFrontendRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceLBFrontendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendDelete")
+	log.Debugf(ctx, "resourceLBFrontendDelete")
 
 	lb, err := utilityLBFrontendCheckPresence(ctx, d, m)
 	if lb == nil {
@@ -113,7 +112,7 @@ func resourceLBFrontendDelete(ctx context.Context, d *schema.ResourceData, m int
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
 	urlValues.Add("frontendName", d.Get("name").(string))
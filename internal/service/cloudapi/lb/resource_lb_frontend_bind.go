@@ -34,19 +34,19 @@ package lb
 
 import (
 	"context"
-	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	lbsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/lb"
 )
 
 func resourceLBFrontendBindCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendBindCreate")
+	log.Debugf(ctx, "resourceLBFrontendBindCreate")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -57,15 +57,15 @@ func resourceLBFrontendBindCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("resourceLBFrontendBindCreate: can't create LB frontend bind because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("frontendName", d.Get("frontend_name").(string))
-	urlValues.Add("bindingName", d.Get("name").(string))
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-	urlValues.Add("bindingAddress", d.Get("address").(string))
-	urlValues.Add("bindingPort", strconv.Itoa(d.Get("port").(int)))
+	req := lbsdk.FrontendBindRequest{
+		LBID:           uint64(d.Get("lb_id").(int)),
+		FrontendName:   d.Get("frontend_name").(string),
+		BindingName:    d.Get("name").(string),
+		BindingAddress: d.Get("address").(string),
+		BindingPort:    uint64(d.Get("port").(int)),
+	}
 
-	_, err = c.DecortAPICall(ctx, "POST", lbFrontendBindAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().FrontendBind(ctx, req)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -86,7 +86,7 @@ func resourceLBFrontendBindCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceLBFrontendBindRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendBindRead")
+	log.Debugf(ctx, "resourceLBFrontendBindRead")
 
 	b, err := utilityLBFrontendBindCheckPresence(ctx, d, m)
 	if b == nil {
@@ -108,7 +108,7 @@ func resourceLBFrontendBindRead(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceLBFrontendBindDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendBindDelete")
+	log.Debugf(ctx, "resourceLBFrontendBindDelete")
 
 	b, err := utilityLBFrontendBindCheckPresence(ctx, d, m)
 	if b == nil {
@@ -118,13 +118,11 @@ func resourceLBFrontendBindDelete(ctx context.Context, d *schema.ResourceData, m
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-	urlValues.Add("bindingName", d.Get("name").(string))
-	urlValues.Add("frontendName", d.Get("frontend_name").(string))
-
-	_, err = c.DecortAPICall(ctx, "POST", lbFrontendBindDeleteAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().FrontendBindDelete(ctx, lbsdk.FrontendBindDeleteRequest{
+		LBID:         uint64(d.Get("lb_id").(int)),
+		FrontendName: d.Get("frontend_name").(string),
+		BindingName:  d.Get("name").(string),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -134,9 +132,7 @@ func resourceLBFrontendBindDelete(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceLBFrontendBindUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceLBFrontendBindEdit")
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
+	log.Debugf(ctx, "resourceLBFrontendBindEdit")
 
 	haveLBID, err := existLBID(ctx, d, m)
 	if err != nil {
@@ -147,19 +143,19 @@ func resourceLBFrontendBindUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("resourceLBFrontendBindUpdate: can't update LB frontend bind because LBID %d is not allowed or does not exist", d.Get("lb_id").(int))
 	}
 
-	urlValues.Add("frontendName", d.Get("frontend_name").(string))
-	urlValues.Add("bindingName", d.Get("name").(string))
-	urlValues.Add("lbId", strconv.Itoa(d.Get("lb_id").(int)))
-
+	req := lbsdk.FrontendBindRequest{
+		LBID:         uint64(d.Get("lb_id").(int)),
+		FrontendName: d.Get("frontend_name").(string),
+		BindingName:  d.Get("name").(string),
+	}
 	if d.HasChange("address") {
-		urlValues.Add("bindingAddress", d.Get("address").(string))
+		req.BindingAddress = d.Get("address").(string)
 	}
-
 	if d.HasChange("port") {
-		urlValues.Add("bindingPort", strconv.Itoa(d.Get("port").(int)))
+		req.BindingPort = uint64(d.Get("port").(int))
 	}
 
-	_, err = c.DecortAPICall(ctx, "POST", lbFrontendBindUpdateAPI, urlValues)
+	_, err = sdk.New(m.(controller.APICaller)).LB().FrontendBindingUpdate(ctx, req)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,304 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package lb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+)
+
+// testLB registers load balancer with its resource group and ViNS in the fake controller
+func testLB(t *testing.T) (*fake.Server, int) {
+	s := fake.NewServer()
+	t.Cleanup(s.Close)
+
+	rgId, vinsId, lbId := s.NewID(), s.NewID(), s.NewID()
+	s.Put(fake.KindRG, rgId, fake.Object{"id": rgId, "name": "rg", "status": "CREATED"})
+	s.Put(fake.KindVins, vinsId, fake.Object{"id": vinsId, "name": "vins", "status": "ENABLED"})
+	s.Put(fake.KindLB, lbId, fake.Object{
+		"id":         lbId,
+		"name":       "lb",
+		"rgId":       rgId,
+		"extnetId":   fake.DefaultExtNetID,
+		"vinsId":     vinsId,
+		"desc":       "old",
+		"status":     "ENABLED",
+		"techStatus": "STARTED",
+		"backends": []fake.Object{{
+			"name":                  "web",
+			"algorithm":             "roundrobin",
+			"serverDefaultSettings": fake.Object{},
+			"servers":               []fake.Object{},
+		}},
+		"frontends": []fake.Object{{
+			"name":     "https",
+			"backend":  "web",
+			"bindings": []fake.Object{},
+		}},
+	})
+	return s, lbId
+}
+
+// testResourceData returns resource data planned to change from prior to raw configuration
+func testResourceData(t *testing.T, r *schema.Resource, prior *schema.ResourceData, raw map[string]interface{}, m interface{}) *schema.ResourceData {
+	t.Helper()
+	var state *terraform.InstanceState
+	if prior != nil {
+		state = prior.State()
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), m)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func testLBBackend(t *testing.T, s *fake.Server, lbId int, name string) fake.Object {
+	t.Helper()
+	lb, _ := s.Get(fake.KindLB, lbId)
+	for _, b := range lb["backends"].([]fake.Object) {
+		if b["name"] == name {
+			return b
+		}
+	}
+	t.Fatalf("backend %s not found", name)
+	return nil
+}
+
+func testLBBinding(t *testing.T, s *fake.Server, lbId int) fake.Object {
+	t.Helper()
+	lb, _ := s.Get(fake.KindLB, lbId)
+	bindings := lb["frontends"].([]fake.Object)[0]["bindings"].([]fake.Object)
+	if len(bindings) != 1 {
+		t.Fatalf("frontend has %d bindings, want 1", len(bindings))
+	}
+	return bindings[0]
+}
+
+func TestResourceLBUpdate(t *testing.T) {
+	tests := []struct {
+		name           string
+		enable, start  bool
+		restart        bool
+		configReset    bool
+		wantStatus     string
+		wantTechStatus string
+		wantCalls      map[string]int
+	}{
+		{
+			name:           "disable and stop",
+			wantStatus:     "DISABLED",
+			wantTechStatus: "STOPPED",
+			wantCalls:      map[string]int{"/lb/disable": 1, "/lb/stop": 1, "/lb/restart": 0, "/lb/configReset": 0},
+		},
+		{
+			name:           "restart and reset config",
+			enable:         true,
+			start:          true,
+			restart:        true,
+			configReset:    true,
+			wantStatus:     "ENABLED",
+			wantTechStatus: "STARTED",
+			wantCalls:      map[string]int{"/lb/disable": 0, "/lb/stop": 0, "/lb/restart": 1, "/lb/configReset": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, lbId := testLB(t)
+			m := s.Client()
+			r := ResourceLB()
+
+			lb, _ := s.Get(fake.KindLB, lbId)
+			config := map[string]interface{}{
+				"rg_id":     lb["rgId"],
+				"extnet_id": fake.DefaultExtNetID,
+				"vins_id":   lb["vinsId"],
+				"name":      "lb",
+				"desc":      "old",
+				"start":     true,
+				"enable":    true,
+			}
+			prior := testResourceData(t, r, nil, config, m)
+			prior.SetId(strconv.Itoa(lbId))
+			prior.Set("lb_id", lbId)
+
+			config["desc"] = "new"
+			config["enable"] = tt.enable
+			config["start"] = tt.start
+			config["restart"] = tt.restart
+			config["config_reset"] = tt.configReset
+			d := testResourceData(t, r, prior, config, m)
+
+			if diags := resourceLBUpdate(context.Background(), d, m); diags.HasError() {
+				t.Fatalf("resourceLBUpdate() = %v", diags)
+			}
+
+			lb, _ = s.Get(fake.KindLB, lbId)
+			if lb["desc"] != "new" || lb["status"] != tt.wantStatus || lb["techStatus"] != tt.wantTechStatus {
+				t.Errorf("lb desc = %v, status = %v, tech status = %v, want new, %s, %s", lb["desc"], lb["status"], lb["techStatus"], tt.wantStatus, tt.wantTechStatus)
+			}
+			if s.Calls("/restmachine/cloudapi/lb/update") != 1 {
+				t.Errorf("lb/update called %d times, want 1", s.Calls("/restmachine/cloudapi/lb/update"))
+			}
+			for api, want := range tt.wantCalls {
+				if got := s.Calls("/restmachine/cloudapi" + api); got != want {
+					t.Errorf("%s called %d times, want %d", api, got, want)
+				}
+			}
+			if got := len(lb["backends"].([]fake.Object)); tt.configReset != (got == 0) {
+				t.Errorf("lb has %d backends after config_reset = %v", got, tt.configReset)
+			}
+		})
+	}
+}
+
+// TestResourceLBBackend checks that server settings are applied to backends and servers,
+// and that servers inherit default settings of their backend
+func TestResourceLBBackend(t *testing.T) {
+	s, lbId := testLB(t)
+	m := s.Client()
+	ctx := context.Background()
+
+	backend := map[string]interface{}{
+		"lb_id": lbId,
+		"name":  "api",
+		"inter": 2000,
+		"rise":  3,
+	}
+	r := ResourceLBBackend()
+	d := testResourceData(t, r, nil, backend, m)
+	if diags := resourceLBBackendCreate(ctx, d, m); diags.HasError() {
+		t.Fatalf("resourceLBBackendCreate() = %v", diags)
+	}
+	b := testLBBackend(t, s, lbId, "api")
+	if settings := b["serverDefaultSettings"].(fake.Object); b["algorithm"] != "roundrobin" || settings["inter"] != 2000 || settings["rise"] != 3 {
+		t.Errorf("backend = %v, want roundrobin with inter 2000 and rise 3", b)
+	}
+
+	server := map[string]interface{}{
+		"lb_id":        lbId,
+		"backend_name": "api",
+		"name":         "srv",
+		"address":      "192.168.0.10",
+		"port":         8080,
+	}
+	rs := ResourceLBBackendServer()
+	ds := testResourceData(t, rs, nil, server, m)
+	if diags := resourceLBBackendServerCreate(ctx, ds, m); diags.HasError() {
+		t.Fatalf("resourceLBBackendServerCreate() = %v", diags)
+	}
+	if inter := ds.Get("inter").(int); inter != 2000 {
+		t.Errorf("server inter = %d, want 2000 inherited from backend", inter)
+	}
+
+	server["weight"] = 50
+	server["check"] = "disabled"
+	ds = testResourceData(t, rs, ds, server, m)
+	if diags := resourceLBBackendServerUpdate(ctx, ds, m); diags.HasError() {
+		t.Fatalf("resourceLBBackendServerUpdate() = %v", diags)
+	}
+	srv := testLBBackend(t, s, lbId, "api")["servers"].([]fake.Object)[0]
+	if settings := srv["serverSettings"].(fake.Object); srv["check"] != "disabled" || settings["weight"] != 50 || settings["inter"] != 2000 {
+		t.Errorf("server = %v, want check disabled, weight 50 and inter kept", srv)
+	}
+
+	backend["algorithm"] = "leastconn"
+	backend["fall"] = 5
+	d = testResourceData(t, r, d, backend, m)
+	if diags := resourceLBBackendUpdate(ctx, d, m); diags.HasError() {
+		t.Fatalf("resourceLBBackendUpdate() = %v", diags)
+	}
+	b = testLBBackend(t, s, lbId, "api")
+	if settings := b["serverDefaultSettings"].(fake.Object); b["algorithm"] != "leastconn" || settings["fall"] != 5 || settings["rise"] != 3 {
+		t.Errorf("backend = %v, want leastconn with fall 5 and rise kept", b)
+	}
+
+	if diags := resourceLBBackendServerDelete(ctx, ds, m); diags.HasError() {
+		t.Fatalf("resourceLBBackendServerDelete() = %v", diags)
+	}
+	if diags := resourceLBBackendDelete(ctx, d, m); diags.HasError() {
+		t.Fatalf("resourceLBBackendDelete() = %v", diags)
+	}
+	lb, _ := s.Get(fake.KindLB, lbId)
+	if got := len(lb["backends"].([]fake.Object)); got != 1 {
+		t.Errorf("lb has %d backends, want 1", got)
+	}
+}
+
+func TestResourceLBFrontendBind(t *testing.T) {
+	s, lbId := testLB(t)
+	m := s.Client()
+	ctx := context.Background()
+
+	bind := map[string]interface{}{
+		"lb_id":         lbId,
+		"frontend_name": "https",
+		"name":          "public",
+		"address":       "10.0.0.5",
+		"port":          443,
+	}
+	r := ResourceLBFrontendBind()
+	d := testResourceData(t, r, nil, bind, m)
+	if diags := resourceLBFrontendBindCreate(ctx, d, m); diags.HasError() {
+		t.Fatalf("resourceLBFrontendBindCreate() = %v", diags)
+	}
+	if b := testLBBinding(t, s, lbId); b["address"] != "10.0.0.5" || b["port"] != 443 {
+		t.Errorf("binding = %v, want 10.0.0.5:443", b)
+	}
+
+	bind["port"] = 8443
+	d = testResourceData(t, r, d, bind, m)
+	if diags := resourceLBFrontendBindUpdate(ctx, d, m); diags.HasError() {
+		t.Fatalf("resourceLBFrontendBindUpdate() = %v", diags)
+	}
+	if b := testLBBinding(t, s, lbId); b["address"] != "10.0.0.5" || b["port"] != 8443 {
+		t.Errorf("binding = %v, want address kept and port 8443", b)
+	}
+
+	if diags := resourceLBFrontendBindDelete(ctx, d, m); diags.HasError() {
+		t.Fatalf("resourceLBFrontendBindDelete() = %v", diags)
+	}
+	lb, _ := s.Get(fake.KindLB, lbId)
+	if got := len(lb["frontends"].([]fake.Object)[0]["bindings"].([]fake.Object)); got != 0 {
+		t.Errorf("frontend has %d bindings, want 0", got)
+	}
+}
@@ -1,45 +1,71 @@
-/*
-Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
-Authors:
-Petr Krutov, <petr.krutov@digitalenergy.online>
-Stanislav Solovev, <spsolovev@digitalenergy.online>
-Kasim Baybikov, <kmbaybikov@basistech.ru>
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-/*
-Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
-Orchestration Technology) with Terraform by Hashicorp.
-
-Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
-
-Please see README.md to learn where to place source code so that it
-builds seamlessly.
-
-Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
-*/
-
-package pfw
-
-type PfwRecord struct {
-	ID              int    `json:"id"`
-	LocalIP         string `json:"localIp"`
-	LocalPort       int    `json:"localPort"`
-	Protocol        string `json:"protocol"`
-	PublicPortEnd   int    `json:"publicPortEnd"`
-	PublicPortStart int    `json:"publicPortStart"`
-	ComputeID       int    `json:"vmId"`
-}
-
-type ComputePfwListResp []PfwRecord
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package lb
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	lbsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/lb"
+)
+
+// utilityLBConfigured reports whether a setting is set in the configuration. It selects
+// settings sent on create, while d.HasChange selects them on update.
+func utilityLBConfigured(d *schema.ResourceData) func(key string) bool {
+	return func(key string) bool {
+		_, ok := d.GetOk(key)
+		return ok
+	}
+}
+
+// utilityLBHealthCheckSettings builds server settings of the backend or server request
+// from the settings selected by send.
+func utilityLBHealthCheckSettings(d *schema.ResourceData, send func(key string) bool) lbsdk.HealthCheckSettings {
+	s := lbsdk.HealthCheckSettings{}
+	fields := map[string]**uint64{
+		"inter":     &s.Inter,
+		"downinter": &s.DownInter,
+		"rise":      &s.Rise,
+		"fall":      &s.Fall,
+		"slowstart": &s.SlowStart,
+		"maxconn":   &s.MaxConn,
+		"maxqueue":  &s.MaxQueue,
+		"weight":    &s.Weight,
+	}
+	for key, field := range fields {
+		if send(key) {
+			v := uint64(d.Get(key).(int))
+			*field = &v
+		}
+	}
+
+	return s
+}
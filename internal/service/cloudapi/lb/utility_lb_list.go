@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityLBListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (LBList, error) {
 	lbList := LBList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if includedeleted, ok := d.GetOk("includedeleted"); ok {
@@ -60,7 +59,7 @@ func utilityLBListCheckPresence(ctx context.Context, d *schema.ResourceData, m i
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityLBListCheckPresence: load lb list")
+	log.Debugf(ctx, "utilityLBListCheckPresence: load lb list")
 	lbListRaw, err := c.DecortAPICall(ctx, "POST", lbListAPI, urlValues)
 	if err != nil {
 		return nil, err
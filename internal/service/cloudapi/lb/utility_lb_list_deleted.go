@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +45,7 @@ import (
 
 func utilityLBListDeletedCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (LBList, error) {
 	lbList := LBList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if page, ok := d.GetOk("page"); ok {
@@ -56,7 +55,7 @@ func utilityLBListDeletedCheckPresence(ctx context.Context, d *schema.ResourceDa
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityLBListDeletedCheckPresence: load lb list")
+	log.Debugf(ctx, "utilityLBListDeletedCheckPresence: load lb list")
 	lbListRaw, err := c.DecortAPICall(ctx, "POST", lbListDeletedAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -1,5 +1,7 @@
 package locations
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 /*
 Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
 Authors:
@@ -22,3 +24,5 @@ limitations under the License.
 
 const locationsListAPI = "/restmachine/cloudapi/locations/list"
 const locationURLAPI = "/restmachine/cloudapi/locations/getUrl"
+
+var log = logging.New("locations")
@@ -37,14 +37,13 @@ import (
 	"encoding/json"
 	"net/url"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 func utilityLocationUrlCheckPresence(ctx context.Context, m interface{}) (string, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
-	log.Debugf("utilityLocationUrlCheckPresence: load locations list")
+	log.Debugf(ctx, "utilityLocationUrlCheckPresence: load locations list")
 	locationUrl, err := c.DecortAPICall(ctx, "POST", locationURLAPI, &url.Values{})
 	if err != nil {
 		return "", err
@@ -32,6 +32,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package rg
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const (
 	ResgroupCreateAPI          = "/restmachine/cloudapi/rg/create"
 	ResgroupUpdateAPI          = "/restmachine/cloudapi/rg/update"
@@ -55,3 +57,5 @@ const (
 	RgSetDefNetAPI             = "/restmachine/cloudapi/rg/setDefNet"
 	RgRestoreAPI               = "/restmachine/cloudapi/rg/restore"
 )
+
+var log = logging.New("rg")
@@ -341,8 +341,9 @@ func dataSourceRgSchemaMake() map[string]*schema.Schema {
 			},
 		},
 		"secret": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"status": {
 			Type:     schema.TypeString,
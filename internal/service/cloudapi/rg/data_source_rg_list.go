@@ -168,8 +168,9 @@ func dataSourceRgListSchemaMake() map[string]*schema.Schema {
 						},
 					},
 					"secret": {
-						Type:     schema.TypeString,
-						Computed: true,
+						Type:      schema.TypeString,
+						Sensitive: true,
+						Computed:  true,
 					},
 					"status": {
 						Type:     schema.TypeString,
@@ -162,8 +162,9 @@ func dataSourceRgListDeletedSchemaMake() map[string]*schema.Schema {
 						},
 					},
 					"secret": {
-						Type:     schema.TypeString,
-						Computed: true,
+						Type:      schema.TypeString,
+						Sensitive: true,
+						Computed:  true,
 					},
 					"status": {
 						Type:     schema.TypeString,
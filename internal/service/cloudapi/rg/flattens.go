@@ -33,11 +33,11 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 package rg
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 )
 
 func flattenAccountSeps(seps map[string]map[string]DiskUsage) []map[string]interface{} {
@@ -83,7 +83,7 @@ func flattenRgResources(r Resources) []map[string]interface{} {
 	return res
 }
 
-func flattenResgroup(d *schema.ResourceData, details RecordResourceGroup) error {
+func flattenResgroup(ctx context.Context, d *schema.ResourceData, details RecordResourceGroup) error {
 	// NOTE: this function modifies ResourceData argument - as such it should never be called
 	// from resourceRsgroupExists(...) method
 	// log.Debugf("%s", rg_facts)
@@ -94,7 +94,7 @@ func flattenResgroup(d *schema.ResourceData, details RecordResourceGroup) error
 	//return err
 	//}
 
-	log.Debugf("flattenResgroup: decoded RG name %q / ID %d, account ID %d",
+	log.Debugf(ctx, "flattenResgroup: decoded RG name %q / ID %d, account ID %d",
 		details.Name, details.ID, details.AccountID)
 
 	d.SetId(fmt.Sprintf("%d", details.ID))
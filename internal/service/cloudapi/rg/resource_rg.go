@@ -38,7 +38,6 @@ import (
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
@@ -57,7 +56,7 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(fmt.Errorf("Cannot create new RG: missing name."))
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	/* Current version of provider works with default grid id (same is true for disk resources)
@@ -71,7 +70,7 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 	*/
 
-	log.Debugf("resourceResgroupCreate: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupCreate: called for RG name %s, account ID %d",
 		rgName.(string), d.Get("account_id").(int))
 
 	haveAccount, err := existAccountID(ctx, d, m)
@@ -104,12 +103,12 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 	var quotaRecord QuotaRecord
 	argValue, ok := d.GetOk("quota")
 	if ok {
-		log.Debugf("resourceResgroupCreate: setting Quota on RG requested")
+		log.Debugf(ctx, "resourceResgroupCreate: setting Quota on RG requested")
 		quotaRecord = makeQuotaRecord(argValue.([]interface{}))
 		setQuota = true
 	}
 
-	log.Debugf("resourceResgroupCreate: called by user %q for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupCreate: called by user %q for RG name %s, account ID %d",
 		c.GetDecortUsername(),
 		rgName.(string), d.Get("account_id").(int))
 
@@ -252,10 +251,10 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceResgroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceResgroupRead: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupRead: called for RG name %s, account ID %d",
 		d.Get("name").(string), d.Get("account_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	rg, err := utilityResgroupCheckPresence(ctx, d, m)
 	if err != nil {
@@ -271,6 +270,9 @@ func resourceResgroupRead(ctx context.Context, d *schema.ResourceData, m interfa
 	case status.Created:
 	case status.Enabled:
 	case status.Deleted:
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "Resource group", rg.Status)
+		}
 		urlValues := &url.Values{}
 		urlValues.Add("rgId", d.Id())
 
@@ -287,6 +289,9 @@ func resourceResgroupRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 	case status.Deleting:
 	case status.Destroyed:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "Resource group", rg.Status)
+		}
 		d.SetId("")
 		return resourceResgroupCreate(ctx, d, m)
 	case status.Destroying:
@@ -303,14 +308,14 @@ func resourceResgroupRead(ctx context.Context, d *schema.ResourceData, m interfa
 			return diag.FromErr(err)
 		}
 	}
-	return diag.FromErr(flattenResgroup(d, *rg))
+	return diag.FromErr(flattenResgroup(ctx, d, *rg))
 }
 
 func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceResgroupUpdate: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupUpdate: called for RG name %s, account ID %d",
 		d.Get("name").(string), d.Get("account_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	haveAccount, err := existAccountID(ctx, d, m)
@@ -416,7 +421,7 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	nameNew, nameSet := d.GetOk("name")
 	if nameSet {
-		log.Debugf("resourceResgroupUpdate: name specified - looking for deltas from the old settings.")
+		log.Debugf(ctx, "resourceResgroupUpdate: name specified - looking for deltas from the old settings.")
 		nameOld, _ := d.GetChange("name")
 		if nameOld.(string) != nameNew.(string) {
 			doGeneralUpdate = true
@@ -426,39 +431,39 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	quotaValue, quotaSet := d.GetOk("quota")
 	if quotaSet {
-		log.Debugf("resourceResgroupUpdate: quota specified - looking for deltas from the old quota.")
+		log.Debugf(ctx, "resourceResgroupUpdate: quota specified - looking for deltas from the old quota.")
 		quotarecordNew := makeQuotaRecord(quotaValue.([]interface{}))
 		quotaValueOld, _ := d.GetChange("quota")
 		quotarecordOld := makeQuotaRecord(quotaValueOld.([]interface{}))
-		log.Debug(quotaValueOld, quotarecordNew)
+		log.Debug(ctx, quotaValueOld, quotarecordNew)
 
 		if quotarecordNew.Cpu != quotarecordOld.Cpu {
 			doGeneralUpdate = true
-			log.Debugf("resourceResgroupUpdate: Cpu diff %d <- %d", quotarecordNew.Cpu, quotarecordOld.Cpu)
+			log.Debugf(ctx, "resourceResgroupUpdate: Cpu diff %d <- %d", quotarecordNew.Cpu, quotarecordOld.Cpu)
 			urlValues.Add("maxCPUCapacity", strconv.Itoa(quotarecordNew.Cpu))
 		}
 
 		if quotarecordNew.Disk != quotarecordOld.Disk {
 			doGeneralUpdate = true
-			log.Debugf("resourceResgroupUpdate: Disk diff %d <- %d", quotarecordNew.Disk, quotarecordOld.Disk)
+			log.Debugf(ctx, "resourceResgroupUpdate: Disk diff %d <- %d", quotarecordNew.Disk, quotarecordOld.Disk)
 			urlValues.Add("maxVDiskCapacity", strconv.Itoa(quotarecordNew.Disk))
 		}
 
 		if quotarecordNew.Ram != quotarecordOld.Ram {
 			doGeneralUpdate = true
-			log.Debugf("resourceResgroupUpdate: Ram diff %f <- %f", quotarecordNew.Ram, quotarecordOld.Ram)
+			log.Debugf(ctx, "resourceResgroupUpdate: Ram diff %f <- %f", quotarecordNew.Ram, quotarecordOld.Ram)
 			urlValues.Add("maxMemoryCapacity", fmt.Sprintf("%f", quotarecordNew.Ram))
 		}
 
 		if quotarecordNew.ExtTraffic != quotarecordOld.ExtTraffic {
 			doGeneralUpdate = true
-			log.Debugf("resourceResgroupUpdate: ExtTraffic diff %d <- %d", quotarecordNew.ExtTraffic, quotarecordOld.ExtTraffic)
+			log.Debugf(ctx, "resourceResgroupUpdate: ExtTraffic diff %d <- %d", quotarecordNew.ExtTraffic, quotarecordOld.ExtTraffic)
 			urlValues.Add("maxNetworkPeerTransfer", strconv.Itoa(quotarecordNew.ExtTraffic))
 		}
 
 		if quotarecordNew.ExtIPs != quotarecordOld.ExtIPs {
 			doGeneralUpdate = true
-			log.Debugf("resourceResgroupUpdate: ExtIPs diff %d <- %d", quotarecordNew.ExtIPs, quotarecordOld.ExtIPs)
+			log.Debugf(ctx, "resourceResgroupUpdate: ExtIPs diff %d <- %d", quotarecordNew.ExtIPs, quotarecordOld.ExtIPs)
 			urlValues.Add("maxNumPublicIP", strconv.Itoa(quotarecordNew.ExtIPs))
 		}
 	} else {
@@ -472,7 +477,7 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	descNew, descSet := d.GetOk("description")
 	if descSet {
-		log.Debugf("resourceResgroupUpdate: description specified - looking for deltas from the old settings.")
+		log.Debugf(ctx, "resourceResgroupUpdate: description specified - looking for deltas from the old settings.")
 		descOld, _ := d.GetChange("description")
 		if descOld.(string) != descNew.(string) {
 			doGeneralUpdate = true
@@ -481,13 +486,13 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if doGeneralUpdate {
-		log.Debugf("resourceResgroupUpdate: detected delta between new and old RG specs - updating the RG")
+		log.Debugf(ctx, "resourceResgroupUpdate: detected delta between new and old RG specs - updating the RG")
 		_, err := c.DecortAPICall(ctx, "POST", ResgroupUpdateAPI, urlValues)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
-		log.Debugf("resourceResgroupUpdate: no difference between old and new state - no update on the RG will be done")
+		log.Debugf(ctx, "resourceResgroupUpdate: no difference between old and new state - no update on the RG will be done")
 	}
 
 	urlValues = &url.Values{}
@@ -584,10 +589,10 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 func resourceResgroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// NOTE: this method forcibly destroys target resource group with flag "permanently", so there is no way to
 	// restore the destroyed resource group as well all Computes & VINSes that existed in it
-	log.Debugf("resourceResgroupDelete: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupDelete: called for RG name %s, account ID %d",
 		d.Get("name").(string), d.Get("account_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("rgId", d.Id())
@@ -815,8 +820,9 @@ func ResourceRgSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"secret": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"status": {
 			Type:        schema.TypeString,
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package rg_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/acctest"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/rg"
+)
+
+const testResgroupConfig = `
+resource "decort_resgroup" "rg" {
+  account_id = %d
+  gid        = %d
+  name       = %q
+}
+`
+
+func TestResourceResgroup(t *testing.T) {
+	s := acctest.NewServer(t)
+	config := func(name string) string {
+		return acctest.Config(s, testResgroupConfig, fake.DefaultAccountID, fake.DefaultGridID, name)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckDestroyed(s, fake.KindRG, "decort_resgroup"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("rg-test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_resgroup.rg", "name", "rg-test"),
+					acctest.CheckObject(s, fake.KindRG, "decort_resgroup.rg", "name", "rg-test"),
+				),
+			},
+			{
+				Config: config("rg-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_resgroup.rg", "name", "rg-renamed"),
+					acctest.CheckObject(s, fake.KindRG, "decort_resgroup.rg", "name", "rg-renamed"),
+				),
+			},
+			{
+				ResourceName:            "decort_resgroup.rg",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"enable", "ext_net_id", "force", "permanently"},
+			},
+		},
+	})
+}
+
+// TestResourceResgroupHandlers runs the steps of TestResourceResgroup through the resource
+// handlers, so that they are covered without Terraform CLI
+func TestResourceResgroupHandlers(t *testing.T) {
+	s := acctest.NewServer(t)
+	m := s.Client()
+	ctx := context.Background()
+	r := rg.ResourceResgroup()
+
+	config := map[string]interface{}{
+		"account_id": fake.DefaultAccountID,
+		"gid":        fake.DefaultGridID,
+		"name":       "rg-test",
+	}
+	d := acctest.ResourceData(t, r, nil, config, m)
+	if diags := r.CreateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("CreateContext() = %v", diags)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		t.Fatalf("resource ID %q: %v", d.Id(), err)
+	}
+	if obj, ok := s.Get(fake.KindRG, id); !ok || obj["name"] != "rg-test" {
+		t.Fatalf("rg %d = %v, want rg-test", id, obj)
+	}
+
+	config["name"] = "rg-renamed"
+	d = acctest.ResourceData(t, r, d, config, m)
+	if diags := r.UpdateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("UpdateContext() = %v", diags)
+	}
+	if obj, _ := s.Get(fake.KindRG, id); obj["name"] != "rg-renamed" {
+		t.Errorf("rg name = %v, want rg-renamed", obj["name"])
+	}
+
+	imported := acctest.Imported(r, d.Id())
+	if diags := r.ReadContext(ctx, imported, m); diags.HasError() {
+		t.Fatalf("ReadContext() = %v", diags)
+	}
+	for _, key := range []string{"name", "account_id", "gid"} {
+		if got, want := imported.Get(key), d.Get(key); got != want {
+			t.Errorf("imported %s = %v, want %v", key, got, want)
+		}
+	}
+
+	if diags := r.DeleteContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("DeleteContext() = %v", diags)
+	}
+	if obj, ok := s.Get(fake.KindRG, id); ok && obj["status"] != "DELETED" && obj["status"] != "DESTROYED" {
+		t.Errorf("rg status = %v after delete", obj["status"])
+	}
+}
@@ -34,11 +34,11 @@ package rg
 
 import (
 	"context"
-	"encoding/json"
-	"net/url"
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	rgsdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/rg"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -63,50 +63,30 @@ func utilityResgroupCheckPresence(ctx context.Context, d *schema.ResourceData, m
 	// method for the Terraform resource Exists method.
 	//
 
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-
+	req := rgsdk.GetRequest{}
 	if d.Id() != "" {
-		urlValues.Add("rgId", d.Id())
+		rgId, err := strconv.ParseUint(d.Id(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req.RGID = rgId
 	} else {
-		urlValues.Add("rgId", strconv.Itoa(d.Get("rg_id").(int)))
+		req.RGID = uint64(d.Get("rg_id").(int))
 	}
 	if reason, ok := d.GetOk("reason"); ok {
-		urlValues.Add("reason", reason.(string))
-	}
-
-	rgData := &RecordResourceGroup{}
-	rgRaw, err := c.DecortAPICall(ctx, "POST", ResgroupGetAPI, urlValues)
-	if err != nil {
-		return nil, err
+		req.Reason = reason.(string)
 	}
 
-	err = json.Unmarshal([]byte(rgRaw), rgData)
-	if err != nil {
-		return nil, err
-	}
-	return rgData, nil
+	return sdk.New(m.(controller.APICaller)).RG().Get(ctx, req)
 }
 
 func utilityDataResgroupCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*RecordResourceGroup, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	rgData := &RecordResourceGroup{}
-
-	urlValues.Add("rgId", strconv.Itoa(d.Get("rg_id").(int)))
-
-	if reason, ok := d.GetOk("reason"); ok {
-		urlValues.Add("reason", reason.(string))
+	req := rgsdk.GetRequest{
+		RGID: uint64(d.Get("rg_id").(int)),
 	}
-
-	rgRaw, err := c.DecortAPICall(ctx, "POST", ResgroupGetAPI, urlValues)
-	if err != nil {
-		return nil, err
+	if reason, ok := d.GetOk("reason"); ok {
+		req.Reason = reason.(string)
 	}
 
-	err = json.Unmarshal([]byte(rgRaw), rgData)
-	if err != nil {
-		return nil, err
-	}
-	return rgData, nil
+	return sdk.New(m.(controller.APICaller)).RG().Get(ctx, req)
 }
@@ -43,7 +43,7 @@ import (
 )
 
 func utilityRgAffinityGroupComputesCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ListAffinityGroupCompute, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 	listGroupComputes := ListAffinityGroupCompute{}
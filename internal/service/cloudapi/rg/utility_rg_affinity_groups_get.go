@@ -43,7 +43,7 @@ import (
 )
 
 func utilityRgAffinityGroupsGetCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) ([]uint64, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 	computes := make([]uint64, 0)
@@ -43,7 +43,7 @@ import (
 )
 
 func utilityRgAffinityGroupsListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (map[string][]uint64, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 	groups := make(map[string][]uint64, 0)
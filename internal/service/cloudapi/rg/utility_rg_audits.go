@@ -43,7 +43,7 @@ import (
 )
 
 func utilityRgAuditsCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ListAudits, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 	rgAudits := ListAudits{}
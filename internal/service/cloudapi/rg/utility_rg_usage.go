@@ -43,7 +43,7 @@ import (
 )
 
 func utilityDataRgUsageCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*Resource, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := url.Values{}
 	usage := Resource{}
 
@@ -32,7 +32,11 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package snapshot
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const snapshotCreateAPI = "/restmachine/cloudapi/compute/snapshotCreate"
 const snapshotDeleteAPI = "/restmachine/cloudapi/compute/snapshotDelete"
 const snapshotRollbackAPI = "/restmachine/cloudapi/compute/snapshotRollback"
 const snapshotListAPI = "/restmachine/cloudapi/compute/snapshotList"
+
+var log = logging.New("snapshot")
@@ -40,15 +40,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceSnapshotCreate: called for snapshot %s", d.Get("label").(string))
+	log.Debugf(ctx, "resourceSnapshotCreate: called for snapshot %s", d.Get("label").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("label", d.Get("label").(string))
 	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
@@ -86,9 +85,9 @@ func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceSnapshotDelete: called for %s, id: %s", d.Get("label").(string), d.Id())
+	log.Debugf(ctx, "resourceSnapshotDelete: called for %s, id: %s", d.Get("label").(string), d.Id())
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
 	urlValues.Add("label", d.Get("label").(string))
@@ -116,7 +115,7 @@ func resourceSnapshotEdit(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceSnapshotRollback(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
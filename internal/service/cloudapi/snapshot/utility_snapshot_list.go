@@ -43,7 +43,7 @@ import (
 )
 
 func utilitySnapshotListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (SnapshotList, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
 
@@ -32,12 +32,16 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package vins
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const (
 	VinsAuditsAPI           = "/restmachine/cloudapi/vins/audits"
 	VinsCreateInAccountAPI  = "/restmachine/cloudapi/vins/createInAccount"
 	VinsCreateInRgAPI       = "/restmachine/cloudapi/vins/createInRG"
 	VinsDeleteAPI           = "/restmachine/cloudapi/vins/delete"
+	VinsDhcpConfigureAPI    = "/restmachine/cloudapi/vins/dhcpConfigure"
 	VinsDisableAPI          = "/restmachine/cloudapi/vins/disable"
+	VinsDnsApplyAPI         = "/restmachine/cloudapi/vins/dnsApply"
 	VinsEnableAPI           = "/restmachine/cloudapi/vins/enable"
 	VinsExtNetConnectAPI    = "/restmachine/cloudapi/vins/extNetConnect"
 	VinsExtNetDisconnectAPI = "/restmachine/cloudapi/vins/extNetDisconnect"
@@ -51,8 +55,11 @@ const (
 	VinsNatRuleAddAPI       = "/restmachine/cloudapi/vins/natRuleAdd"
 	VinsNatRuleDelAPI       = "/restmachine/cloudapi/vins/natRuleDel"
 	VinsNatRuleListAPI      = "/restmachine/cloudapi/vins/natRuleList"
+	VinsNetQosAPI           = "/restmachine/cloudapi/vins/netQos"
 	VinsRestoreAPI          = "/restmachine/cloudapi/vins/restore"
 	VinsSearchAPI           = "/restmachine/cloudapi/vins/search"
 	VinsVnfdevRedeployAPI   = "/restmachine/cloudapi/vins/vnfdevRedeploy"
 	VinsVnfdevRestartAPI    = "/restmachine/cloudapi/vins/vnfdevRestart"
 )
+
+var log = logging.New("vins")
@@ -59,8 +59,9 @@ func vnfConfigMGMTSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"password": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"ssh_key": {
 			Type:     schema.TypeString,
@@ -366,6 +367,10 @@ func dhcpConfigSchemaMake() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 		},
+		"domain": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		"ip_end": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -174,6 +174,7 @@ func flattenDHCPConfig(config DHCPConfig) []map[string]interface{} {
 	temp := map[string]interface{}{
 		"default_gw":   config.DefaultGW,
 		"dns":          config.DNS,
+		"domain":       config.Domain,
 		"ip_end":       config.IPEnd,
 		"ip_start":     config.IPStart,
 		"lease":        config.Lease,
@@ -385,6 +386,13 @@ func flattenVins(d *schema.ResourceData, vins VINSDetailed) {
 	d.Set("vnfs", flattenVNFS(vins.VNFS))
 	d.Set("vxlan_id", vins.VXLanID)
 	d.Set("nat_rule", flattenRuleBlock(vins.VNFS.NAT.Config.Rules))
+	d.Set("dhcp", flattenDHCPBlock(vins.VNFS.DHCP.Config))
+	d.Set("qos", flattenQOSBlock(vins.DefaultQOS))
+	// external_connection is only read back when it is managed, so that imported ViNS or
+	// connection made with extnet v1 or v2 arguments do not plan its removal
+	if len(d.Get("external_connection").([]interface{})) > 0 {
+		d.Set("external_connection", flattenExternalConnection(vins.VNFS.GW.Config))
+	}
 }
 
 func flattenVinsData(d *schema.ResourceData, vins VINSDetailed) {
@@ -34,14 +34,14 @@ package vins
 
 import (
 	"context"
-	"fmt"
 	"net/url"
 	"strconv"
 
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -50,7 +50,7 @@ import (
 )
 
 func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if _, ok := d.GetOk("rg_id"); ok {
@@ -116,15 +116,6 @@ func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface
 			urlValues.Add("extIp", extIp.(string))
 		}
 
-		//extnet v2
-		if extNetResp, ok := d.GetOk("ext_net"); ok {
-			extNetSl := extNetResp.([]interface{})
-			extNet := extNetSl[0].(map[string]interface{})
-			urlValues.Add("vinsId", d.Id())
-			urlValues.Add("netId", strconv.Itoa(extNet["ext_net_id"].(int)))
-			urlValues.Add("extIp", extNet["ext_net_ip"].(string))
-		}
-
 		if desc, ok := d.GetOk("desc"); ok {
 			urlValues.Add("desc", desc.(string))
 		}
@@ -155,6 +146,31 @@ func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	warnings := dc.Warnings{}
+
+	// external_connection and extnet v2 are connected once ViNS exists, the same way for
+	// ViNSes in resource group and in account
+	if conn, ok := d.GetOk("external_connection"); ok {
+		extNet := conn.([]interface{})[0].(map[string]interface{})
+		if err := utilityVinsExtNetConnect(ctx, d, m, uint64(extNet["ext_net_id"].(int)), extNet["ext_ip"].(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if extNetResp, ok := d.GetOk("ext_net"); ok {
+		extNet := extNetResp.([]interface{})[0].(map[string]interface{})
+		if err := utilityVinsExtNetConnect(ctx, d, m, uint64(extNet["ext_net_id"].(int)), extNet["ext_net_ip"].(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := utilityVinsDHCPConfigure(ctx, d, m, true); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := utilityVinsQOSConfigure(ctx, d, m, true); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := utilityVinsStaticRoutesConfigure(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
 	urlValues = &url.Values{}
 	if ipRes, ok := d.GetOk("ip"); ok {
 		ipsSlice := ipRes.([]interface{})
@@ -207,7 +223,7 @@ func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	warnings := dc.Warnings{}
 
 	vins, err := utilityVinsCheckPresence(ctx, d, m)
@@ -222,10 +238,16 @@ func resourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	switch vins.Status {
 	case status.Destroyed:
+		if controller.DriftPolicyOf(m) != controller.DriftRecreate {
+			return dc.Gone(d, "ViNS", vins.Status)
+		}
 		d.SetId("")
 		d.Set("vins_id", 0)
 		return resourceVinsCreate(ctx, d, m)
 	case status.Deleted:
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return dc.Gone(d, "ViNS", vins.Status)
+		}
 		hasChangeState = true
 		urlValues := &url.Values{}
 
@@ -239,6 +261,11 @@ func resourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.Errorf("ViNS are in status: %s, please, contact support for more information", vins.Status)
 	case status.Created:
 	case status.Enabled:
+		if !isEnabled && controller.DriftPolicyOf(m) == controller.DriftReport {
+			// keep actual state, so that the drift is shown by the plan and fixed by Update
+			d.Set("enable", true)
+			break
+		}
 		if !isEnabled {
 			hasChangeState = true
 			urlValues := &url.Values{}
@@ -252,6 +279,11 @@ func resourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		}
 	case status.Enabling:
 	case status.Disabled:
+		if isEnabled && controller.DriftPolicyOf(m) == controller.DriftReport {
+			// keep actual state, so that the drift is shown by the plan and fixed by Update
+			d.Set("enable", false)
+			break
+		}
 		if isEnabled {
 			hasChangeState = true
 			urlValues := &url.Values{}
@@ -277,6 +309,9 @@ func resourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 
 	flattenVins(d, *vins)
+	if err := utilityVinsStaticRoutesRead(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
 
 	return warnings.Get()
 }
@@ -306,9 +341,6 @@ func isContinsNatRule(els []interface{}, el interface{}) bool {
 }
 
 func resourceVinsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
-	warnings := dc.Warnings{}
-
 	if _, ok := d.GetOk("rg_id"); ok {
 		haveRGID, err := existRGID(ctx, d, m)
 		if err != nil {
@@ -363,19 +395,18 @@ func resourceVinsUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	hasChangeState := false
 
+	vinsId, _ := strconv.ParseUint(d.Id(), 10, 64)
+	api := sdk.New(m.(controller.APICaller)).Vins()
+	idReq := vinssdk.IDRequest{VinsID: vinsId}
+
 	switch vins.Status {
 	case status.Destroyed:
 		d.SetId("")
 		return resourceVinsCreate(ctx, d, m)
 	case status.Deleted:
 		hasChangeState = true
-		urlValues := &url.Values{}
-
-		urlValues.Add("vinsId", d.Id())
-
-		_, err := c.DecortAPICall(ctx, "POST", VinsRestoreAPI, urlValues)
-		if err != nil {
-			warnings.Add(err)
+		if _, err := api.Restore(ctx, idReq); err != nil {
+			return diag.FromErr(err)
 		}
 	case status.Modeled:
 		return diag.Errorf("ViNS are in status: %s, please, contact support for more information", vins.Status)
@@ -383,26 +414,16 @@ func resourceVinsUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	case status.Enabled:
 		if !isEnabled {
 			hasChangeState = true
-			urlValues := &url.Values{}
-
-			urlValues.Add("vinsId", d.Id())
-
-			_, err := c.DecortAPICall(ctx, "POST", VinsDisableAPI, urlValues)
-			if err != nil {
-				warnings.Add(err)
+			if _, err := api.Disable(ctx, idReq); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	case status.Enabling:
 	case status.Disabled:
 		if isEnabled {
 			hasChangeState = true
-			urlValues := &url.Values{}
-
-			urlValues.Add("vinsId", d.Id())
-
-			_, err := c.DecortAPICall(ctx, "POST", VinsEnableAPI, urlValues)
-			if err != nil {
-				warnings.Add(err)
+			if _, err := api.Enable(ctx, idReq); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	case status.Disabling:
@@ -417,211 +438,179 @@ func resourceVinsUpdate(ctx context.Context, d *schema.ResourceData, m interface
 			return diag.FromErr(err)
 		}
 	}
-	urlValues := &url.Values{}
+
+	// ViNS is read back even if a change fails, so that the state reflects changes applied so far
+	defer resourceVinsRead(ctx, d, m)
 
 	enableOld, enableNew := d.GetChange("enable")
 	if enableOld.(bool) && !enableNew.(bool) {
-		if !urlValues.Has("vinsId") {
-			urlValues.Add("vinsId", d.Id())
-		}
-		_, err := c.DecortAPICall(ctx, "POST", VinsDisableAPI, urlValues)
-		if err != nil {
-			warnings.Add(err)
+		if _, err := api.Disable(ctx, idReq); err != nil {
+			return diag.FromErr(err)
 		}
 	} else if !enableOld.(bool) && enableNew.(bool) {
-		if !urlValues.Has("vinsId") {
-			urlValues.Add("vinsId", d.Id())
+		if _, err := api.Enable(ctx, idReq); err != nil {
+			return diag.FromErr(err)
 		}
-		_, err := c.DecortAPICall(ctx, "POST", VinsEnableAPI, urlValues)
-		if err != nil {
-			warnings.Add(err)
+	}
+
+	// ViNS can only be connected to one external network at a time, so that it is disconnected
+	// first and connected to the new network after extnet v1 changes are applied
+	oldConn, newConn := d.GetChange("external_connection")
+	if d.HasChange("external_connection") && len(oldConn.([]interface{})) > 0 {
+		if err := utilityVinsExtNetDisconnect(ctx, d, m); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
 	//extnet v1
 	oldExtNetId, newExtNedId := d.GetChange("ext_net_id")
 	if oldExtNetId.(int) != newExtNedId.(int) {
-		log.Debugf("resourceVinsUpdate: changing ViNS ID %s - ext_net_id %d -> %d", d.Id(), oldExtNetId.(int), newExtNedId.(int))
-
-		extnetParams := &url.Values{}
-		extnetParams.Add("vinsId", d.Id())
+		log.Debugf(ctx, "resourceVinsUpdate: changing ViNS ID %s - ext_net_id %d -> %d", d.Id(), oldExtNetId.(int), newExtNedId.(int))
 
 		if oldExtNetId.(int) > 0 {
 			// there was preexisting external net connection - disconnect ViNS
-			_, err := c.DecortAPICall(ctx, "POST", VinsExtNetDisconnectAPI, extnetParams)
-			if err != nil {
-				warnings.Add(err)
+			if err := utilityVinsExtNetDisconnect(ctx, d, m); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		if newExtNedId.(int) > 0 {
 			// new external network connection requested - connect ViNS
-			extnetParams.Add("netId", fmt.Sprintf("%d", newExtNedId.(int)))
-			extNetIp, ok := d.GetOk("ext_net_ip")
-			if ok && extNetIp.(string) != "" {
-				urlValues.Add("Ip", extNetIp.(string))
-			}
-			_, err := c.DecortAPICall(ctx, "POST", VinsExtNetConnectAPI, extnetParams)
-			if err != nil {
-				warnings.Add(err)
+			if err := utilityVinsExtNetConnect(ctx, d, m, uint64(newExtNedId.(int)), d.Get("ext_ip_addr").(string)); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
 
-	urlValues = &url.Values{}
-	if d.HasChange("ip") {
-		deletedIps := make([]interface{}, 0)
-		addedIps := make([]interface{}, 0)
+	if newList := newConn.([]interface{}); d.HasChange("external_connection") && len(newList) > 0 {
+		extNet := newList[0].(map[string]interface{})
+		if err := utilityVinsExtNetConnect(ctx, d, m, uint64(extNet["ext_net_id"].(int)), extNet["ext_ip"].(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("static_route") {
+		if err := utilityVinsStaticRoutesConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("dhcp") {
+		if err := utilityVinsDHCPConfigure(ctx, d, m, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
+	if d.HasChange("qos") {
+		if err := utilityVinsQOSConfigure(ctx, d, m, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("ip") {
 		oldIpInterface, newIpInterface := d.GetChange("ip")
 		oldIpSlice := oldIpInterface.([]interface{})
 		newIpSlice := newIpInterface.([]interface{})
 
 		for _, el := range oldIpSlice {
-			if !isContainsIp(newIpSlice, el) {
-				deletedIps = append(deletedIps, el)
+			if isContainsIp(newIpSlice, el) {
+				continue
 			}
-		}
-
-		for _, el := range newIpSlice {
-			if !isContainsIp(oldIpSlice, el) {
-				addedIps = append(addedIps, el)
+			ip := el.(map[string]interface{})
+			_, err := api.IPRelease(ctx, vinssdk.IPReleaseRequest{
+				VinsID: vinsId,
+				IPAddr: ip["ip_addr"].(string),
+				MAC:    ip["mac_addr"].(string),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
-		if len(deletedIps) > 0 {
-			for _, ipInterfase := range deletedIps {
-				urlValues = &url.Values{}
-				ip := ipInterfase.(map[string]interface{})
-
-				urlValues.Add("vinsId", d.Id())
-				if ip["ip_addr"].(string) != "" {
-					urlValues.Add("ipAddr", ip["ip_addr"].(string))
-				}
-				if ip["mac_addr"].(string) != "" {
-					urlValues.Add("mac", ip["mac_addr"].(string))
-				}
-				_, err := c.DecortAPICall(ctx, "POST", VinsIpReleaseAPI, urlValues)
-				if err != nil {
-					warnings.Add(err)
-				}
+		for _, el := range newIpSlice {
+			if isContainsIp(oldIpSlice, el) {
+				continue
 			}
-		}
-
-		if len(addedIps) > 0 {
-			for _, ipInterfase := range addedIps {
-				urlValues = &url.Values{}
-				ip := ipInterfase.(map[string]interface{})
-
-				urlValues.Add("vinsId", d.Id())
-				urlValues.Add("type", ip["type"].(string))
-				if ip["ip_addr"].(string) != "" {
-					urlValues.Add("ipAddr", ip["ip_addr"].(string))
-				}
-				if ip["mac_addr"].(string) != "" {
-					urlValues.Add("mac", ip["mac_addr"].(string))
-				}
-				if ip["compute_id"].(int) != 0 {
-					urlValues.Add("computeId", strconv.Itoa(ip["compute_id"].(int)))
-				}
-				_, err := c.DecortAPICall(ctx, "POST", VinsIpReserveAPI, urlValues)
-				if err != nil {
-					warnings.Add(err)
-				}
+			ip := el.(map[string]interface{})
+			_, err := api.IPReserve(ctx, vinssdk.IPReserveRequest{
+				VinsID:    vinsId,
+				Type:      ip["type"].(string),
+				IPAddr:    ip["ip_addr"].(string),
+				MAC:       ip["mac_addr"].(string),
+				ComputeID: uint64(ip["compute_id"].(int)),
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	if d.HasChange("nat_rule") {
-		deletedNatRules := make([]interface{}, 0)
-		addedNatRules := make([]interface{}, 0)
-
 		oldNatRulesInterface, newNatRulesInterface := d.GetChange("nat_rule")
 		oldNatRulesSlice := oldNatRulesInterface.([]interface{})
 		newNatRulesSlice := newNatRulesInterface.([]interface{})
 
 		for _, el := range oldNatRulesSlice {
-			if !isContinsNatRule(newNatRulesSlice, el) {
-				deletedNatRules = append(deletedNatRules, el)
+			if isContinsNatRule(newNatRulesSlice, el) {
+				continue
+			}
+			natRule := el.(map[string]interface{})
+			log.Debugf(ctx, "resourceVinsUpdate: deleting NAT rule ID %d of ViNS ID %s", natRule["rule_id"].(int), d.Id())
+			_, err := api.NATRuleDel(ctx, vinssdk.NATRuleDelRequest{VinsID: vinsId, RuleID: int64(natRule["rule_id"].(int))})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		for _, el := range newNatRulesSlice {
-			if !isContinsNatRule(oldNatRulesSlice, el) {
-				addedNatRules = append(addedNatRules, el)
+			if isContinsNatRule(oldNatRulesSlice, el) {
+				continue
 			}
-		}
-
-		if len(deletedNatRules) > 0 {
-			for _, natRuleInterface := range deletedNatRules {
-				urlValues = &url.Values{}
-				natRule := natRuleInterface.(map[string]interface{})
-
-				urlValues.Add("vinsId", d.Id())
-				urlValues.Add("ruleId", strconv.Itoa(natRule["rule_id"].(int)))
-				log.Debug("NAT_RULE_DEL_WITH: ", urlValues.Encode())
-				_, err := c.DecortAPICall(ctx, "POST", VinsNatRuleDelAPI, urlValues)
-				if err != nil {
-					warnings.Add(err)
-				}
+			natRule := el.(map[string]interface{})
+			req := vinssdk.NATRuleAddRequest{
+				VinsID:       vinsId,
+				IntIP:        natRule["int_ip"].(string),
+				IntPort:      uint64(natRule["int_port"].(int)),
+				ExtPortStart: uint64(natRule["ext_port_start"].(int)),
+				ExtPortEnd:   uint64(natRule["ext_port_end"].(int)),
+				Proto:        natRule["proto"].(string),
 			}
-		}
-
-		if len(addedNatRules) > 0 {
-			for _, natRuleInterface := range addedNatRules {
-				urlValues = &url.Values{}
-				natRule := natRuleInterface.(map[string]interface{})
-
-				urlValues.Add("vinsId", d.Id())
-				urlValues.Add("intIp", natRule["int_ip"].(string))
-				urlValues.Add("intPort", strconv.Itoa(natRule["int_port"].(int)))
-				urlValues.Add("extPortStart", strconv.Itoa(natRule["ext_port_start"].(int)))
-				if natRule["ext_port_end"].(int) != 0 {
-					urlValues.Add("extPortEnd", strconv.Itoa(natRule["ext_port_end"].(int)))
-				}
-				if natRule["proto"].(string) != "" {
-					urlValues.Add("proto", natRule["proto"].(string))
-				}
-
-				log.Debug("NAT_RULE_ADD_WITH: ", urlValues.Encode())
-				_, err := c.DecortAPICall(ctx, "POST", VinsNatRuleAddAPI, urlValues)
-				if err != nil {
-					warnings.Add(err)
-				}
+			log.Debugf(ctx, "resourceVinsUpdate: adding NAT rule %+v", req)
+			if _, err := api.NATRuleAdd(ctx, req); err != nil {
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	if oldRestart, newRestart := d.GetChange("vnfdev_restart"); oldRestart == false && newRestart == true {
-		urlValues = &url.Values{}
-		urlValues.Add("vinsId", d.Id())
-		_, err := c.DecortAPICall(ctx, "POST", VinsVnfdevRestartAPI, urlValues)
-		if err != nil {
-			warnings.Add(err)
+		if _, err := api.VnfdevRestart(ctx, idReq); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
 	if oldRedeploy, newRedeploy := d.GetChange("vnfdev_redeploy"); oldRedeploy == false && newRedeploy == true {
-		urlValues = &url.Values{}
-		urlValues.Add("vinsId", d.Id())
-		_, err := c.DecortAPICall(ctx, "POST", VinsVnfdevRedeployAPI, urlValues)
-		if err != nil {
-			warnings.Add(err)
+		if _, err := api.VnfdevRedeploy(ctx, idReq); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	defer resourceVinsRead(ctx, d, m)
-	return warnings.Get()
+	return nil
 }
 
-func resourceVinsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	urlValues := &url.Values{}
-	c := m.(*controller.ControllerCfg)
+// resourceVinsImport sets "enable" to its default, as Read enables or disables ViNS according to it
+// and would otherwise take it for false and disable the imported ViNS
+func resourceVinsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("enable", true)
+	return []*schema.ResourceData{d}, nil
+}
 
-	urlValues.Add("vinsId", d.Id())
-	urlValues.Add("force", strconv.FormatBool(d.Get("force").(bool)))
-	urlValues.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
-	_, err := c.DecortAPICall(ctx, "POST", VinsDeleteAPI, urlValues)
+func resourceVinsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	vinsId, _ := strconv.ParseUint(d.Id(), 10, 64)
+	_, err := sdk.New(m.(controller.APICaller)).Vins().Delete(ctx, vinssdk.DeleteRequest{
+		VinsID:      vinsId,
+		Force:       d.Get("force").(bool),
+		Permanently: d.Get("permanently").(bool),
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -636,7 +625,7 @@ func extNetSchemaMake() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"ext_net_ip": {
-			Type:     schema.TypeInt,
+			Type:     schema.TypeString,
 			Optional: true,
 			Default:  "",
 		},
@@ -716,14 +705,16 @@ func resourceVinsSchemaMake() map[string]*schema.Schema {
 		Computed: true,
 	}
 	rets["ext_net_id"] = &schema.Schema{
-		Type:     schema.TypeInt,
-		Optional: true,
-		Default:  -1,
+		Type:          schema.TypeInt,
+		Optional:      true,
+		Default:       -1,
+		ConflictsWith: []string{"external_connection"},
 	}
 	rets["ext_ip_addr"] = &schema.Schema{
-		Type:     schema.TypeString,
-		Optional: true,
-		Default:  "",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Default:       "",
+		ConflictsWith: []string{"external_connection"},
 	}
 	rets["ipcidr"] = &schema.Schema{
 		Type:     schema.TypeString,
@@ -755,12 +746,32 @@ func resourceVinsSchemaMake() map[string]*schema.Schema {
 		Default:  false,
 	}
 	rets["ext_net"] = &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"external_connection"},
+		Deprecated:    "use external_connection block instead",
+		Elem: &schema.Resource{
+			Schema: extNetSchemaMake(),
+		},
+	}
+	rets["external_connection"] = &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
 		MaxItems: 1,
 		Elem: &schema.Resource{
-			Schema: extNetSchemaMake(),
+			Schema: externalConnectionSchemaMake(),
+		},
+		Description: "Connection of this ViNS to an external network. Changing it moves ViNS to another external network without recreating it, removing it disconnects ViNS. It is only read back while the block is present, so that connection of imported ViNS is left intact until the block is added.",
+	}
+	rets["static_route"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Set:      hashStaticRoute,
+		Elem: &schema.Resource{
+			Schema: staticRouteSchemaMake(),
 		},
+		Description: "Static routes of this ViNS, e.g. to networks of other ViNSes.",
 	}
 	rets["ip"] = &schema.Schema{
 		Type:     schema.TypeList,
@@ -772,9 +783,31 @@ func resourceVinsSchemaMake() map[string]*schema.Schema {
 	rets["nat_rule"] = &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
+		Computed: true, // rules may be managed by decort_vins_nat_rule resources instead
 		Elem: &schema.Resource{
 			Schema: natRuleSchemaMake(),
 		},
+		Description: "NAT rules of this ViNS, which forward ports of its external IP address to computes. Rules removed from the configuration are deleted and changed rules are recreated. Without nat_rule blocks actual rules of the ViNS are only read back and left intact.",
+	}
+	rets["dhcp"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: dhcpResourceSchemaMake(),
+		},
+		Description: "DHCP options of this ViNS, applied through dnsApply and dhcpConfigure ViNS API of the controller, which must support them. Options, which are not set, keep their actual values, except for domain and default_gw, which are reset.",
+	}
+	rets["qos"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: qosResourceSchemaMake(),
+		},
+		Description: "Default QoS limits of this ViNS, applied through netQos ViNS API of the controller, which must support it. Limits, which are not set, keep their actual values.",
 	}
 	rets["desc"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -817,7 +850,7 @@ func ResourceVins() *schema.Resource {
 		DeleteContext: resourceVinsDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceVinsImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
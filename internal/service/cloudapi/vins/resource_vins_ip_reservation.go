@@ -0,0 +1,196 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/statefuncs"
+)
+
+func resourceVinsIpReservationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	vinsId := uint64(d.Get("vins_id").(int))
+	log.Debugf(ctx, "resourceVinsIpReservationCreate: reserve %s IP in ViNS ID %d", d.Get("type").(string), vinsId)
+
+	ip, err := sdk.New(m.(controller.APICaller)).Vins().IPReserve(ctx, vinssdk.IPReserveRequest{
+		VinsID:    vinsId,
+		Type:      strings.ToUpper(d.Get("type").(string)),
+		IPAddr:    d.Get("ip_addr").(string),
+		MAC:       d.Get("mac_addr").(string),
+		ComputeID: uint64(d.Get("compute_id").(int)),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(utilityVinsIpReservationMakeId(vinsId, ip))
+	log.Debugf(ctx, "resourceVinsIpReservationCreate: reserved IP %s", d.Id())
+
+	return resourceVinsIpReservationRead(ctx, d, m)
+}
+
+func resourceVinsIpReservationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceVinsIpReservationRead: ID %s", d.Id())
+
+	reservation, diags := utilityVinsIpReservationCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if reservation == nil {
+		log.Warnf(ctx, "resourceVinsIpReservationRead: IP reservation %s was released outside of Terraform", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	vinsId, _, _ := utilityVinsIpReservationParseId(d.Id())
+	d.Set("vins_id", vinsId)
+	d.Set("type", reservation.Type)
+	d.Set("ip_addr", reservation.IP)
+	d.Set("mac_addr", reservation.MAC)
+	d.Set("compute_id", reservation.VMID)
+	d.Set("client_type", reservation.ClientType)
+	d.Set("domainname", reservation.DomainName)
+	d.Set("hostname", reservation.HostName)
+
+	return nil
+}
+
+func resourceVinsIpReservationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceVinsIpReservationDelete: ID %s", d.Id())
+
+	reservation, diags := utilityVinsIpReservationCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if reservation == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// release by IP only, as releasing by MAC also drops other reservations of the same MAC
+	vinsId, ip, _ := utilityVinsIpReservationParseId(d.Id())
+	_, err := sdk.New(m.(controller.APICaller)).Vins().IPRelease(ctx, vinssdk.IPReleaseRequest{
+		VinsID: vinsId,
+		IPAddr: ip,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVinsIpReservationSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vins_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the ViNS to reserve IP address in.",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			StateFunc:    statefuncs.StateFuncToUpper,
+			ValidateFunc: validation.StringInSlice([]string{"DHCP", "VIP", "EXCLUDE"}, true),
+			Description:  "Type of this reservation: DHCP, VIP or EXCLUDE.",
+		},
+		"ip_addr": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IP address to reserve. It is allocated automatically if not set.",
+		},
+		"mac_addr": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "MAC address to bind the reserved IP address to.",
+		},
+		"compute_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "ID of the compute to bind the reserved IP address to.",
+		},
+		"client_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"domainname": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"hostname": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func ResourceVinsIpReservation() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 1,
+
+		CreateContext: resourceVinsIpReservationCreate,
+		ReadContext:   resourceVinsIpReservationRead,
+		DeleteContext: resourceVinsIpReservationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &constants.Timeout60s,
+			Read:    &constants.Timeout30s,
+			Delete:  &constants.Timeout60s,
+			Default: &constants.Timeout60s,
+		},
+
+		Schema: resourceVinsIpReservationSchemaMake(),
+	}
+}
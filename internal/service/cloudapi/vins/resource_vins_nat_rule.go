@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+)
+
+func resourceVinsNatRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	vinsId := uint64(d.Get("vins_id").(int))
+	log.Debugf(ctx, "resourceVinsNatRuleCreate: add NAT rule to ViNS ID %d", vinsId)
+
+	ruleId, err := sdk.New(m.(controller.APICaller)).Vins().NATRuleAdd(ctx, vinssdk.NATRuleAddRequest{
+		VinsID:       vinsId,
+		IntIP:        d.Get("int_ip").(string),
+		IntPort:      uint64(d.Get("int_port").(int)),
+		ExtPortStart: uint64(d.Get("ext_port_start").(int)),
+		ExtPortEnd:   uint64(d.Get("ext_port_end").(int)),
+		Proto:        d.Get("proto").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(utilityVinsNatRuleMakeId(vinsId, ruleId))
+	log.Debugf(ctx, "resourceVinsNatRuleCreate: added NAT rule %s", d.Id())
+
+	return resourceVinsNatRuleRead(ctx, d, m)
+}
+
+func resourceVinsNatRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceVinsNatRuleRead: ID %s", d.Id())
+
+	rule, diags := utilityVinsNatRuleCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if rule == nil {
+		log.Warnf(ctx, "resourceVinsNatRuleRead: NAT rule %s was deleted outside of Terraform", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	vinsId, _, _ := utilityVinsNatRuleParseId(d.Id())
+	d.Set("vins_id", vinsId)
+	d.Set("rule_id", rule.ID)
+	d.Set("int_ip", rule.LocalIP)
+	d.Set("int_port", rule.LocalPort)
+	d.Set("ext_port_start", rule.PublicPortStart)
+	d.Set("ext_port_end", rule.PublicPortEnd)
+	d.Set("proto", rule.Protocol)
+	d.Set("vm_id", rule.VMID)
+	d.Set("vm_name", rule.VMName)
+
+	return nil
+}
+
+func resourceVinsNatRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Debugf(ctx, "resourceVinsNatRuleDelete: ID %s", d.Id())
+
+	rule, diags := utilityVinsNatRuleCheckPresence(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	if rule == nil {
+		d.SetId("")
+		return nil
+	}
+
+	vinsId, ruleId, _ := utilityVinsNatRuleParseId(d.Id())
+	_, err := sdk.New(m.(controller.APICaller)).Vins().NATRuleDel(ctx, vinssdk.NATRuleDelRequest{
+		VinsID: vinsId,
+		RuleID: int64(ruleId),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVinsNatRuleSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vins_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the ViNS to add NAT rule to.",
+		},
+		"int_ip": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "Internal IP address to forward traffic to.",
+		},
+		"int_port": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Internal port to forward traffic to.",
+		},
+		"ext_port_start": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Start of the external ports range.",
+		},
+		"ext_port_end": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "End of the external ports range. Equals to ext_port_start if not set.",
+		},
+		"proto": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
+			Description:  "Protocol of this rule, either tcp or udp.",
+		},
+		"rule_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "ID of this rule assigned by the platform.",
+		},
+		"vm_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"vm_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func ResourceVinsNatRule() *schema.Resource {
+	return &schema.Resource{
+		SchemaVersion: 1,
+
+		CreateContext: resourceVinsNatRuleCreate,
+		ReadContext:   resourceVinsNatRuleRead,
+		DeleteContext: resourceVinsNatRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create:  &constants.Timeout60s,
+			Read:    &constants.Timeout30s,
+			Delete:  &constants.Timeout60s,
+			Default: &constants.Timeout60s,
+		},
+
+		Schema: resourceVinsNatRuleSchemaMake(),
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/acctest"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/service/cloudapi/vins"
+)
+
+const testVinsConfig = `
+resource "decort_resgroup" "rg" {
+  account_id = %d
+  gid        = %d
+  name       = "rg-test"
+}
+
+resource "decort_vins" "vins" {
+  rg_id = decort_resgroup.rg.id
+  name  = "vins-test"
+  %s
+}
+`
+
+const testVinsQOS = `
+  qos {
+    ingress_rate = 1000
+    egress_rate  = 2000
+  }
+`
+
+func TestResourceVins(t *testing.T) {
+	s := acctest.NewServer(t)
+	config := func(extra string) string {
+		return acctest.Config(s, testVinsConfig, fake.DefaultAccountID, fake.DefaultGridID, extra)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.CheckDestroyed(s, fake.KindVins, "decort_vins"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_vins.vins", "name", "vins-test"),
+					resource.TestCheckResourceAttrPair("decort_vins.vins", "rg_id", "decort_resgroup.rg", "id"),
+					acctest.CheckObject(s, fake.KindVins, "decort_vins.vins", "status", "ENABLED"),
+				),
+			},
+			{
+				Config: config(testVinsQOS),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("decort_vins.vins", "qos.0.ingress_rate", "1000"),
+					resource.TestCheckResourceAttr("decort_vins.vins", "qos.0.egress_rate", "2000"),
+				),
+			},
+			{
+				ResourceName:      "decort_vins.vins",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"ext_net_id", "force", "permanently", "restore", "vnfdev_redeploy", "vnfdev_restart",
+				},
+			},
+		},
+	})
+}
+
+// TestResourceVinsHandlers runs the steps of TestResourceVins through the resource handlers,
+// so that they are covered without Terraform CLI
+func TestResourceVinsHandlers(t *testing.T) {
+	s := acctest.NewServer(t)
+	m := s.Client()
+	ctx := context.Background()
+	r := vins.ResourceVins()
+
+	rgId := acctest.NewRG(t, s)
+	config := map[string]interface{}{
+		"rg_id": rgId,
+		"name":  "vins-test",
+	}
+	d := acctest.ResourceData(t, r, nil, config, m)
+	if diags := r.CreateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("CreateContext() = %v", diags)
+	}
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		t.Fatalf("resource ID %q: %v", d.Id(), err)
+	}
+	if obj, ok := s.Get(fake.KindVins, id); !ok || obj["status"] != "ENABLED" || obj["rgId"] != rgId {
+		t.Fatalf("vins %d = %v, want ENABLED in rg %d", id, obj, rgId)
+	}
+
+	config["qos"] = []interface{}{map[string]interface{}{"ingress_rate": 1000, "egress_rate": 2000}}
+	d = acctest.ResourceData(t, r, d, config, m)
+	if diags := r.UpdateContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("UpdateContext() = %v", diags)
+	}
+	if ingress, egress := d.Get("qos.0.ingress_rate"), d.Get("qos.0.egress_rate"); ingress != 1000 || egress != 2000 {
+		t.Errorf("qos ingress_rate = %v, egress_rate = %v, want 1000, 2000", ingress, egress)
+	}
+
+	imported := acctest.Imported(r, d.Id())
+	if diags := r.ReadContext(ctx, imported, m); diags.HasError() {
+		t.Fatalf("ReadContext() = %v", diags)
+	}
+	for _, key := range []string{"name", "rg_id", "qos.0.ingress_rate", "qos.0.egress_rate"} {
+		if got, want := imported.Get(key), d.Get(key); got != want {
+			t.Errorf("imported %s = %v, want %v", key, got, want)
+		}
+	}
+
+	if diags := r.DeleteContext(ctx, d, m); diags.HasError() {
+		t.Fatalf("DeleteContext() = %v", diags)
+	}
+	if obj, ok := s.Get(fake.KindVins, id); ok && obj["status"] != "DELETED" && obj["status"] != "DESTROYED" {
+		t.Errorf("vins status = %v after delete", obj["status"])
+	}
+}
@@ -34,49 +34,53 @@ package vins
 
 import (
 	"context"
-	"encoding/json"
-	"net/url"
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityDataVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*VINSDetailed, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	vins := &VINSDetailed{}
-
-	urlValues.Add("vinsId", strconv.Itoa(d.Get("vins_id").(int)))
-	vinsRaw, err := c.DecortAPICall(ctx, "POST", VinsGetAPI, urlValues)
-	if err != nil {
-		return nil, err
+	req := vinssdk.IDRequest{
+		VinsID: uint64(d.Get("vins_id").(int)),
 	}
+	return sdk.New(m.(controller.APICaller)).Vins().Get(ctx, req)
+}
 
-	err = json.Unmarshal([]byte(vinsRaw), vins)
+func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*VINSDetailed, error) {
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
 	if err != nil {
 		return nil, err
 	}
-	return vins, nil
-
+	return sdk.New(m.(controller.APICaller)).Vins().Get(ctx, vinssdk.IDRequest{VinsID: vinsId})
 }
 
-func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*VINSDetailed, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	vins := &VINSDetailed{}
-
-	urlValues.Add("vinsId", d.Id())
-	vinsRaw, err := c.DecortAPICall(ctx, "POST", VinsGetAPI, urlValues)
+// utilityVinsParentGet returns ViNS, which owns NAT rules and IP reservations. If the ViNS is
+// gone, the child resource of the given kind is removed from the state and diagnostics
+// explaining why are returned instead.
+func utilityVinsParentGet(ctx context.Context, d *schema.ResourceData, m interface{}, kind string, vinsId uint64) (*VINSDetailed, diag.Diagnostics) {
+	vins, err := sdk.New(m.(controller.APICaller)).Vins().Get(ctx, vinssdk.IDRequest{VinsID: vinsId})
 	if err != nil {
-		return nil, err
+		if controller.IsNotFound(err) {
+			return nil, dc.ParentGone(d, kind, "ViNS", status.Destroyed)
+		}
+		return nil, diag.FromErr(err)
 	}
 
-	err = json.Unmarshal([]byte(vinsRaw), vins)
-	if err != nil {
-		return nil, err
+	switch vins.Status {
+	case status.Destroyed:
+		return nil, dc.ParentGone(d, kind, "ViNS", vins.Status)
+	case status.Deleted:
+		// ViNS resource restores deleted ViNS with its configuration, unless drift policy forbids it
+		if controller.DriftPolicyOf(m) == controller.DriftReport {
+			return nil, dc.ParentGone(d, kind, "ViNS", vins.Status)
+		}
 	}
 	return vins, nil
-
 }
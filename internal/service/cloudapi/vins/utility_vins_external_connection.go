@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+)
+
+func externalConnectionSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ext_net_id": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "ID of the external network to connect ViNS to.",
+		},
+		"ext_ip": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IP address of ViNS in the external network. It is allocated automatically if not set.",
+		},
+		"default_gw": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Default gateway of the external network.",
+		},
+	}
+}
+
+func flattenExternalConnection(gw GWConfig) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, 1)
+	if gw.ExtNetID == 0 {
+		return res
+	}
+	res = append(res, map[string]interface{}{
+		"ext_net_id": gw.ExtNetID,
+		"ext_ip":     gw.ExtNetIP,
+		"default_gw": gw.DefaultGW,
+	})
+	return res
+}
+
+// utilityVinsExtNetConnect connects ViNS to external network netId and waits until the
+// connection is up. IP address is allocated by the platform if ip is empty. ViNS already
+// connected to the network, e.g. imported one, is left as is.
+func utilityVinsExtNetConnect(ctx context.Context, d *schema.ResourceData, m interface{}, netId uint64, ip string) error {
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Vins()
+
+	vins, err := api.Get(ctx, vinssdk.IDRequest{VinsID: vinsId})
+	if err != nil {
+		return err
+	}
+	if gw := vins.VNFS.GW.Config; gw.ExtNetID == netId && (ip == "" || gw.ExtNetIP == ip) {
+		log.Debugf(ctx, "utilityVinsExtNetConnect: ViNS ID %d is already connected to ExtNet ID %d", vinsId, netId)
+		return nil
+	}
+
+	log.Debugf(ctx, "utilityVinsExtNetConnect: connecting ViNS ID %d to ExtNet ID %d", vinsId, netId)
+	if _, err := api.ExtNetConnect(ctx, vinssdk.ExtNetConnectRequest{VinsID: vinsId, NetID: netId, IP: ip}); err != nil {
+		return err
+	}
+	_, err = api.WaitExtNet(ctx, vinsId, netId)
+	return err
+}
+
+// utilityVinsExtNetDisconnect disconnects ViNS from its external network and waits until
+// the connection is down
+func utilityVinsExtNetDisconnect(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Vins()
+
+	log.Debugf(ctx, "utilityVinsExtNetDisconnect: disconnecting ViNS ID %d from external network", vinsId)
+	if _, err := api.ExtNetDisconnect(ctx, vinssdk.IDRequest{VinsID: vinsId}); err != nil {
+		return err
+	}
+	_, err = api.WaitExtNet(ctx, vinsId, 0)
+	return err
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+)
+
+// IP reservation resource ID has the form <vins_id>#<ip>. IP address is either requested or
+// allocated by the platform on reserve and identifies the reservation within ViNS.
+func utilityVinsIpReservationMakeId(vinsId uint64, ip string) string {
+	return strconv.FormatUint(vinsId, 10) + "#" + ip
+}
+
+func utilityVinsIpReservationParseId(id string) (uint64, string, error) {
+	parameters := strings.SplitN(id, "#", 2)
+	if len(parameters) != 2 || net.ParseIP(parameters[1]) == nil {
+		return 0, "", fmt.Errorf("invalid IP reservation ID %q, expected <vins_id>#<ip>", id)
+	}
+	vinsId, err := strconv.ParseUint(parameters[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid ViNS ID in IP reservation ID %q: %w", id, err)
+	}
+	return vinsId, parameters[1], nil
+}
+
+// utilityVinsIpReservationCheckPresence returns the reservation identified by resource ID or nil,
+// if ViNS does not have it anymore. If the ViNS itself is gone, the resource is removed from
+// the state and diagnostics explaining why are returned instead.
+func utilityVinsIpReservationCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*IP, diag.Diagnostics) {
+	vinsId, ip, err := utilityVinsIpReservationParseId(d.Id())
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	vins, diags := utilityVinsParentGet(ctx, d, m, "IP reservation", vinsId)
+	if diags != nil {
+		return nil, diags
+	}
+	if vins.Status == status.Deleted {
+		// IPs of deleted ViNS are not listed, reservations are kept in its DHCP configuration until restore
+		for _, reservation := range vins.VNFS.DHCP.Config.Reservations {
+			if reservation.IP == ip {
+				return &IP{
+					ClientType: reservation.ClientType,
+					DomainName: reservation.DomainName,
+					HostName:   reservation.HostName,
+					IP:         reservation.IP,
+					MAC:        reservation.MAC,
+					Type:       reservation.Type,
+					VMID:       uint64(reservation.VMID),
+				}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	ips, err := sdk.New(m.(controller.APICaller)).Vins().IPList(ctx, vinssdk.IDRequest{VinsID: vinsId})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	for i := range ips {
+		if ips[i].IP == ip {
+			return &ips[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/status"
+)
+
+// NAT rule resource ID has the form <vins_id>#<rule_id>. Rule ID is assigned by the platform
+// on create and does not depend on the order of ViNS rules.
+func utilityVinsNatRuleMakeId(vinsId, ruleId uint64) string {
+	return strconv.FormatUint(vinsId, 10) + "#" + strconv.FormatUint(ruleId, 10)
+}
+
+func utilityVinsNatRuleParseId(id string) (uint64, uint64, error) {
+	parameters := strings.SplitN(id, "#", 2)
+	if len(parameters) != 2 {
+		return 0, 0, fmt.Errorf("invalid NAT rule ID %q, expected <vins_id>#<rule_id>", id)
+	}
+	vinsId, err := strconv.ParseUint(parameters[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ViNS ID in NAT rule ID %q: %w", id, err)
+	}
+	ruleId, err := strconv.ParseUint(parameters[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rule ID in NAT rule ID %q: %w", id, err)
+	}
+	return vinsId, ruleId, nil
+}
+
+// utilityVinsNatRuleCheckPresence returns the NAT rule identified by resource ID or nil,
+// if ViNS does not have it anymore. If the ViNS itself is gone, the resource is removed from
+// the state and diagnostics explaining why are returned instead.
+func utilityVinsNatRuleCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*NATRule, diag.Diagnostics) {
+	vinsId, ruleId, err := utilityVinsNatRuleParseId(d.Id())
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	vins, diags := utilityVinsParentGet(ctx, d, m, "NAT rule", vinsId)
+	if diags != nil {
+		return nil, diags
+	}
+	if vins.Status == status.Deleted {
+		// rules of deleted ViNS are not listed, they are kept in its NAT configuration until restore
+		for _, rule := range vins.VNFS.NAT.Config.Rules {
+			if rule.ID == ruleId {
+				res := NATRule(rule)
+				return &res, nil
+			}
+		}
+		return nil, nil
+	}
+
+	rules, err := sdk.New(m.(controller.APICaller)).Vins().NATRuleList(ctx, vinssdk.IDRequest{VinsID: vinsId})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	for i := range rules {
+		if rules[i].ID == ruleId {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+)
+
+func staticRouteSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"destination": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "Destination network address, e.g. network of another ViNS.",
+		},
+		"netmask": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "Destination network mask, e.g. 255.255.255.0.",
+		},
+		"gateway": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IP address of the next hop in this ViNS.",
+		},
+		"route_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+// hashStaticRoute identifies static route by its configurable fields, as route_id is only
+// known after the route is added
+func hashStaticRoute(v interface{}) int {
+	route := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s/%s/%s", route["destination"], route["netmask"], route["gateway"]))
+}
+
+func flattenStaticRoutes(routes vinssdk.ListStaticRoutes) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(routes))
+	for _, route := range routes {
+		res = append(res, map[string]interface{}{
+			"destination": route.Destination,
+			"netmask":     route.Netmask,
+			"gateway":     route.Gateway,
+			"route_id":    route.ID,
+		})
+	}
+	return res
+}
+
+// utilityVinsStaticRoutesRead reads static routes of ViNS. Routes are only read if there
+// are routes in the state, so that ViNSes without routes do not depend on the endpoint.
+func utilityVinsStaticRoutesRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("static_route").(*schema.Set).Len() == 0 {
+		return nil
+	}
+
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	routes, err := sdk.New(m.(controller.APICaller)).Vins().StaticRouteList(ctx, vinssdk.IDRequest{VinsID: vinsId})
+	if err != nil {
+		return err
+	}
+	return d.Set("static_route", flattenStaticRoutes(routes))
+}
+
+// utilityVinsStaticRoutesConfigure deletes static routes removed from the configuration and
+// adds new ones
+func utilityVinsStaticRoutesConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Vins()
+
+	oldSet, newSet := d.GetChange("static_route")
+
+	for _, item := range oldSet.(*schema.Set).Difference(newSet.(*schema.Set)).List() {
+		route := item.(map[string]interface{})
+		log.Debugf(ctx, "utilityVinsStaticRoutesConfigure: deleting route ID %d of ViNS ID %d", route["route_id"].(int), vinsId)
+		_, err := api.StaticRouteDel(ctx, vinssdk.StaticRouteDelRequest{
+			VinsID:  vinsId,
+			RouteID: uint64(route["route_id"].(int)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range newSet.(*schema.Set).Difference(oldSet.(*schema.Set)).List() {
+		route := item.(map[string]interface{})
+		log.Debugf(ctx, "utilityVinsStaticRoutesConfigure: adding route to %s/%s via %s to ViNS ID %d",
+			route["destination"].(string), route["netmask"].(string), route["gateway"].(string), vinsId)
+		_, err := api.StaticRouteAdd(ctx, vinssdk.StaticRouteAddRequest{
+			VinsID:      vinsId,
+			Destination: route["destination"].(string),
+			Netmask:     route["netmask"].(string),
+			Gateway:     route["gateway"].(string),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller/fake"
+)
+
+func TestUtilityVinsNatRuleId(t *testing.T) {
+	if id := utilityVinsNatRuleMakeId(12, 345); id != "12#345" {
+		t.Errorf("utilityVinsNatRuleMakeId() = %q, want %q", id, "12#345")
+	}
+
+	tests := []struct {
+		id         string
+		wantVinsId uint64
+		wantRuleId uint64
+		wantErr    bool
+	}{
+		{id: "12#345", wantVinsId: 12, wantRuleId: 345},
+		{id: "12", wantErr: true},
+		{id: "12#", wantErr: true},
+		{id: "#345", wantErr: true},
+		{id: "vins#345", wantErr: true},
+		{id: "12#345#6", wantErr: true},
+		{id: "-1#345", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			vinsId, ruleId, err := utilityVinsNatRuleParseId(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("utilityVinsNatRuleParseId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if vinsId != tt.wantVinsId || ruleId != tt.wantRuleId {
+				t.Errorf("utilityVinsNatRuleParseId() = %d, %d, want %d, %d", vinsId, ruleId, tt.wantVinsId, tt.wantRuleId)
+			}
+		})
+	}
+}
+
+func TestUtilityVinsIpReservationId(t *testing.T) {
+	if id := utilityVinsIpReservationMakeId(12, "192.168.0.10"); id != "12#192.168.0.10" {
+		t.Errorf("utilityVinsIpReservationMakeId() = %q, want %q", id, "12#192.168.0.10")
+	}
+
+	tests := []struct {
+		id         string
+		wantVinsId uint64
+		wantIp     string
+		wantErr    bool
+	}{
+		{id: "12#192.168.0.10", wantVinsId: 12, wantIp: "192.168.0.10"},
+		{id: "12#fd00::10", wantVinsId: 12, wantIp: "fd00::10"},
+		{id: "12", wantErr: true},
+		{id: "12#", wantErr: true},
+		{id: "12#192.168.0", wantErr: true},
+		{id: "vins#192.168.0.10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			vinsId, ip, err := utilityVinsIpReservationParseId(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("utilityVinsIpReservationParseId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if vinsId != tt.wantVinsId || ip != tt.wantIp {
+				t.Errorf("utilityVinsIpReservationParseId() = %d, %q, want %d, %q", vinsId, ip, tt.wantVinsId, tt.wantIp)
+			}
+		})
+	}
+}
+
+// TestUtilityVinsChildParentGone checks that NAT rules and IP reservations of destroyed ViNS
+// are removed from the state with a warning, and those of deleted ViNS are kept until restore
+func TestUtilityVinsChildParentGone(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		exists   bool
+		wantGone bool
+	}{
+		{name: "not found", wantGone: true},
+		{name: "destroyed", status: "DESTROYED", exists: true, wantGone: true},
+		{name: "deleted", status: "DELETED", exists: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fake.NewServer()
+			t.Cleanup(s.Close)
+
+			vinsId := s.NewID()
+			if tt.exists {
+				s.Put(fake.KindVins, vinsId, fake.Object{
+					"id":     vinsId,
+					"status": tt.status,
+					"vnfs": fake.Object{
+						"DHCP": fake.Object{"config": fake.Object{"reservations": []fake.Object{{"ip": "192.168.0.10", "type": "DHCP"}}}},
+						"NAT":  fake.Object{"config": fake.Object{"rules": []fake.Object{{"id": 7, "localIp": "192.168.0.10"}}}},
+					},
+				})
+			}
+
+			check := func(kind string, schemaMap map[string]*schema.Schema, id string, checkPresence func(*schema.ResourceData) (bool, diag.Diagnostics)) {
+				d := schema.TestResourceDataRaw(t, schemaMap, map[string]interface{}{})
+				d.SetId(id)
+				found, diags := checkPresence(d)
+				if tt.wantGone {
+					if len(diags) != 1 || diags[0].Severity != diag.Warning || d.Id() != "" {
+						t.Errorf("%s: diags = %v, ID = %q, want a warning and empty ID", kind, diags, d.Id())
+					}
+					return
+				}
+				if diags != nil || !found || d.Id() != id {
+					t.Errorf("%s: found = %v, diags = %v, ID = %q, want it found in ViNS configuration", kind, found, diags, d.Id())
+				}
+			}
+
+			check("NAT rule", resourceVinsNatRuleSchemaMake(), utilityVinsNatRuleMakeId(uint64(vinsId), 7), func(d *schema.ResourceData) (bool, diag.Diagnostics) {
+				rule, diags := utilityVinsNatRuleCheckPresence(context.Background(), d, s.Client())
+				return rule != nil, diags
+			})
+			check("IP reservation", resourceVinsIpReservationSchemaMake(), utilityVinsIpReservationMakeId(uint64(vinsId), "192.168.0.10"), func(d *schema.ResourceData) (bool, diag.Diagnostics) {
+				reservation, diags := utilityVinsIpReservationCheckPresence(context.Background(), d, s.Client())
+				return reservation != nil, diags
+			})
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package vins
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	vinssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/vins"
+)
+
+func dhcpResourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"dns": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			Description: "IP addresses of DNS servers announced to clients.",
+		},
+		// domain and default_gw are not computed, so that omitting them resets them
+		"domain": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Domain name announced to clients. Omitting it removes the domain.",
+		},
+		"ip_start": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			RequiredWith: []string{"dhcp.0.ip_end"},
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "First IP address of the lease range. Must be set together with ip_end.",
+		},
+		"ip_end": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			RequiredWith: []string{"dhcp.0.ip_start"},
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "Last IP address of the lease range. Must be set together with ip_start.",
+		},
+		"lease": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Lease time in seconds.",
+		},
+		"default_gw": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "Default gateway announced to clients instead of the ViNS gateway. Omitting it announces the ViNS gateway again.",
+		},
+	}
+}
+
+func qosResourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"ingress_rate": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Ingress rate limit, kbit/s.",
+		},
+		"ingress_burst": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Ingress burst limit, kbit.",
+		},
+		"egress_rate": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Egress rate limit, kbit/s.",
+		},
+	}
+}
+
+func flattenDHCPBlock(config DHCPConfig) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"dns":        config.DNS,
+		"domain":     config.Domain,
+		"ip_start":   config.IPStart,
+		"ip_end":     config.IPEnd,
+		"lease":      config.Lease,
+		"default_gw": config.DefaultGW,
+	}}
+}
+
+func flattenQOSBlock(qos QOS) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"ingress_rate":  qos.InRate,
+		"ingress_burst": qos.InBurst,
+		"egress_rate":   qos.ERate,
+	}}
+}
+
+// utilityVinsDHCPConfigure applies dhcp block of the resource. On create (force is true) all
+// configured options are applied, on update only the changed ones, so that an option set to
+// an empty value is reset.
+func utilityVinsDHCPConfigure(ctx context.Context, d *schema.ResourceData, m interface{}, force bool) error {
+	dhcpList := d.Get("dhcp").([]interface{})
+	if len(dhcpList) == 0 || dhcpList[0] == nil {
+		return nil
+	}
+	dhcp := dhcpList[0].(map[string]interface{})
+
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.New(m.(controller.APICaller)).Vins()
+
+	// changed tells whether the option is to be sent: it is configured on create or changed on update
+	changed := func(key string) bool {
+		if force {
+			_, ok := d.GetOk("dhcp.0." + key)
+			return ok
+		}
+		return d.HasChange("dhcp.0." + key)
+	}
+
+	if changed("dns") {
+		dnsList := make([]string, 0, len(dhcp["dns"].([]interface{})))
+		for _, dns := range dhcp["dns"].([]interface{}) {
+			dnsList = append(dnsList, dns.(string))
+		}
+		log.Debugf(ctx, "utilityVinsDHCPConfigure: applying DNS servers %v to ViNS ID %d", dnsList, vinsId)
+		if _, err := api.DNSApply(ctx, vinssdk.DNSApplyRequest{VinsID: vinsId, DNSList: dnsList}); err != nil {
+			return err
+		}
+	}
+
+	req := vinssdk.DHCPConfigureRequest{VinsID: vinsId}
+	send := false
+	// lease range is always sent as a whole
+	if changed("ip_start") || changed("ip_end") {
+		ipStart, ipEnd := dhcp["ip_start"].(string), dhcp["ip_end"].(string)
+		req.IPStart, req.IPEnd = &ipStart, &ipEnd
+		send = true
+	}
+	if changed("lease") {
+		lease := uint64(dhcp["lease"].(int))
+		req.Lease = &lease
+		send = true
+	}
+	if changed("default_gw") {
+		defaultGW := dhcp["default_gw"].(string)
+		req.DefaultGW = &defaultGW
+		send = true
+	}
+	if changed("domain") {
+		domain := dhcp["domain"].(string)
+		req.Domain = &domain
+		send = true
+	}
+	if send {
+		log.Debugf(ctx, "utilityVinsDHCPConfigure: configuring DHCP of ViNS ID %d", vinsId)
+		if _, err := api.DHCPConfigure(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// utilityVinsQOSConfigure applies qos block of the resource as default QoS of the ViNS. On
+// create (force is true) all configured limits are applied, on update only the changed ones.
+func utilityVinsQOSConfigure(ctx context.Context, d *schema.ResourceData, m interface{}, force bool) error {
+	qosList := d.Get("qos").([]interface{})
+	if len(qosList) == 0 || qosList[0] == nil {
+		return nil
+	}
+	qos := qosList[0].(map[string]interface{})
+
+	vinsId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	req := vinssdk.NetQOSRequest{VinsID: vinsId}
+	send := false
+	for key, field := range map[string]**uint64{
+		"ingress_rate":  &req.IngressRate,
+		"ingress_burst": &req.IngressBurst,
+		"egress_rate":   &req.EgressRate,
+	} {
+		_, configured := d.GetOk("qos.0." + key)
+		if force && configured || !force && d.HasChange("qos.0."+key) {
+			value := uint64(qos[key].(int))
+			*field = &value
+			send = true
+		}
+	}
+	if !send {
+		return nil
+	}
+
+	log.Debugf(ctx, "utilityVinsQOSConfigure: setting default QoS of ViNS ID %d", vinsId)
+	_, err = sdk.New(m.(controller.APICaller)).Vins().NetQOS(ctx, req)
+	return err
+}
@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package account
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const accountAddUserAPI = "/restmachine/cloudbroker/account/addUser"
 const accountAuditsAPI = "/restmachine/cloudbroker/account/audits"
 const accountCreateAPI = "/restmachine/cloudbroker/account/create"
@@ -54,3 +56,5 @@ const accountUpdateUserAPI = "/restmachine/cloudbroker/account/updateUser"
 //const accountsEnableAPI = "/restmachine/cloudbroker/account/enableAccounts"
 //const accountsDisableAPI = "/restmachine/cloudbroker/account/disableAccounts"
 //const accountsDeleteAPI = "/restmachine/cloudbroker/account/deleteAccounts"
+
+var log = logging.New("cb_account")
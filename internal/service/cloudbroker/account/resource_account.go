@@ -42,13 +42,12 @@ import (
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountCreate")
+	log.Debugf(ctx, "resourceAccountCreate")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("name", d.Get("account_name").(string))
@@ -168,7 +167,7 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountRead")
+	log.Debugf(ctx, "resourceAccountRead")
 
 	acc, err := utilityAccountCheckPresence(ctx, d, m)
 	if acc == nil {
@@ -204,7 +203,7 @@ func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountDelete")
+	log.Debugf(ctx, "resourceAccountDelete")
 
 	account, err := utilityAccountCheckPresence(ctx, d, m)
 	if account == nil {
@@ -214,7 +213,7 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("accountId", strconv.Itoa(d.Get("account_id").(int)))
 	urlValues.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
@@ -229,8 +228,8 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountEdit(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceAccountEdit")
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceAccountEdit")
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 	if d.HasChange("enable") {
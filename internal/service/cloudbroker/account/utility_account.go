@@ -38,14 +38,13 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityAccountCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*AccountWithResources, error) {
 	account := &AccountWithResources{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if (strconv.Itoa(d.Get("account_id").(int))) != "0" {
@@ -54,7 +53,7 @@ func utilityAccountCheckPresence(ctx context.Context, d *schema.ResourceData, m
 		urlValues.Add("accountId", d.Id())
 	}
 
-	log.Debugf("utilityAccountCheckPresence: load account")
+	log.Debugf(ctx, "utilityAccountCheckPresence: load account")
 	accountRaw, err := c.DecortAPICall(ctx, "POST", accountGetAPI, urlValues)
 	if err != nil {
 		return nil, err
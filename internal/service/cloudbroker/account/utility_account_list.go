@@ -38,14 +38,13 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityAccountListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (AccountList, error) {
 	accountList := AccountList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if page, ok := d.GetOk("page"); ok {
@@ -55,7 +54,7 @@ func utilityAccountListCheckPresence(ctx context.Context, d *schema.ResourceData
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityAccountListCheckPresence: load account list")
+	log.Debugf(ctx, "utilityAccountListCheckPresence: load account list")
 	accountListRaw, err := c.DecortAPICall(ctx, "POST", accountListAPI, urlValues)
 	if err != nil {
 		return nil, err
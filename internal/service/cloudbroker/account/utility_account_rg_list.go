@@ -38,19 +38,18 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityAccountRGListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (AccountRGList, error) {
 	accountRGList := AccountRGList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("accountId", strconv.Itoa(d.Get("account_id").(int)))
 
-	log.Debugf("utilityAccountRGListCheckPresence: load account list")
+	log.Debugf(ctx, "utilityAccountRGListCheckPresence: load account list")
 	accountRGListRaw, err := c.DecortAPICall(ctx, "POST", accountListRGAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package disks
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const disksCreateAPI = "/restmachine/cloudbroker/disks/create"
 const disksGetAPI = "/restmachine/cloudbroker/disks/get"
 const disksListAPI = "/restmachine/cloudbroker/disks/list"
@@ -39,3 +41,5 @@ const disksRenameAPI = "/restmachine/cloudbroker/disks/rename"
 const disksDeleteAPI = "/restmachine/cloudbroker/disks/delete"
 const disksIOLimitAPI = "/restmachine/cloudbroker/disks/limitIO"
 const disksRestoreAPI = "/restmachine/cloudbroker/disks/restore"
+
+var log = logging.New("cb_disks")
@@ -265,8 +265,9 @@ func dataSourceDiskSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"passwd": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"pci_slot": {
 			Type:     schema.TypeInt,
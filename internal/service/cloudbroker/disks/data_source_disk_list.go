@@ -347,8 +347,9 @@ func dataSourceDiskListSchemaMake() map[string]*schema.Schema {
 						Computed: true,
 					},
 					"passwd": {
-						Type:     schema.TypeString,
-						Computed: true,
+						Type:      schema.TypeString,
+						Sensitive: true,
+						Computed:  true,
 					},
 					"pci_slot": {
 						Type:     schema.TypeInt,
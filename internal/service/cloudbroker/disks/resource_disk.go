@@ -41,7 +41,6 @@ import (
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -49,7 +48,7 @@ import (
 )
 
 func resourceDiskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("accountId", fmt.Sprintf("%d", d.Get("account_id").(int)))
@@ -180,13 +179,13 @@ func resourceDiskRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceDiskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if d.HasChange("size_max") {
 		oldSize, newSize := d.GetChange("size_max")
 		if oldSize.(int) < newSize.(int) {
-			log.Debugf("resourceDiskUpdate: resizing disk ID %s - %d GB -> %d GB",
+			log.Debugf(ctx, "resourceDiskUpdate: resizing disk ID %s - %d GB -> %d GB",
 				d.Id(), oldSize.(int), newSize.(int))
 			urlValues.Add("diskId", d.Id())
 			urlValues.Add("size", fmt.Sprintf("%d", newSize.(int)))
@@ -272,7 +271,7 @@ func resourceDiskDelete(ctx context.Context, d *schema.ResourceData, m interface
 	params.Add("permanently", strconv.FormatBool(d.Get("permanently").(bool)))
 	params.Add("reason", d.Get("reason").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	_, err = c.DecortAPICall(ctx, "POST", disksDeleteAPI, params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -506,8 +505,9 @@ func resourceDiskSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"passwd": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"pci_slot": {
 			Type:     schema.TypeInt,
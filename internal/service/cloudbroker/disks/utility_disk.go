@@ -33,38 +33,28 @@ package disks
 
 import (
 	"context"
-	"encoding/json"
-	"net/url"
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	diskssdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/disks"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityDiskCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*Disk, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-
-	disk := &Disk{}
-
-	if d.Get("disk_id").(int) == 0 {
-		urlValues.Add("diskId", d.Id())
-	} else {
-		urlValues.Add("diskId", strconv.Itoa(d.Get("disk_id").(int)))
+	diskId, _ := d.Get("disk_id").(int)
+	req := diskssdk.GetRequest{
+		DiskID: uint64(diskId),
 	}
-
-	log.Debugf("utilityDiskCheckPresence: load disk")
-	diskRaw, err := c.DecortAPICall(ctx, "POST", disksGetAPI, urlValues)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal([]byte(diskRaw), disk)
-	if err != nil {
-		return nil, err
+	if req.DiskID == 0 {
+		id, err := strconv.ParseUint(d.Id(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		req.DiskID = id
 	}
 
-	return disk, nil
+	log.Debugf(ctx, "utilityDiskCheckPresence: load disk")
+	return sdk.NewCloudBroker(m.(controller.APICaller)).Disks().Get(ctx, req)
 }
@@ -39,14 +39,13 @@ import (
 	"strings"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityDiskListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (DisksList, error) {
 	diskList := DisksList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if page, ok := d.GetOk("page"); ok {
@@ -62,7 +61,7 @@ func utilityDiskListCheckPresence(ctx context.Context, d *schema.ResourceData, m
 		urlValues.Add("accountId", strconv.Itoa(accountId.(int)))
 	}
 
-	log.Debugf("utilityDiskListCheckPresence: load disk list")
+	log.Debugf(ctx, "utilityDiskListCheckPresence: load disk list")
 	diskListRaw, err := c.DecortAPICall(ctx, "POST", disksListAPI, urlValues)
 	if err != nil {
 		return nil, err
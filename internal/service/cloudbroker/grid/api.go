@@ -31,5 +31,9 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package grid
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const GridListGetAPI = "/restmachine/cloudbroker/grid/list"
 const GridGetAPI = "/restmachine/cloudbroker/grid/get"
+
+var log = logging.New("cb_grid")
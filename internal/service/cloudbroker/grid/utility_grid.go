@@ -39,14 +39,13 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityGridCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*Grid, error) {
 	grid := &Grid{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if gridId, ok := d.GetOk("grid_id"); ok {
@@ -55,7 +54,7 @@ func utilityGridCheckPresence(ctx context.Context, d *schema.ResourceData, m int
 		return nil, errors.New("grid_id is required")
 	}
 
-	log.Debugf("utilityGridCheckPresence: load grid")
+	log.Debugf(ctx, "utilityGridCheckPresence: load grid")
 	gridRaw, err := c.DecortAPICall(ctx, "POST", GridGetAPI, urlValues)
 	if err != nil {
 		return nil, err
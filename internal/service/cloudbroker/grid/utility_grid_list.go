@@ -38,14 +38,13 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityGridListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (GridList, error) {
 	gridList := GridList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if page, ok := d.GetOk("page"); ok {
@@ -55,7 +54,7 @@ func utilityGridListCheckPresence(ctx context.Context, d *schema.ResourceData, m
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityGridListCheckPresence: load grid list")
+	log.Debugf(ctx, "utilityGridListCheckPresence: load grid list")
 	gridListRaw, err := c.DecortAPICall(ctx, "POST", GridListGetAPI, urlValues)
 	if err != nil {
 		return nil, err
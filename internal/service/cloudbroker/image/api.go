@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package image
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const imageCreateAPI = "/restmachine/cloudbroker/image/createImage"
 const imageSyncCreateAPI = "/restmachine/cloudbroker/image/syncCreateImage"
 const imageCreateVirtualAPI = "/restmachine/cloudbroker/image/createVirtual"
@@ -50,3 +52,5 @@ const imageComputeciSetAPI = "/restmachine/cloudbroker/image/computeciSet"
 const imageComputeciUnsetAPI = "/restmachine/cloudbroker/image/computeciUnset"
 const imageUpdateNodesAPI = "/restmachine/cloudbroker/image/updateNodes"
 const imageDeleteImagesAPI = "/restmachine/cloudbroker/image/deleteImages"
+
+var log = logging.New("cb_image")
@@ -177,6 +177,7 @@ func dataSourceImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "Optional password for the image",
 		},
@@ -192,6 +193,7 @@ func dataSourceImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password_dl": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "password for upload binary media",
 		},
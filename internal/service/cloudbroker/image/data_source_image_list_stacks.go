@@ -131,8 +131,9 @@ func dataSourceImageListStackSchemaMake() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"passwd": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:      schema.TypeString,
+			Sensitive: true,
+			Computed:  true,
 		},
 		"reference_id": {
 			Type:     schema.TypeString,
@@ -40,13 +40,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceCDROMImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceCDROMImageCreate: called for image %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourceCDROMImageCreate: called for image %s", d.Get("name").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("url", d.Get("url").(string))
@@ -116,7 +115,7 @@ func resourceCDROMImageCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceCDROMImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceCDROMImageDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceCDROMImageDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
 
 	image, err := utilityImageCheckPresence(ctx, d, m)
 	if image == nil {
@@ -126,7 +125,7 @@ func resourceCDROMImageDelete(ctx context.Context, d *schema.ResourceData, m int
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 
@@ -200,6 +199,7 @@ func resourceCDROMImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Optional:    true,
 			Computed:    true,
 			Description: "Optional password for the image",
@@ -218,6 +218,7 @@ func resourceCDROMImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password_dl": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Optional:    true,
 			Computed:    true,
 			Description: "password for upload binary media",
@@ -41,7 +41,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceCreateListImages(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -51,9 +50,9 @@ func resourceCreateListImages(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceDeleteListImages(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceDeleteListImages: start deleting...")
+	log.Debugf(ctx, "resourceDeleteListImages: start deleting...")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	imageIds := d.Get("image_ids").([]interface{})
@@ -42,13 +42,12 @@ import (
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageCreate: called for image %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourceImageCreate: called for image %s", d.Get("name").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("url", d.Get("url").(string))
@@ -129,7 +128,7 @@ func resourceImageCreate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceImageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageRead: called for %s id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceImageRead: called for %s id: %s", d.Get("name").(string), d.Id())
 
 	image, err := utilityImageCheckPresence(ctx, d, m)
 	if image == nil {
@@ -180,7 +179,7 @@ func resourceImageRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourceImageDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
 
 	image, err := utilityImageCheckPresence(ctx, d, m)
 	if image == nil {
@@ -190,7 +189,7 @@ func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interfac
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	if reason, ok := d.GetOk("reason"); ok {
@@ -212,8 +211,8 @@ func resourceImageDelete(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceImageEditName(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceImageEditName: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceImageEditName: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	urlValues.Add("name", d.Get("name").(string))
@@ -226,8 +225,8 @@ func resourceImageEditName(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceImageEdit(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageEdit: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceImageEdit: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if d.HasChange("enabled") {
@@ -300,7 +299,7 @@ func resourceImageEdit(ctx context.Context, d *schema.ResourceData, m interface{
 func resourceImageChangeEnabled(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	var api string
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	if d.Get("enabled").(bool) {
@@ -323,8 +322,8 @@ func resourceImageChangeEnabled(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceImageLink(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceVirtualImageLink: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceVirtualImageLink: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	urlValues.Add("targetId", strconv.Itoa(d.Get("link_to").(int)))
@@ -337,8 +336,8 @@ func resourceImageLink(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceImageShare(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceImageShare: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceImageShare: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	accIds := d.Get("shared_with").([]interface{})
@@ -364,7 +363,7 @@ func resourceImageShare(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceImageChangeComputeci(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
@@ -388,8 +387,8 @@ func resourceImageChangeComputeci(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceImageUpdateNodes(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceImageUpdateNodes: called for %s, id: %s", d.Get("name").(string), d.Id())
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceImageUpdateNodes: called for %s, id: %s", d.Get("name").(string), d.Id())
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 	enabledStacks := d.Get("enabled_stacks").([]interface{})
@@ -469,6 +468,7 @@ func resourceImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Optional:    true,
 			Computed:    true,
 			Description: "Optional password for the image",
@@ -487,6 +487,7 @@ func resourceImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password_dl": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Optional:    true,
 			Computed:    true,
 			Description: "password for upload binary media",
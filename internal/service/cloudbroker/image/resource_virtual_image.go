@@ -40,13 +40,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceVirtualImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceImageCreate: called for image %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourceImageCreate: called for image %s", d.Get("name").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("targetId", strconv.Itoa(d.Get("target_id").(int)))
@@ -158,6 +157,7 @@ func resourceVirtualImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Optional:    true,
 			Computed:    true,
 			Description: "Optional password for the image",
@@ -176,6 +176,7 @@ func resourceVirtualImageSchemaMake() map[string]*schema.Schema {
 		},
 		"password_dl": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Optional:    true,
 			Computed:    true,
 			Description: "password for upload binary media",
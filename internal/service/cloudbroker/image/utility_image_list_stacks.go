@@ -38,19 +38,18 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityImageListStacksCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ImageListStacks, error) {
 	imageListStacks := ImageListStacks{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("imageId", strconv.Itoa(d.Get("image_id").(int)))
 
-	log.Debugf("utilityImageListStacksCheckPresence: load image list")
+	log.Debugf(ctx, "utilityImageListStacksCheckPresence: load image list")
 	imageListRaw, err := c.DecortAPICall(ctx, "POST", imageListStacksApi, urlValues)
 	if err != nil {
 		return nil, err
@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package k8s
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const K8sCreateAPI = "/restmachine/cloudbroker/k8s/create"
 const K8sGetAPI = "/restmachine/cloudbroker/k8s/get"
 const K8sUpdateAPI = "/restmachine/cloudbroker/k8s/update"
@@ -47,3 +49,5 @@ const K8sGetConfigAPI = "/restmachine/cloudbroker/k8s/getConfig"
 const LbGetAPI = "/restmachine/cloudbroker/lb/get"
 
 const AsyncTaskGetAPI = "/restmachine/cloudbroker/tasks/get"
+
+var log = logging.New("cb_k8s")
@@ -37,20 +37,18 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
 )
 
 func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sCreate: called with name %s, rg %d", d.Get("name").(string), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sCreate: called with name %s, rg %d", d.Get("name").(string), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("rgId", strconv.Itoa(d.Get("rg_id").(int)))
@@ -99,33 +97,13 @@ func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	urlValues = &url.Values{}
-	urlValues.Add("auditId", strings.Trim(resp, `"`))
-
-	for {
-		resp, err := c.DecortAPICall(ctx, "POST", AsyncTaskGetAPI, urlValues)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		task := AsyncTask{}
-		if err := json.Unmarshal([]byte(resp), &task); err != nil {
-			return diag.FromErr(err)
-		}
-		log.Debugf("resourceK8sCreate: instance creating - %s", task.Stage)
-
-		if task.Completed {
-			if task.Error != "" {
-				return diag.FromErr(fmt.Errorf("cannot create k8s instance: %v", task.Error))
-			}
-
-			d.SetId(strconv.Itoa(int(task.Result)))
-			break
-		}
-
-		time.Sleep(time.Second * 10)
+	task, err := sdk.NewCloudBroker(c).Tasks().Wait(ctx, resp)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot create k8s instance: %w", err))
 	}
 
+	d.SetId(strconv.Itoa(int(task.Result)))
+
 	k8s, err := utilityK8sCheckPresence(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
@@ -150,17 +128,22 @@ func resourceK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	urlValues = &url.Values{}
 	urlValues.Add("k8sId", d.Id())
-	kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
-	if err != nil {
-		log.Warnf("could not get kubeconfig: %v", err)
+	if controller.OmitSecretsOf(m) {
+		// kubeconfig is not fetched at all, so that it cannot leak into state or logs
+		d.Set("kubeconfig", "")
+	} else {
+		kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
+		if err != nil {
+			log.Warnf(ctx, "could not get kubeconfig: %v", err)
+		}
+		d.Set("kubeconfig", kubeconfig)
 	}
-	d.Set("kubeconfig", kubeconfig)
 
 	return nil
 }
 
 func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sRead: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sRead: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
 
 	k8s, err := utilityK8sCheckPresence(ctx, d, m)
 	if k8s == nil {
@@ -176,7 +159,7 @@ func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	d.Set("workers", nodeToResource(k8s.Groups.Workers[0]))
 	d.Set("default_wg_id", k8s.Groups.Workers[0].ID)
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("lbId", strconv.Itoa(k8s.LbID))
 
@@ -194,19 +177,24 @@ func resourceK8sRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 	urlValues = &url.Values{}
 	urlValues.Add("k8sId", d.Id())
-	kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
-	if err != nil {
-		log.Warnf("could not get kubeconfig: %v", err)
+	if controller.OmitSecretsOf(m) {
+		// kubeconfig is not fetched at all, so that it cannot leak into state or logs
+		d.Set("kubeconfig", "")
+	} else {
+		kubeconfig, err := c.DecortAPICall(ctx, "POST", K8sGetConfigAPI, urlValues)
+		if err != nil {
+			log.Warnf(ctx, "could not get kubeconfig: %v", err)
+		}
+		d.Set("kubeconfig", kubeconfig)
 	}
-	d.Set("kubeconfig", kubeconfig)
 
 	return nil
 }
 
 func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sUpdate: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sUpdate: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	if d.HasChange("name") {
 		urlValues := &url.Values{}
@@ -251,7 +239,7 @@ func resourceK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceK8sDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sDelete: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceK8sDelete: called with id %s, rg %d", d.Id(), d.Get("rg_id").(int))
 
 	k8s, err := utilityK8sCheckPresence(ctx, d, m)
 	if k8s == nil {
@@ -261,7 +249,7 @@ func resourceK8sDelete(ctx context.Context, d *schema.ResourceData, m interface{
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", d.Id())
 	urlValues.Add("permanently", "true")
@@ -360,6 +348,7 @@ func resourceK8sSchemaMake() map[string]*schema.Schema {
 
 		"kubeconfig": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Computed:    true,
 			Description: "Kubeconfig for cluster access.",
 		},
@@ -38,15 +38,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	log "github.com/sirupsen/logrus"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 )
 
 func resourceK8sWgCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgCreate: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgCreate: called with k8s id %d", d.Get("k8s_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", strconv.Itoa(d.Get("k8s_id").(int)))
 	urlValues.Add("name", d.Get("name").(string))
@@ -66,7 +65,7 @@ func resourceK8sWgCreate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceK8sWgRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgRead: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgRead: called with k8s id %d", d.Get("k8s_id").(int))
 
 	wg, err := utilityK8sWgCheckPresence(ctx, d, m)
 	if wg == nil {
@@ -84,9 +83,9 @@ func resourceK8sWgRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceK8sWgUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgUpdate: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgUpdate: called with k8s id %d", d.Get("k8s_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	wg, err := utilityK8sWgCheckPresence(ctx, d, m)
 	if err != nil {
@@ -117,7 +116,7 @@ func resourceK8sWgUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceK8sWgDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceK8sWgDelete: called with k8s id %d", d.Get("k8s_id").(int))
+	log.Debugf(ctx, "resourceK8sWgDelete: called with k8s id %d", d.Get("k8s_id").(int))
 
 	wg, err := utilityK8sWgCheckPresence(ctx, d, m)
 	if wg == nil {
@@ -127,7 +126,7 @@ func resourceK8sWgDelete(ctx context.Context, d *schema.ResourceData, m interfac
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("k8sId", strconv.Itoa(d.Get("k8s_id").(int)))
 	urlValues.Add("workersGroupId", strconv.Itoa(wg.ID))
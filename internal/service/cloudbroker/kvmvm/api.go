@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package kvmvm
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const KvmX86CreateAPI = "/restmachine/cloudbroker/kvmx86/create"
 const KvmPPCCreateAPI = "/restmachine/cloudbroker/kvmppc/create"
 const ComputeGetAPI = "/restmachine/cloudbroker/compute/get"
@@ -44,3 +46,34 @@ const ComputeStopAPI = "/restmachine/cloudbroker/compute/stop"
 const ComputeResizeAPI = "/restmachine/cloudbroker/compute/resize"
 const DisksResizeAPI = "/restmachine/cloudbroker/disks/resize2"
 const ComputeDeleteAPI = "/restmachine/cloudbroker/compute/delete"
+const ComputeUpdateAPI = "/restmachine/cloudbroker/compute/update"
+const ComputeEnableAPI = "/restmachine/cloudbroker/compute/enable"
+const ComputeDisableAPI = "/restmachine/cloudbroker/compute/disable"
+const ComputeAffinityLabelSetAPI = "/restmachine/cloudbroker/compute/affinityLabelSet"
+const ComputeAffinityLabelRemoveAPI = "/restmachine/cloudbroker/compute/affinityLabelRemove"
+const ComputeAffinityRuleAddAPI = "/restmachine/cloudbroker/compute/affinityRuleAdd"
+const ComputeAffinityRuleRemoveAPI = "/restmachine/cloudbroker/compute/affinityRuleRemove"
+const ComputeAffinityRulesClearAPI = "/restmachine/cloudbroker/compute/affinityRulesClear"
+const ComputeAntiAffinityRuleAddAPI = "/restmachine/cloudbroker/compute/antiAffinityRuleAdd"
+const ComputeAntiAffinityRuleRemoveAPI = "/restmachine/cloudbroker/compute/antiAffinityRuleRemove"
+const ComputeAntiAffinityRulesClearAPI = "/restmachine/cloudbroker/compute/antiAffinityRulesClear"
+const ComputeTagAddAPI = "/restmachine/cloudbroker/compute/tagAdd"
+const ComputeTagRemoveAPI = "/restmachine/cloudbroker/compute/tagRemove"
+const ComputePinToStackAPI = "/restmachine/cloudbroker/compute/pinToStack"
+const ComputeUnpinFromStackAPI = "/restmachine/cloudbroker/compute/unpinFromStack"
+const ComputePfwAddAPI = "/restmachine/cloudbroker/compute/pfwAdd"
+const ComputePfwDelAPI = "/restmachine/cloudbroker/compute/pfwDel"
+const ComputeUserGrantAPI = "/restmachine/cloudbroker/compute/userGrant"
+const ComputeUserRevokeAPI = "/restmachine/cloudbroker/compute/userRevoke"
+const ComputeSnapshotCreateAPI = "/restmachine/cloudbroker/compute/snapshotCreate"
+const ComputeSnapshotDeleteAPI = "/restmachine/cloudbroker/compute/snapshotDelete"
+const ComputeSnapshotRollbackAPI = "/restmachine/cloudbroker/compute/snapshotRollback"
+const ComputePauseAPI = "/restmachine/cloudbroker/compute/pause"
+const ComputeResumeAPI = "/restmachine/cloudbroker/compute/resume"
+const ComputeResetAPI = "/restmachine/cloudbroker/compute/reset"
+const ComputeCdInsertAPI = "/restmachine/cloudbroker/compute/cdInsert"
+const ComputeCdEjectAPI = "/restmachine/cloudbroker/compute/cdEject"
+const ComputeMoveToRgAPI = "/restmachine/cloudbroker/compute/moveToRg"
+const ComputeMigrateAPI = "/restmachine/cloudbroker/compute/migrate"
+
+var log = logging.New("cb_kvmvm")
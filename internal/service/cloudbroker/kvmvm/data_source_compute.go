@@ -39,7 +39,7 @@ import (
 	// "net/url"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -48,11 +48,11 @@ import (
 
 // Parse list of all disks from API compute/get into a list of "extra disks" attached to this compute
 // Extra disks are all compute disks but a boot disk.
-func parseComputeDisksToExtraDisks(disks []DiskRecord) []interface{} {
+func parseComputeDisksToExtraDisks(ctx context.Context, disks []DiskRecord) []interface{} {
 	// this return value will be used to d.Set("extra_disks",) item of dataSourceCompute schema,
 	// which is a simple list of integer disk IDs excluding boot disk ID
 	length := len(disks)
-	log.Debugf("parseComputeDisksToExtraDisks: called for %d disks", length)
+	log.Debugf(ctx, "parseComputeDisksToExtraDisks: called for %d disks", length)
 
 	if length == 0 || (length == 1 && disks[0].Type == "B") {
 		// the disk list is empty (which is kind of strange - diskless compute?), or
@@ -89,10 +89,10 @@ func findBootDisk(disks []DiskRecord) *DiskRecord {
 
 // Parse the list of interfaces from compute/get response into a list of networks
 // attached to this compute
-func parseComputeInterfacesToNetworks(ifaces []InterfaceRecord) []interface{} {
+func parseComputeInterfacesToNetworks(ctx context.Context, ifaces []InterfaceRecord) []interface{} {
 	// return value will be used to d.Set("network") item of dataSourceCompute schema
 	length := len(ifaces)
-	log.Debugf("parseComputeInterfacesToNetworks: called for %d ifaces", length)
+	log.Debugf(ctx, "parseComputeInterfacesToNetworks: called for %d ifaces", length)
 
 	result := []interface{}{}
 
@@ -113,26 +113,27 @@ func parseComputeInterfacesToNetworks(ifaces []InterfaceRecord) []interface{} {
 	return result
 }
 
-func flattenCompute(d *schema.ResourceData, compFacts string) error {
+func flattenCompute(ctx context.Context, d *schema.ResourceData, compFacts string) error {
 	// This function expects that compFacts string contains response from API compute/get,
 	// i.e. detailed information about compute instance.
 	//
 	// NOTE: this function modifies ResourceData argument - as such it should never be called
 	// from resourceComputeExists(...) method
 	model := ComputeGetResp{}
-	log.Debugf("flattenCompute: ready to unmarshal string %s", compFacts)
+	log.Tracef(ctx, "flattenCompute: ready to unmarshal string %s", logging.Mask(compFacts))
 	err := json.Unmarshal([]byte(compFacts), &model)
 	if err != nil {
 		return err
 	}
 
-	log.Debugf("flattenCompute: ID %d, RG ID %d", model.ID, model.RgID)
+	log.Debugf(ctx, "flattenCompute: ID %d, RG ID %d", model.ID, model.RgID)
 
 	d.SetId(fmt.Sprintf("%d", model.ID))
 	// d.Set("compute_id", model.ID) - we should NOT set compute_id in the schema here: if it was set - it is already set, if it wasn't - we shouldn't
 	d.Set("name", model.Name)
 	d.Set("rg_id", model.RgID)
 	d.Set("rg_name", model.RgName)
+	d.Set("stack_id", model.StackID)
 	d.Set("account_id", model.AccountID)
 	d.Set("account_name", model.AccountName)
 	d.Set("driver", model.Driver)
@@ -145,6 +146,8 @@ func flattenCompute(d *schema.ResourceData, compFacts string) error {
 	// d.Set("status", model.Status)
 	// d.Set("tech_status", model.TechStatus)
 
+	d.Set("enabled", model.Status == "ENABLED")
+
 	if model.TechStatus == "STARTED" {
 		d.Set("started", true)
 	} else {
@@ -159,24 +162,23 @@ func flattenCompute(d *schema.ResourceData, compFacts string) error {
 	d.Set("pool", bootDisk.Pool)
 
 	if len(model.Disks) > 0 {
-		log.Debugf("flattenCompute: calling parseComputeDisksToExtraDisks for %d disks", len(model.Disks))
-		if err = d.Set("extra_disks", parseComputeDisksToExtraDisks(model.Disks)); err != nil {
+		log.Debugf(ctx, "flattenCompute: calling parseComputeDisksToExtraDisks for %d disks", len(model.Disks))
+		if err = d.Set("extra_disks", parseComputeDisksToExtraDisks(ctx, model.Disks)); err != nil {
 			return err
 		}
 	}
 
 	if len(model.Interfaces) > 0 {
-		log.Debugf("flattenCompute: calling parseComputeInterfacesToNetworks for %d interfaces", len(model.Interfaces))
-		if err = d.Set("network", parseComputeInterfacesToNetworks(model.Interfaces)); err != nil {
+		log.Debugf(ctx, "flattenCompute: calling parseComputeInterfacesToNetworks for %d interfaces", len(model.Interfaces))
+		if err = d.Set("network", parseComputeInterfacesToNetworks(ctx, model.Interfaces)); err != nil {
 			return err
 		}
 	}
 
-	if len(model.OsUsers) > 0 {
-		log.Debugf("flattenCompute: calling parseOsUsers for %d logins", len(model.OsUsers))
-		if err = d.Set("os_users", parseOsUsers(model.OsUsers)); err != nil {
-			return err
-		}
+	// os_users is set even if empty, otherwise it is planned as unknown on every apply
+	log.Debugf(ctx, "flattenCompute: calling parseOsUsers for %d logins", len(model.OsUsers))
+	if err = d.Set("os_users", parseOsUsers(ctx, model.OsUsers)); err != nil {
+		return err
 	}
 
 	return nil
@@ -191,13 +193,41 @@ func dataSourceComputeRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
-	if err = flattenCompute(d, compFacts); err != nil {
+	if err = flattenCompute(ctx, d, compFacts); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = utilityComputeSettingsRead(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
+func dataSourceComputeRuleSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"topology": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"policy": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"mode": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"key": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"value": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
 func DataSourceCompute() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
@@ -236,6 +266,12 @@ func DataSourceCompute() *schema.Resource {
 				Description: "Name of the resource group where this compute instance is located.",
 			},
 
+			"stack_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the stack this compute instance runs on.",
+			},
+
 			"account_id": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -347,6 +383,145 @@ func DataSourceCompute() *schema.Resource {
 				Default:     true,
 				Description: "Is compute started.",
 			},
+
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is compute enabled.",
+			},
+
+			"affinity_label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Affinity label of this compute instance.",
+			},
+
+			"affinity_rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceComputeRuleSchemaMake(),
+				},
+				Description: "Affinity rules of this compute instance.",
+			},
+
+			"anti_affinity_rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataSourceComputeRuleSchemaMake(),
+				},
+				Description: "Anti-affinity rules of this compute instance.",
+			},
+
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Tags of this compute instance.",
+			},
+
+			"port_forwarding": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"public_port_start": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"public_port_end": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "End of the public port range, -1 if single port is forwarded.",
+						},
+						"local_port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"proto": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Port forwarding rules of this compute instance.",
+			},
+
+			"user_access": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"access_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Users granted access to this compute instance explicitly.",
+			},
+
+			"snapshot": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Snapshots of this compute instance.",
+			},
+
+			"cd": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cdrom_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Description: "CD-ROM image inserted into this compute instance.",
+			},
+
+			"pin_to_stack": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is compute pinned to its stack.",
+			},
+
+			"pause": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is compute paused.",
+			},
+
+			"power_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Power state of this compute: running, stopped or paused. Empty while compute is in transition.",
+			},
 		},
 	}
 }
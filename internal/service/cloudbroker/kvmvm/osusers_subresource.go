@@ -32,12 +32,11 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 package kvmvm
 
 import (
-	log "github.com/sirupsen/logrus"
-
+	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func parseOsUsers(logins []OsUserRecord) []interface{} {
+func parseOsUsers(ctx context.Context, logins []OsUserRecord) []interface{} {
 	var result = make([]interface{}, len(logins))
 
 	for index, value := range logins {
@@ -48,7 +47,7 @@ func parseOsUsers(logins []OsUserRecord) []interface{} {
 		elem["password"] = value.Password
 		elem["public_key"] = value.PubKey
 		result[index] = elem
-		log.Debugf("parseOsUsers: parsed element %d - login %q", index, value.Login)
+		log.Debugf(ctx, "parseOsUsers: parsed element %d - login %q", index, value.Login)
 	}
 
 	return result
@@ -37,10 +37,13 @@ import (
 	"net/url"
 	"strconv"
 
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/cloudinit"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/dc"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/statefuncs"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -53,10 +56,8 @@ func cloudInitDiffSupperss(key, oldVal, newVal string, d *schema.ResourceData) b
 		// and there is a chance that the user will want custom cloud init parameters - so we check if
 		// cloud_init is explicitly set in TF file by making sure that its new value is different from "applied",
 		// which is a reserved key word.
-		log.Debugf("cloudInitDiffSupperss: key=%s, oldVal=%q, newVal=%q -> suppress=FALSE", key, oldVal, newVal)
 		return false // there is a difference between stored and new value
 	}
-	log.Debugf("cloudInitDiffSupperss: key=%s, oldVal=%q, newVal=%q -> suppress=TRUE", key, oldVal, newVal)
 	return true // suppress difference
 }
 
@@ -64,11 +65,11 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	// we assume all mandatory parameters it takes to create a comptue instance are properly
 	// specified - we rely on schema "Required" attributes to let Terraform validate them for us
 
-	log.Debugf("resourceComputeCreate: called for Compute name %q, RG ID %d", d.Get("name").(string), d.Get("rg_id").(int))
+	log.Debugf(ctx, "resourceComputeCreate: called for Compute name %q, RG ID %d", d.Get("name").(string), d.Get("rg_id").(int))
 
 	// create basic Compute (i.e. without extra disks and network connections - those will be attached
 	// by subsequent individual API calls).
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("rgId", fmt.Sprintf("%d", d.Get("rg_id").(int)))
 	urlValues.Add("name", d.Get("name").(string))
@@ -92,31 +93,21 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		urlValues.Add("pool", pool.(string))
 	}
 
-	/*
-		sshKeysVal, sshKeysSet := d.GetOk("ssh_keys")
-		if sshKeysSet {
-			// process SSH Key settings and set API values accordingly
-			log.Debugf("resourceComputeCreate: calling makeSshKeysArgString to setup SSH keys for guest login(s)")
-			urlValues.Add("userdata", makeSshKeysArgString(sshKeysVal.([]interface{})))
-		}
-	*/
-
 	computeCreateAPI := KvmX86CreateAPI
 	driver := d.Get("driver").(string)
 	if driver == "KVM_PPC" {
 		computeCreateAPI = KvmPPCCreateAPI
-		log.Debugf("resourceComputeCreate: creating Compute of type KVM VM PowerPC")
+		log.Debugf(ctx, "resourceComputeCreate: creating Compute of type KVM VM PowerPC")
 	} else { // note that we do not validate arch value for explicit "KVM_X86" here
-		log.Debugf("resourceComputeCreate: creating Compute of type KVM VM x86")
+		log.Debugf(ctx, "resourceComputeCreate: creating Compute of type KVM VM x86")
 	}
 
-	argVal, argSet = d.GetOk("cloud_init")
-	if argSet {
-		// userdata must not be empty string and must not be a reserved keyword "applied"
-		userdata := argVal.(string)
-		if userdata != "" && userdata != "applied" {
-			urlValues.Add("userdata", userdata)
-		}
+	userdata, err := cloudinit.Userdata(d.Get("cloud_init").(string), d.Get("cloud_config"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if userdata != "" {
+		urlValues.Add("userdata", userdata)
 	}
 
 	apiResp, err := c.DecortAPICall(ctx, "POST", computeCreateAPI, urlValues)
@@ -137,22 +128,22 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 			urlValues.Add("detachDisks", "1")
 
 			if _, err := c.DecortAPICall(ctx, "POST", ComputeDeleteAPI, urlValues); err != nil {
-				log.Errorf("resourceComputeCreate: could not delete compute after failed creation: %v", err)
+				log.Errorf(ctx, "resourceComputeCreate: could not delete compute after failed creation: %v", err)
 			}
 			d.SetId("")
 		}
 	}()
 
-	log.Debugf("resourceComputeCreate: new simple Compute ID %d, name %s created", compId, d.Get("name").(string))
+	log.Debugf(ctx, "resourceComputeCreate: new simple Compute ID %d, name %s created", compId, d.Get("name").(string))
 
 	// Configure data disks if any
 	argVal, argSet = d.GetOk("extra_disks")
 	if argSet && argVal.(*schema.Set).Len() > 0 {
 		// urlValues.Add("desc", argVal.(string))
-		log.Debugf("resourceComputeCreate: calling utilityComputeExtraDisksConfigure to attach %d extra disk(s)", argVal.(*schema.Set).Len())
+		log.Debugf(ctx, "resourceComputeCreate: calling utilityComputeExtraDisksConfigure to attach %d extra disk(s)", argVal.(*schema.Set).Len())
 		err = utilityComputeExtraDisksConfigure(ctx, d, m, false) // do_delta=false, as we are working on a new compute
 		if err != nil {
-			log.Errorf("resourceComputeCreate: error when attaching extra disk(s) to a new Compute ID %d: %v", compId, err)
+			log.Errorf(ctx, "resourceComputeCreate: error when attaching extra disk(s) to a new Compute ID %d: %v", compId, err)
 			cleanup = true
 			return diag.FromErr(err)
 		}
@@ -160,38 +151,82 @@ func resourceComputeCreate(ctx context.Context, d *schema.ResourceData, m interf
 	// Configure external networks if any
 	argVal, argSet = d.GetOk("network")
 	if argSet && argVal.(*schema.Set).Len() > 0 {
-		log.Debugf("resourceComputeCreate: calling utilityComputeNetworksConfigure to attach %d network(s)", argVal.(*schema.Set).Len())
+		log.Debugf(ctx, "resourceComputeCreate: calling utilityComputeNetworksConfigure to attach %d network(s)", argVal.(*schema.Set).Len())
 		err = utilityComputeNetworksConfigure(ctx, d, m, false) // do_delta=false, as we are working on a new compute
 		if err != nil {
-			log.Errorf("resourceComputeCreate: error when attaching networks to a new Compute ID %d: %s", compId, err)
+			log.Errorf(ctx, "resourceComputeCreate: error when attaching networks to a new Compute ID %d: %s", compId, err)
+			cleanup = true
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := utilityComputeDevicesConfigure(ctx, d, m); err != nil {
+		log.Errorf(ctx, "resourceComputeCreate: error when attaching devices to a new Compute ID %d: %v", compId, err)
+		cleanup = true
+		return diag.FromErr(err)
+	}
+
+	// compute is migrated while it is still stopped
+	if stackId, ok := d.GetOk("stack_id"); ok {
+		if err := utilityComputeMigrate(ctx, d, m, stackId.(int)); err != nil {
 			cleanup = true
 			return diag.FromErr(err)
 		}
 	}
 
 	// Note bene: we created compute in a STOPPED state (this is required to properly attach 1st network interface),
-	// now we need to start it before we report the sequence complete
-	if d.Get("started").(bool) {
-		reqValues := &url.Values{}
-		reqValues.Add("computeId", fmt.Sprintf("%d", compId))
-		log.Debugf("resourceComputeCreate: starting Compute ID %d after completing its resource configuration", compId)
-		if _, err := c.DecortAPICall(ctx, "POST", ComputeStartAPI, reqValues); err != nil {
+	// now we need to bring it to the configured power state before we report the sequence complete
+	if powerState := utilityComputeTargetPowerState(d, true); powerState != powerstate.Stopped {
+		log.Debugf(ctx, "resourceComputeCreate: bringing Compute ID %d to power state %s after completing its resource configuration", compId, powerState)
+		if err := utilityComputeSetPowerState(ctx, d, m, powerState); err != nil {
 			cleanup = true
 			return diag.FromErr(err)
 		}
 	}
 
-	log.Debugf("resourceComputeCreate: new Compute ID %d, name %s creation sequence complete", compId, d.Get("name").(string))
+	// the rest of settings do not affect the compute itself, so that failures are reported
+	// as warnings instead of destroying the new compute
+	warnings := dc.Warnings{}
+	if err := utilityComputeToggle(ctx, d, m, "enabled", ComputeEnableAPI, ComputeDisableAPI); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputeAffinityConfigure(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputeTagsConfigure(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputePfwConfigure(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputeUserAccessConfigure(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputeSnapshotsConfigure(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputeCdConfigure(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+	if err := utilityComputeToggle(ctx, d, m, "pin_to_stack", ComputePinToStackAPI, ComputeUnpinFromStackAPI); err != nil {
+		warnings.Add(err)
+	}
+
+	if err := utilityComputeDevicesRead(ctx, d, m); err != nil {
+		warnings.Add(err)
+	}
+
+	log.Debugf(ctx, "resourceComputeCreate: new Compute ID %d, name %s creation sequence complete", compId, d.Get("name").(string))
 
 	// We may reuse dataSourceComputeRead here as we maintain similarity
 	// between Compute resource and Compute data source schemas
 	// Compute read function will also update resource ID on success, so that Terraform
 	// will know the resource exists
-	return dataSourceComputeRead(ctx, d, m)
+	return append(warnings.Get(), dataSourceComputeRead(ctx, d, m)...)
 }
 
 func resourceComputeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceComputeRead: called for Compute name %s, RG ID %d",
+	log.Debugf(ctx, "resourceComputeRead: called for Compute name %s, RG ID %d",
 		d.Get("name").(string), d.Get("rg_id").(int))
 
 	compFacts, err := utilityComputeCheckPresence(ctx, d, m)
@@ -203,30 +238,79 @@ func resourceComputeRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return nil
 	}
 
-	if err = flattenCompute(d, compFacts); err != nil {
+	if err = flattenCompute(ctx, d, compFacts); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = utilityComputeSettingsRead(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = utilityComputeDevicesRead(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if controller.OmitSecretsOf(m) {
+		// users are kept, so that only their passwords are left out of state
+		d.Set("os_users", flattens.OmitSecrets(d.Get("os_users")))
+	}
 
-	log.Debugf("resourceComputeRead: after flattenCompute: Compute ID %s, name %q, RG ID %d",
+	log.Debugf(ctx, "resourceComputeRead: after flattenCompute: Compute ID %s, name %q, RG ID %d",
 		d.Id(), d.Get("name").(string), d.Get("rg_id").(int))
 
 	return nil
 }
 
 func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceComputeUpdate: called for Compute ID %s / name %s, RGID %d",
+	log.Debugf(ctx, "resourceComputeUpdate: called for Compute ID %s / name %s, RGID %d",
 		d.Id(), d.Get("name").(string), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	/*
+		0. Move to another RG or stack
 		1. Resize CPU/RAM
 		2. Resize (grow) boot disk
 		3. Update extra disks
 		4. Update networks
 		5. Start/stop
+		6. Update other settings
 	*/
 
+	// 0. Move to another RG or stack, these are admin-only operations
+	if d.HasChange("rg_id") {
+		params := &url.Values{}
+		params.Add("computeId", d.Id())
+		params.Add("rgId", strconv.Itoa(d.Get("rg_id").(int)))
+		log.Debugf(ctx, "resourceComputeUpdate: moving Compute ID %s to RG ID %d", d.Id(), d.Get("rg_id").(int))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeMoveToRgAPI, params); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("stack_id") {
+		if stackId, ok := d.GetOk("stack_id"); ok {
+			if err := utilityComputeMigrate(ctx, d, m, stackId.(int)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChanges("name", "description") {
+		params := &url.Values{}
+		params.Add("computeId", d.Id())
+		params.Add("name", d.Get("name").(string))
+		params.Add("desc", d.Get("description").(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeUpdateAPI, params); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// compute is enabled before it may be started below and disabled after it has been stopped
+	if d.HasChange("enabled") && d.Get("enabled").(bool) {
+		if err := utilityComputeToggle(ctx, d, m, "enabled", ComputeEnableAPI, ComputeDisableAPI); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// 1. Resize CPU/RAM
 	params := &url.Values{}
 	doUpdate := false
@@ -249,7 +333,7 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if doUpdate {
-		log.Debugf("resourceComputeUpdate: changing CPU %d -> %d and/or RAM %d -> %d",
+		log.Debugf(ctx, "resourceComputeUpdate: changing CPU %d -> %d and/or RAM %d -> %d",
 			oldCpu.(int), newCpu.(int),
 			oldRam.(int), newRam.(int))
 		params.Add("force", "true")
@@ -265,14 +349,14 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		bdsParams := &url.Values{}
 		bdsParams.Add("diskId", fmt.Sprintf("%d", d.Get("boot_disk_id").(int)))
 		bdsParams.Add("size", fmt.Sprintf("%d", newSize.(int)))
-		log.Debugf("resourceComputeUpdate: compute ID %s, boot disk ID %d resize %d -> %d",
+		log.Debugf(ctx, "resourceComputeUpdate: compute ID %s, boot disk ID %d resize %d -> %d",
 			d.Id(), d.Get("boot_disk_id").(int), oldSize.(int), newSize.(int))
 		_, err := c.DecortAPICall(ctx, "POST", DisksResizeAPI, bdsParams)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else if oldSize.(int) > newSize.(int) {
-		log.Warnf("resourceComputeUpdate: compute ID %s - shrinking boot disk is not allowed", d.Id())
+		log.Warnf(ctx, "resourceComputeUpdate: compute ID %s - shrinking boot disk is not allowed", d.Id())
 	}
 
 	// 3. Calculate and apply changes to data disks
@@ -287,17 +371,76 @@ func resourceComputeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("started") {
-		params := &url.Values{}
-		params.Add("computeId", d.Id())
-		if d.Get("started").(bool) {
-			if _, err := c.DecortAPICall(ctx, "POST", ComputeStartAPI, params); err != nil {
-				return diag.FromErr(err)
-			}
-		} else {
-			if _, err := c.DecortAPICall(ctx, "POST", ComputeStopAPI, params); err != nil {
-				return diag.FromErr(err)
-			}
+	if d.HasChanges("vgpu", "pci_device") {
+		if err := utilityComputeDevicesConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// 6. Update other settings
+	if err := utilityComputeAffinityConfigure(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("tags") {
+		if err := utilityComputeTagsConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("port_forwarding") {
+		if err := utilityComputePfwConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("user_access") {
+		if err := utilityComputeUserAccessConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("snapshot") {
+		if err := utilityComputeSnapshotsConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("rollback") {
+		if err := utilityComputeRollback(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("cd") {
+		if err := utilityComputeCdConfigure(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := utilityComputeToggle(ctx, d, m, "pin_to_stack", ComputePinToStackAPI, ComputeUnpinFromStackAPI); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// 5. Start/stop, after all changes that need compute stopped are applied
+	if powerState := utilityComputeTargetPowerState(d, false); powerState != "" {
+		log.Debugf(ctx, "resourceComputeUpdate: bringing Compute ID %s to power state %s", d.Id(), powerState)
+		if err := utilityComputeSetPowerState(ctx, d, m, powerState); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("enabled") && !d.Get("enabled").(bool) {
+		if err := utilityComputeToggle(ctx, d, m, "enabled", ComputeEnableAPI, ComputeDisableAPI); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// reset is an action rather than a state, so that it is performed on every change of the trigger
+	if d.HasChange("reset_trigger") && d.Get("reset_trigger").(string) != "" {
+		log.Debugf(ctx, "resourceComputeUpdate: resetting Compute ID %s", d.Id())
+		if err := utilityComputeReset(ctx, d, m); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -311,10 +454,10 @@ func resourceComputeDelete(ctx context.Context, d *schema.ResourceData, m interf
 	// there is no way to restore it.
 	// If compute being destroyed has some extra disks attached, they are
 	// detached from the compute
-	log.Debugf("resourceComputeDelete: called for Compute name %s, RG ID %d",
+	log.Debugf(ctx, "resourceComputeDelete: called for Compute name %s, RG ID %d",
 		d.Get("name").(string), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	params := &url.Values{}
 	params.Add("computeId", d.Id())
@@ -328,6 +471,122 @@ func resourceComputeDelete(ctx context.Context, d *schema.ResourceData, m interf
 	return nil
 }
 
+func affinityRuleSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"topology": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"node", "compute"}, false),
+			Description:  "compute or node, for whom rule applies",
+		},
+		"policy": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"RECOMMENDED", "REQUIRED"}, false),
+			Description:  "RECOMMENDED or REQUIRED, the degree of 'strictness' of this rule",
+		},
+		"mode": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"EQ", "NE", "ANY"}, false),
+			Description:  "EQ or NE or ANY - the comparison mode is 'value', recorded by the specified 'key'",
+		},
+		"key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "key that are taken into account when analyzing this rule will be identified",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "value that must match the key to be taken into account when analyzing this rule",
+		},
+	}
+}
+
+func tagsSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"value": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func portForwardingSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"public_port_start": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+		"public_port_end": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  -1,
+		},
+		"local_port": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+		"proto": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
+		},
+	}
+}
+
+func userAccessSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"access_type": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func snapshotSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"label": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func rollbackSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"label": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Label of the snapshot to roll the compute back to.",
+		},
+		"trigger": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Arbitrary value, e.g. a timestamp. Change it to roll the compute back again.",
+		},
+	}
+}
+
+func cdSubresourceSchemaMake() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cdrom_id": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+	}
+}
+
 func ResourceCompute() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
@@ -336,6 +595,7 @@ func ResourceCompute() *schema.Resource {
 		ReadContext:   resourceComputeRead,
 		UpdateContext: resourceComputeUpdate,
 		DeleteContext: resourceComputeDelete,
+		CustomizeDiff: resourceComputeCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -360,7 +620,15 @@ func ResourceCompute() *schema.Resource {
 				Type:         schema.TypeInt,
 				Required:     true,
 				ValidateFunc: validation.IntAtLeast(1),
-				Description:  "ID of the resource group where this compute should be deployed.",
+				Description:  "ID of the resource group where this compute should be deployed. Changing it moves the compute to another resource group.",
+			},
+
+			"stack_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the stack this compute runs on. Changing it migrates the compute to another stack.",
 			},
 
 			"driver": {
@@ -435,17 +703,141 @@ func ResourceCompute() *schema.Resource {
 				Description: "Optional network connection(s) for this compute. You may specify several network blocks, one for each connection.",
 			},
 
-			/*
-				"ssh_keys": {
-					Type:     schema.TypeList,
-					Optional: true,
-					MaxItems: MaxSshKeysPerCompute,
-					Elem: &schema.Resource{
-						Schema: sshSubresourceSchemaMake(),
-					},
-					Description: "SSH keys to authorize on this compute instance.",
+			"affinity_label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Set affinity label for compute",
+			},
+
+			"affinity_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: affinityRuleSubresourceSchemaMake(),
+				},
+			},
+
+			"anti_affinity_rules": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: affinityRuleSubresourceSchemaMake(),
+				},
+			},
+
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: tagsSubresourceSchemaMake(),
+				},
+			},
+
+			"port_forwarding": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: portForwardingSubresourceSchemaMake(),
+				},
+			},
+
+			"user_access": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: userAccessSubresourceSchemaMake(),
+				},
+			},
+
+			"snapshot": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: snapshotSubresourceSchemaMake(),
+				},
+			},
+
+			"rollback": {
+				Type:     schema.TypeSet,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: rollbackSubresourceSchemaMake(),
+				},
+				Description: "Snapshot to roll the compute back to. Rollback is performed when this block is added or its trigger changes, changing only the label does not roll the compute back. Compute is stopped for rollback and brought back to its power state afterwards.",
+			},
+
+			"vgpu": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: vgpuSubresourceSchemaMake(),
+				},
+				Description: "vGPU(s) attached to this compute. Running or paused compute is stopped to change vGPUs and then brought back to its power state, even if the change fails. When vgpu blocks are omitted, vGPUs attached outside of this resource are left intact.",
+			},
+
+			"pci_device": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: pciDeviceSubresourceSchemaMake(),
 				},
-			*/
+				Description: "PCI device(s) passed through to this compute. Running or paused compute is stopped to change PCI devices and then brought back to its power state, even if the change fails. Attached devices are only read back while pci_device blocks are present. When pci_device blocks are omitted, devices attached outside of this resource are left intact.",
+			},
+
+			"cd": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: cdSubresourceSchemaMake(),
+				},
+			},
+
+			"pin_to_stack": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "If true - enable compute, else - disable. Compute is enabled before it is started and disabled after it is stopped, so that disabled compute must have power_state stopped.",
+			},
+
+			"power_state": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.StringInSlice([]string{powerstate.Running, powerstate.Stopped, powerstate.Paused}, false),
+				ConflictsWith: []string{"started", "pause"},
+				Description:   "Power state of this compute: running, stopped or paused. Provider waits for the compute to reach this state on create and update. New compute is started, unless configured otherwise.",
+			},
+
+			"pause": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Deprecated:  "use power_state instead",
+				Description: "Pause compute.",
+			},
+
+			"reset_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value, e.g. a timestamp. Any change of it to a non-empty value resets the compute, as if it was powered off and on, and waits for it to be running again. Compute must have power_state running. Compute is not reset on create.",
+			},
+
+			"force_stop": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Power off compute instead of graceful shutdown whenever this resource stops it: on snapshot rollback, on changing vGPUs or PCI devices and on changing power_state to stopped.",
+			},
 
 			"description": {
 				Type:        schema.TypeString,
@@ -458,7 +850,17 @@ func ResourceCompute() *schema.Resource {
 				Optional:         true,
 				Default:          "applied",
 				DiffSuppressFunc: cloudInitDiffSupperss,
-				Description:      "Optional cloud_init parameters. Applied when creating new compute instance only, ignored in all other cases.",
+				Description:      "Optional cloud_init parameters. Applied when creating new compute instance only, ignored in all other cases. When cloud_config is set, it must be a #cloud-config document, which is merged with cloud_config.",
+			},
+
+			"cloud_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: cloudinit.SchemaMake(),
+				},
+				Description: "Optional cloud-init settings rendered into #cloud-config userdata. Lists are appended to the same keys of cloud_init document. Applied when creating new compute instance only, ignored in all other cases.",
 			},
 
 			// The rest are Compute properties, which are "computed" once it is created
@@ -498,7 +900,8 @@ func ResourceCompute() *schema.Resource {
 			"started": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     true,
+				Computed:    true,
+				Deprecated:  "use power_state instead",
 				Description: "Is compute started.",
 			},
 		},
@@ -3,6 +3,7 @@ Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
 Authors:
 Petr Krutov, <petr.krutov@digitalenergy.online>
 Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -29,49 +30,31 @@ builds seamlessly.
 Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
 */
 
-package pfw
+package kvmvm
 
 import (
 	"context"
-	"encoding/json"
-	"net/url"
-	"strconv"
-	"strings"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
 )
 
-func utilityPfwCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*PfwRecord, error) {
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-
-	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
-	resp, err := c.DecortAPICall(ctx, "POST", ComputePfwListAPI, urlValues)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp == "" {
-		return nil, nil
-	}
-
-	idS := strings.Split(d.Id(), "-")[1]
-	id, err := strconv.Atoi(idS)
-	if err != nil {
-		return nil, err
-	}
-
-	var pfws []PfwRecord
-	if err := json.Unmarshal([]byte(resp), &pfws); err != nil {
-		return nil, err
-	}
-
-	for _, pfw := range pfws {
-		if pfw.ID == id {
-			return &pfw, nil
+// resourceComputeCustomizeDiff validates vGPUs at plan time, so that stopping compute for
+// an attachment, which is bound to fail, is avoided. Power state changes are ordered by Update,
+// so that only combinations it cannot satisfy are rejected.
+func resourceComputeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if powerState := powerstate.Configured(d); powerState != "" {
+		if !d.Get("enabled").(bool) && d.NewValueKnown("enabled") && powerState != powerstate.Stopped {
+			return fmt.Errorf("disabled compute must be %s, got power state %s", powerstate.Stopped, powerState)
+		}
+		if d.Id() != "" && d.HasChange("reset_trigger") && d.Get("reset_trigger").(string) != "" && powerState != powerstate.Running {
+			return fmt.Errorf("only running compute can be reset, got power state %s", powerState)
 		}
 	}
 
-	return nil, nil
+	if d.HasChange("vgpu") && d.NewValueKnown("vgpu") {
+		return utilityComputeVGPUsCheck(ctx, d, m)
+	}
+	return nil
 }
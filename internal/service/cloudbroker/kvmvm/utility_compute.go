@@ -39,7 +39,6 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -53,9 +52,9 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 
 	// Note that this function will not abort on API errors, but will continue to configure (attach / detach) other individual
 	// disks via atomic API calls. However, it will not retry failed manipulation on the same disk.
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
-	log.Debugf("utilityComputeExtraDisksConfigure: called for Compute ID %s with do_delta = %t", d.Id(), do_delta)
+	log.Debugf(ctx, "utilityComputeExtraDisksConfigure: called for Compute ID %s with do_delta = %t", d.Id(), do_delta)
 
 	// NB: as of rc-1.25 "extra_disks" are TypeSet with the elem of TypeInt
 	old_set, new_set := d.GetChange("extra_disks")
@@ -81,7 +80,7 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 		}
 
 		if apiErrCount > 0 {
-			log.Errorf("utilityComputeExtraDisksConfigure: there were %d error(s) when attaching disks to Compute ID %s. Last error was: %s",
+			log.Errorf(ctx, "utilityComputeExtraDisksConfigure: there were %d error(s) when attaching disks to Compute ID %s. Last error was: %s",
 				apiErrCount, d.Id(), lastSavedError)
 			return lastSavedError
 		}
@@ -90,7 +89,7 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 	}
 
 	detach_set := old_set.(*schema.Set).Difference(new_set.(*schema.Set))
-	log.Debugf("utilityComputeExtraDisksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeExtraDisksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
 	for _, diskId := range detach_set.List() {
 		urlValues := &url.Values{}
 		urlValues.Add("computeId", d.Id())
@@ -98,14 +97,14 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 		_, err := c.DecortAPICall(ctx, "POST", ComputeDiskDetachAPI, urlValues)
 		if err != nil {
 			// failed to detach disk - there will be partial resource update
-			log.Errorf("utilityComputeExtraDisksConfigure: failed to detach disk ID %d from Compute ID %s: %s", diskId.(int), d.Id(), err)
+			log.Errorf(ctx, "utilityComputeExtraDisksConfigure: failed to detach disk ID %d from Compute ID %s: %s", diskId.(int), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
 		}
 	}
 
 	attach_set := new_set.(*schema.Set).Difference(old_set.(*schema.Set))
-	log.Debugf("utilityComputeExtraDisksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeExtraDisksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
 	for _, diskId := range attach_set.List() {
 		urlValues := &url.Values{}
 		urlValues.Add("computeId", d.Id())
@@ -113,14 +112,14 @@ func utilityComputeExtraDisksConfigure(ctx context.Context, d *schema.ResourceDa
 		_, err := c.DecortAPICall(ctx, "POST", ComputeDiskAttachAPI, urlValues)
 		if err != nil {
 			// failed to attach disk - there will be partial resource update
-			log.Errorf("utilityComputeExtraDisksConfigure: failed to attach disk ID %d to Compute ID %s: %s", diskId.(int), d.Id(), err)
+			log.Errorf(ctx, "utilityComputeExtraDisksConfigure: failed to attach disk ID %d to Compute ID %s: %s", diskId.(int), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
 		}
 	}
 
 	if apiErrCount > 0 {
-		log.Errorf("utilityComputeExtraDisksConfigure: there were %d error(s) when managing disks of Compute ID %s. Last error was: %s",
+		log.Errorf(ctx, "utilityComputeExtraDisksConfigure: there were %d error(s) when managing disks of Compute ID %s. Last error was: %s",
 			apiErrCount, d.Id(), lastSavedError)
 		return lastSavedError
 	}
@@ -135,7 +134,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	// Otherwise it will apply whatever is found in the new set of "network" right away.
 	// Primary use of do_delta=false is when calling this function from compute Create handler.
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	old_set, new_set := d.GetChange("network")
 
@@ -166,7 +165,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 		}
 
 		if apiErrCount > 0 {
-			log.Errorf("utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
+			log.Errorf(ctx, "utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
 				apiErrCount, d.Id(), lastSavedError)
 			return lastSavedError
 		}
@@ -174,7 +173,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	}
 
 	detach_set := old_set.(*schema.Set).Difference(new_set.(*schema.Set))
-	log.Debugf("utilityComputeNetworksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeNetworksConfigure: detach set has %d items for Compute ID %s", detach_set.Len(), d.Id())
 	for _, runner := range detach_set.List() {
 		urlValues := &url.Values{}
 		net_data := runner.(map[string]interface{})
@@ -184,7 +183,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 		_, err := c.DecortAPICall(ctx, "POST", ComputeNetDetachAPI, urlValues)
 		if err != nil {
 			// failed to detach this network - there will be partial resource update
-			log.Errorf("utilityComputeNetworksConfigure: failed to detach net ID %d of type %s from Compute ID %s: %s",
+			log.Errorf(ctx, "utilityComputeNetworksConfigure: failed to detach net ID %d of type %s from Compute ID %s: %s",
 				net_data["net_id"].(int), net_data["net_type"].(string), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
@@ -192,7 +191,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	}
 
 	attach_set := new_set.(*schema.Set).Difference(old_set.(*schema.Set))
-	log.Debugf("utilityComputeNetworksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
+	log.Debugf(ctx, "utilityComputeNetworksConfigure: attach set has %d items for Compute ID %s", attach_set.Len(), d.Id())
 	for _, runner := range attach_set.List() {
 		urlValues := &url.Values{}
 		net_data := runner.(map[string]interface{})
@@ -205,7 +204,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 		_, err := c.DecortAPICall(ctx, "POST", ComputeNetAttachAPI, urlValues)
 		if err != nil {
 			// failed to attach this network - there will be partial resource update
-			log.Errorf("utilityComputeNetworksConfigure: failed to attach net ID %d of type %s to Compute ID %s: %s",
+			log.Errorf(ctx, "utilityComputeNetworksConfigure: failed to attach net ID %d of type %s to Compute ID %s: %s",
 				net_data["net_id"].(int), net_data["net_type"].(string), d.Id(), err)
 			apiErrCount++
 			lastSavedError = err
@@ -213,7 +212,7 @@ func utilityComputeNetworksConfigure(ctx context.Context, d *schema.ResourceData
 	}
 
 	if apiErrCount > 0 {
-		log.Errorf("utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
+		log.Errorf(ctx, "utilityComputeNetworksConfigure: there were %d error(s) when managing networks of Compute ID %s. Last error was: %s",
 			apiErrCount, d.Id(), lastSavedError)
 		return lastSavedError
 	}
@@ -235,7 +234,7 @@ func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m
 	// method for resource's Exists method.
 	//
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	// make it possible to use "read" & "check presence" functions with compute ID set so
@@ -254,7 +253,7 @@ func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m
 
 	if idSet {
 		// compute ID is specified, try to get compute instance straight by this ID
-		log.Debugf("utilityComputeCheckPresence: locating compute by its ID %d", theId)
+		log.Debugf(ctx, "utilityComputeCheckPresence: locating compute by its ID %d", theId)
 		urlValues.Add("computeId", fmt.Sprintf("%d", theId))
 		computeFacts, err := c.DecortAPICall(ctx, "POST", ComputeGetAPI, urlValues)
 		if err != nil {
@@ -281,7 +280,7 @@ func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m
 		return "", err
 	}
 
-	log.Debugf("utilityComputeCheckPresence: ready to unmarshal string %s", apiResp)
+	log.Debugf(ctx, "utilityComputeCheckPresence: ready to unmarshal string %s", apiResp)
 
 	computeList := RgListComputesResp{}
 	err = json.Unmarshal([]byte(apiResp), &computeList)
@@ -290,11 +289,11 @@ func utilityComputeCheckPresence(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// log.Printf("%#v", computeList)
-	log.Debugf("utilityComputeCheckPresence: traversing decoded JSON of length %d", len(computeList))
+	log.Debugf(ctx, "utilityComputeCheckPresence: traversing decoded JSON of length %d", len(computeList))
 	for index, item := range computeList {
 		// need to match Compute by name, skip Computes with the same name in DESTROYED satus
 		if item.Name == computeName.(string) && item.Status != "DESTROYED" {
-			log.Debugf("utilityComputeCheckPresence: index %d, matched name %s", index, item.Name)
+			log.Debugf(ctx, "utilityComputeCheckPresence: index %d, matched name %s", index, item.Name)
 			// we found the Compute we need - now get detailed information via compute/get API
 			cgetValues := &url.Values{}
 			cgetValues.Add("computeId", fmt.Sprintf("%d", item.ID))
@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+)
+
+// utilityComputeTargetPowerState returns power state compute should be brought to, or empty
+// string if power state is not changed. New compute is started, unless configured otherwise.
+func utilityComputeTargetPowerState(d *schema.ResourceData, isNew bool) string {
+	return powerstate.Target(d, isNew, powerstate.Running)
+}
+
+// utilityComputeSetPowerState brings compute to the target power state and waits for it to settle,
+// stopping it gracefully or forcibly as configured by force_stop
+func utilityComputeSetPowerState(ctx context.Context, d *schema.ResourceData, m interface{}, target string) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = powerstate.Set(ctx, sdk.NewCloudBroker(m.(controller.APICaller)).Compute(), computeId, target, d.Get("force_stop").(bool))
+	return err
+}
+
+// utilityComputeStopped runs op against stopped compute and brings compute back to its previous
+// power state, which is returned
+func utilityComputeStopped(ctx context.Context, d *schema.ResourceData, m interface{}, op func() error) (string, error) {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return "", err
+	}
+	return powerstate.WithStopped(ctx, sdk.NewCloudBroker(m.(controller.APICaller)).Compute(), computeId, d.Get("force_stop").(bool), op)
+}
+
+// utilityComputeReset resets running compute and waits for it to be running again
+func utilityComputeReset(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	return powerstate.Reset(ctx, sdk.NewCloudBroker(m.(controller.APICaller)).Compute(), computeId)
+}
@@ -0,0 +1,448 @@
+/*
+Copyright (c) 2019-2022 Digital Energy Cloud Solutions LLC. All Rights Reserved.
+Authors:
+Petr Krutov, <petr.krutov@digitalenergy.online>
+Stanislav Solovev, <spsolovev@digitalenergy.online>
+Kasim Baybikov, <kmbaybikov@basistech.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Terraform DECORT provider - manage resources provided by DECORT (Digital Energy Cloud
+Orchestration Technology) with Terraform by Hashicorp.
+
+Source code: https://repository.basistech.ru/BASIS/terraform-provider-decort
+
+Please see README.md to learn where to place source code so that it
+builds seamlessly.
+
+Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/wiki
+*/
+
+package kvmvm
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/powerstate"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	computesdk "repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/techstatus"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Functions below apply compute settings, which are managed by separate API calls. They compare
+// old and new values of the corresponding schema keys, so that on create, when old values are
+// empty, they apply whatever is configured, and on update they apply changes only.
+
+func utilityComputeAffinityConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+
+	if d.HasChange("affinity_label") {
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		affinityLabel := d.Get("affinity_label").(string)
+		api := ComputeAffinityLabelRemoveAPI
+		if affinityLabel != "" {
+			urlValues.Add("affinityLabel", affinityLabel)
+			api = ComputeAffinityLabelSetAPI
+		}
+		log.Debugf(ctx, "utilityComputeAffinityConfigure: setting affinity label %q of Compute ID %s", affinityLabel, d.Id())
+		if _, err := c.DecortAPICall(ctx, "POST", api, urlValues); err != nil {
+			return err
+		}
+	}
+
+	rules := []struct {
+		key, addAPI, removeAPI, clearAPI string
+	}{
+		{"affinity_rules", ComputeAffinityRuleAddAPI, ComputeAffinityRuleRemoveAPI, ComputeAffinityRulesClearAPI},
+		{"anti_affinity_rules", ComputeAntiAffinityRuleAddAPI, ComputeAntiAffinityRuleRemoveAPI, ComputeAntiAffinityRulesClearAPI},
+	}
+	for _, rule := range rules {
+		if !d.HasChange(rule.key) {
+			continue
+		}
+
+		oldRules, newRules := d.GetChange(rule.key)
+		oldConv := oldRules.([]interface{})
+		newConv := newRules.([]interface{})
+
+		if len(newConv) == 0 {
+			urlValues := &url.Values{}
+			urlValues.Add("computeId", d.Id())
+			if _, err := c.DecortAPICall(ctx, "POST", rule.clearAPI, urlValues); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, el := range oldConv {
+			if isContainsAR(newConv, el) {
+				continue
+			}
+			if _, err := c.DecortAPICall(ctx, "POST", rule.removeAPI, affinityRuleValues(d.Id(), el)); err != nil {
+				return err
+			}
+		}
+		for _, el := range newConv {
+			if isContainsAR(oldConv, el) {
+				continue
+			}
+			if _, err := c.DecortAPICall(ctx, "POST", rule.addAPI, affinityRuleValues(d.Id(), el)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func affinityRuleValues(computeId string, rule interface{}) *url.Values {
+	arConv := rule.(map[string]interface{})
+	urlValues := &url.Values{}
+	urlValues.Add("computeId", computeId)
+	urlValues.Add("topology", arConv["topology"].(string))
+	urlValues.Add("policy", arConv["policy"].(string))
+	urlValues.Add("mode", arConv["mode"].(string))
+	urlValues.Add("key", arConv["key"].(string))
+	urlValues.Add("value", arConv["value"].(string))
+	return urlValues
+}
+
+func isContainsAR(els []interface{}, el interface{}) bool {
+	for _, elOld := range els {
+		elOldConv := elOld.(map[string]interface{})
+		elConv := el.(map[string]interface{})
+		if elOldConv["key"].(string) == elConv["key"].(string) &&
+			elOldConv["value"].(string) == elConv["value"].(string) &&
+			elOldConv["mode"].(string) == elConv["mode"].(string) &&
+			elOldConv["topology"].(string) == elConv["topology"].(string) &&
+			elOldConv["policy"].(string) == elConv["policy"].(string) {
+			return true
+		}
+	}
+	return false
+}
+
+func utilityComputeTagsConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+
+	oldSet, newSet := d.GetChange("tags")
+	for _, tagInterface := range oldSet.(*schema.Set).Difference(newSet.(*schema.Set)).List() {
+		tagItem := tagInterface.(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("key", tagItem["key"].(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeTagRemoveAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	for _, tagInterface := range newSet.(*schema.Set).Difference(oldSet.(*schema.Set)).List() {
+		tagItem := tagInterface.(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("key", tagItem["key"].(string))
+		urlValues.Add("value", tagItem["value"].(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeTagAddAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func utilityComputePfwConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+
+	oldSet, newSet := d.GetChange("port_forwarding")
+	for _, pfwInterface := range oldSet.(*schema.Set).Difference(newSet.(*schema.Set)).List() {
+		if _, err := c.DecortAPICall(ctx, "POST", ComputePfwDelAPI, pfwValues(d.Id(), pfwInterface)); err != nil {
+			return err
+		}
+	}
+
+	for _, pfwInterface := range newSet.(*schema.Set).Difference(oldSet.(*schema.Set)).List() {
+		if _, err := c.DecortAPICall(ctx, "POST", ComputePfwAddAPI, pfwValues(d.Id(), pfwInterface)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func pfwValues(computeId string, pfw interface{}) *url.Values {
+	pfwItem := pfw.(map[string]interface{})
+	urlValues := &url.Values{}
+	urlValues.Add("computeId", computeId)
+	urlValues.Add("publicPortStart", strconv.Itoa(pfwItem["public_port_start"].(int)))
+	if pfwItem["public_port_end"].(int) == -1 {
+		// single port is forwarded, if the end of the range is not set
+		urlValues.Add("publicPortEnd", strconv.Itoa(pfwItem["public_port_start"].(int)))
+	} else {
+		urlValues.Add("publicPortEnd", strconv.Itoa(pfwItem["public_port_end"].(int)))
+	}
+	urlValues.Add("localBasePort", strconv.Itoa(pfwItem["local_port"].(int)))
+	urlValues.Add("proto", pfwItem["proto"].(string))
+	return urlValues
+}
+
+func utilityComputeUserAccessConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+
+	oldSet, newSet := d.GetChange("user_access")
+	for _, userAccessInterface := range oldSet.(*schema.Set).Difference(newSet.(*schema.Set)).List() {
+		userAccessItem := userAccessInterface.(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("userName", userAccessItem["username"].(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeUserRevokeAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	for _, userAccessInterface := range newSet.(*schema.Set).Difference(oldSet.(*schema.Set)).List() {
+		userAccessItem := userAccessInterface.(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("userName", userAccessItem["username"].(string))
+		urlValues.Add("accesstype", userAccessItem["access_type"].(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeUserGrantAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func utilityComputeSnapshotsConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+
+	oldSet, newSet := d.GetChange("snapshot")
+	for _, snapshotInterface := range oldSet.(*schema.Set).Difference(newSet.(*schema.Set)).List() {
+		snapshotItem := snapshotInterface.(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("label", snapshotItem["label"].(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeSnapshotDeleteAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	for _, snapshotInterface := range newSet.(*schema.Set).Difference(oldSet.(*schema.Set)).List() {
+		snapshotItem := snapshotInterface.(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("label", snapshotItem["label"].(string))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeSnapshotCreateAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// utilityComputeRollback rolls compute back to the snapshot set in "rollback" block. Rollback is an
+// action, so that it is performed only when the block is added or its trigger changes, but not when
+// only the label changes. Compute must be stopped for rollback, so that it is stopped first and
+// brought back to its power state afterwards.
+func utilityComputeRollback(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	oldRollback, newRollback := d.GetChange("rollback")
+	if newRollback.(*schema.Set).Len() == 0 {
+		return nil
+	}
+	rollbackItem := newRollback.(*schema.Set).List()[0].(map[string]interface{})
+	if oldRollback.(*schema.Set).Len() > 0 {
+		oldItem := oldRollback.(*schema.Set).List()[0].(map[string]interface{})
+		if oldItem["trigger"].(string) == rollbackItem["trigger"].(string) {
+			log.Debugf(ctx, "utilityComputeRollback: trigger of rollback of Compute ID %s is not changed, skipping", d.Id())
+			return nil
+		}
+	}
+	c := m.(controller.APICaller)
+
+	log.Debugf(ctx, "utilityComputeRollback: rolling Compute ID %s back to snapshot %q", d.Id(), rollbackItem["label"].(string))
+	_, err := utilityComputeStopped(ctx, d, m, func() error {
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("label", rollbackItem["label"].(string))
+		_, err := c.DecortAPICall(ctx, "POST", ComputeSnapshotRollbackAPI, urlValues)
+		return err
+	})
+	return err
+}
+
+func utilityComputeCdConfigure(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(controller.APICaller)
+
+	oldSet, newSet := d.GetChange("cd")
+	if oldSet.(*schema.Set).Difference(newSet.(*schema.Set)).Len() > 0 {
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeCdEjectAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	addedCd := newSet.(*schema.Set).Difference(oldSet.(*schema.Set)).List()
+	if len(addedCd) > 0 {
+		cdItem := addedCd[0].(map[string]interface{})
+		urlValues := &url.Values{}
+		urlValues.Add("computeId", d.Id())
+		urlValues.Add("cdromId", strconv.Itoa(cdItem["cdrom_id"].(int)))
+		if _, err := c.DecortAPICall(ctx, "POST", ComputeCdInsertAPI, urlValues); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// utilityComputeToggle calls onAPI or offAPI, when boolean schema key changes to true or false
+// respectively, e.g. to pin compute to stack or to pause it
+func utilityComputeToggle(ctx context.Context, d *schema.ResourceData, m interface{}, key, onAPI, offAPI string) error {
+	if !d.HasChange(key) {
+		return nil
+	}
+	c := m.(controller.APICaller)
+
+	api := offAPI
+	if d.Get(key).(bool) {
+		api = onAPI
+	}
+	urlValues := &url.Values{}
+	urlValues.Add("computeId", d.Id())
+	log.Debugf(ctx, "utilityComputeToggle: %s=%t for Compute ID %s", key, d.Get(key).(bool), d.Id())
+	_, err := c.DecortAPICall(ctx, "POST", api, urlValues)
+	return err
+}
+
+// utilityComputeSettingsRead reads compute settings, which are managed by separate API calls, so
+// that changes made outside of Terraform are detected
+func utilityComputeSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	computeId, err := strconv.ParseUint(d.Id(), 10, 64)
+	if err != nil {
+		return err
+	}
+	api := sdk.NewCloudBroker(m.(controller.APICaller)).Compute()
+
+	compute, err := api.Get(ctx, computesdk.GetRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+	pfws, err := api.PFWList(ctx, computesdk.IDRequest{ComputeID: computeId})
+	if err != nil {
+		return err
+	}
+
+	d.Set("affinity_label", compute.AffinityLabel)
+	d.Set("affinity_rules", flattenComputeRules(compute.AffinityRules))
+	d.Set("anti_affinity_rules", flattenComputeRules(compute.AntiAffinityRules))
+
+	tags := make([]map[string]interface{}, 0, len(compute.Tags))
+	for key, value := range compute.Tags {
+		tags = append(tags, map[string]interface{}{"key": key, "value": value})
+	}
+	d.Set("tags", tags)
+
+	pfwList := make([]map[string]interface{}, 0, len(pfws))
+	for _, pfw := range pfws {
+		// single port is reported with unset end of the range, as it is configured
+		publicPortEnd := int(pfw.PublicPortEnd)
+		if pfw.PublicPortEnd == pfw.PublicPortStart {
+			publicPortEnd = -1
+		}
+		pfwList = append(pfwList, map[string]interface{}{
+			"public_port_start": int(pfw.PublicPortStart),
+			"public_port_end":   publicPortEnd,
+			"local_port":        int(pfw.LocalPort),
+			"proto":             pfw.Protocol,
+		})
+	}
+	d.Set("port_forwarding", pfwList)
+
+	userAccess := make([]map[string]interface{}, 0)
+	for _, acl := range compute.ACL.ComputeACL {
+		// inherited account and RG permissions are not managed by compute
+		if explicit, _ := acl.Explicit.(bool); !explicit {
+			continue
+		}
+		userAccess = append(userAccess, map[string]interface{}{"username": acl.UserGroupID, "access_type": acl.Right})
+	}
+	d.Set("user_access", userAccess)
+
+	snapshots := make([]map[string]interface{}, 0, len(compute.SnapSets))
+	for _, snapSet := range compute.SnapSets {
+		snapshots = append(snapshots, map[string]interface{}{"label": snapSet.Label})
+	}
+	d.Set("snapshot", snapshots)
+
+	cd := make([]map[string]interface{}, 0, 1)
+	if compute.CdImageID != 0 {
+		cd = append(cd, map[string]interface{}{"cdrom_id": int(compute.CdImageID)})
+	}
+	d.Set("cd", cd)
+
+	d.Set("pin_to_stack", compute.Pinned)
+	d.Set("pause", compute.TechStatus == techstatus.Paused)
+	if powerState := powerstate.Of(compute.TechStatus); powerState != "" {
+		d.Set("power_state", powerState)
+	}
+
+	return nil
+}
+
+func flattenComputeRules(rules computesdk.ListRules) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		res = append(res, map[string]interface{}{
+			"topology": rule.Topology,
+			"policy":   rule.Policy,
+			"mode":     rule.Mode,
+			"key":      rule.Key,
+			"value":    rule.Value,
+		})
+	}
+	return res
+}
+
+// utilityComputeMigrate moves compute to the specified stack, unless it is already there
+func utilityComputeMigrate(ctx context.Context, d *schema.ResourceData, m interface{}, stackId int) error {
+	c := m.(controller.APICaller)
+
+	compFacts, err := utilityComputeCheckPresence(ctx, d, m)
+	if err != nil {
+		return err
+	}
+	model := ComputeGetResp{}
+	if err := json.Unmarshal([]byte(compFacts), &model); err != nil {
+		return err
+	}
+	if model.StackID == stackId {
+		return nil
+	}
+
+	urlValues := &url.Values{}
+	urlValues.Add("computeId", d.Id())
+	urlValues.Add("targetStackId", strconv.Itoa(stackId))
+	log.Debugf(ctx, "utilityComputeMigrate: migrating Compute ID %s from stack ID %d to stack ID %d", d.Id(), model.StackID, stackId)
+	_, err = c.DecortAPICall(ctx, "POST", ComputeMigrateAPI, urlValues)
+	return err
+}
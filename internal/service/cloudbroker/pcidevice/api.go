@@ -31,8 +31,12 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package pcidevice
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const pcideviceListAPI = "/restmachine/cloudbroker/pcidevice/list"
 const pcideviceDisableAPI = "/restmachine/cloudbroker/pcidevice/disable"
 const pcideviceEnableAPI = "/restmachine/cloudbroker/pcidevice/enable"
 const pcideviceCreateAPI = "/restmachine/cloudbroker/pcidevice/create"
 const pcideviceDeleteAPI = "/restmachine/cloudbroker/pcidevice/delete"
+
+var log = logging.New("cb_pcidevice")
@@ -41,13 +41,12 @@ import (
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourcePcideviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourcePcideviceCreate: called for pcidevice %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourcePcideviceCreate: called for pcidevice %s", d.Get("name").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("name", d.Get("name").(string))
 	urlValues.Add("hwPath", d.Get("hw_path").(string))
@@ -98,9 +97,9 @@ func resourcePcideviceRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourcePcideviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourcePcideviceDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
+	log.Debugf(ctx, "resourcePcideviceDelete: called for %s, id: %s", d.Get("name").(string), d.Id())
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("deviceId", d.Id())
 	urlValues.Add("force", strconv.FormatBool(d.Get("force").(bool)))
@@ -118,7 +117,7 @@ func resourcePcideviceDelete(ctx context.Context, d *schema.ResourceData, m inte
 func resourcePcideviceEdit(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.HasChange("enable") {
 		state := d.Get("enable").(bool)
-		c := m.(*controller.ControllerCfg)
+		c := m.(controller.APICaller)
 		urlValues := &url.Values{}
 		api := ""
 
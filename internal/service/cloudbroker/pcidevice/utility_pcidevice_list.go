@@ -41,7 +41,7 @@ import (
 
 func utilityPcideviceListCheckPresence(ctx context.Context, m interface{}) (PcideviceList, error) {
 	pcideviceList := PcideviceList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	pcideviceListRaw, err := c.DecortAPICall(ctx, "POST", pcideviceListAPI, urlValues)
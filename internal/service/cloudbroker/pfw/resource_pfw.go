@@ -32,166 +32,22 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 package pfw
 
 import (
-	"context"
-	"fmt"
-	"net/url"
-	"strconv"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/computepfw"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk"
+	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/sdk/compute"
 )
 
-func resourcePfwCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourcePfwCreate: called for compute %d", d.Get("compute_id").(int))
-
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
-	urlValues.Add("publicPortStart", strconv.Itoa(d.Get("public_port_start").(int)))
-	urlValues.Add("localBasePort", strconv.Itoa(d.Get("local_base_port").(int)))
-	urlValues.Add("proto", d.Get("proto").(string))
-
-	if portEnd, ok := d.GetOk("public_port_end"); ok {
-		urlValues.Add("publicPortEnd", strconv.Itoa(portEnd.(int)))
-	}
-
-	pfwId, err := c.DecortAPICall(ctx, "POST", ComputePfwAddAPI, urlValues)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId(fmt.Sprintf("%d-%s", d.Get("compute_id").(int), pfwId))
-
-	pfw, err := utilityPfwCheckPresence(ctx, d, m)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.Set("local_ip", pfw.LocalIP)
-	if _, ok := d.GetOk("public_port_end"); !ok {
-		d.Set("public_port_end", pfw.PublicPortEnd)
-	}
-
-	return nil
-}
-
-func resourcePfwRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourcePfwRead: called for compute %d, rule %s", d.Get("compute_id").(int), d.Id())
-
-	pfw, err := utilityPfwCheckPresence(ctx, d, m)
-	if pfw == nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-
-	d.Set("compute_id", pfw.ComputeID)
-	d.Set("public_port_start", pfw.PublicPortStart)
-	d.Set("public_port_end", pfw.PublicPortEnd)
-	d.Set("local_ip", pfw.LocalIP)
-	d.Set("local_base_port", pfw.LocalPort)
-	d.Set("proto", pfw.Protocol)
-
-	return nil
-}
-
-func resourcePfwDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourcePfwDelete: called for compute %d, rule %s", d.Get("compute_id").(int), d.Id())
-
-	pfw, err := utilityPfwCheckPresence(ctx, d, m)
-	if pfw == nil {
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		return nil
-	}
-
-	c := m.(*controller.ControllerCfg)
-	urlValues := &url.Values{}
-	urlValues.Add("computeId", strconv.Itoa(d.Get("compute_id").(int)))
-	urlValues.Add("ruleId", strconv.Itoa(pfw.ID))
-
-	_, err = c.DecortAPICall(ctx, "POST", ComputePfwDelAPI, urlValues)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
-}
-
-func resourcePfwSchemaMake() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"compute_id": {
-			Type:        schema.TypeInt,
-			Required:    true,
-			ForceNew:    true,
-			Description: "ID of compute instance.",
-		},
-
-		"public_port_start": {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.IntBetween(1, 65535),
-			Description:  "External start port number for the rule.",
-		},
-
-		"public_port_end": {
-			Type:         schema.TypeInt,
-			Optional:     true,
-			Computed:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.IntBetween(1, 65535),
-			Description:  "End port number (inclusive) for the ranged rule.",
-		},
-
-		"local_ip": {
-			Type:        schema.TypeString,
-			Computed:    true,
-			Description: "IP address of compute instance.",
-		},
-
-		"local_base_port": {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.IntBetween(1, 65535),
-			Description:  "Internal base port number.",
-		},
-
-		"proto": {
-			Type:         schema.TypeString,
-			Required:     true,
-			ForceNew:     true,
-			ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
-			Description:  "Network protocol, either 'tcp' or 'udp'.",
-		},
-	}
+// computeAPI returns the compute API client used to manage port forwarding rules
+func computeAPI(m interface{}) *compute.Compute {
+	return sdk.NewCloudBroker(m.(controller.APICaller)).Compute()
 }
 
 func ResourcePfw() *schema.Resource {
-	return &schema.Resource{
-		SchemaVersion: 1,
-
-		CreateContext: resourcePfwCreate,
-		ReadContext:   resourcePfwRead,
-		DeleteContext: resourcePfwDelete,
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-
-		Timeouts: &schema.ResourceTimeout{
-			Create:  &constants.Timeout60s,
-			Read:    &constants.Timeout30s,
-			Update:  &constants.Timeout60s,
-			Delete:  &constants.Timeout60s,
-			Default: &constants.Timeout60s,
-		},
+	return computepfw.ResourcePfw(computeAPI)
+}
 
-		Schema: resourcePfwSchemaMake(),
-	}
+func ResourcePfwSet() *schema.Resource {
+	return computepfw.ResourcePfwSet(computeAPI)
 }
@@ -31,9 +31,13 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package rg
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const ResgroupCreateAPI = "/restmachine/cloudbroker/rg/create"
 const ResgroupUpdateAPI = "/restmachine/cloudbroker/rg/update"
 const ResgroupListAPI = "/restmachine/cloudbroker/rg/list"
 const ResgroupGetAPI = "/restmachine/cloudbroker/rg/get"
 const ResgroupDeleteAPI = "/restmachine/cloudbroker/rg/delete"
 const RgListComputesAPI = "/restmachine/cloudbroker/rg/listComputes"
+
+var log = logging.New("cb_rg")
@@ -40,7 +40,6 @@ import (
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/location"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -70,7 +69,7 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 	*/
 
 	// all required parameters are set in the schema - we can continue with RG creation
-	log.Debugf("resourceResgroupCreate: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupCreate: called for RG name %s, account ID %d",
 		rg_name.(string), d.Get("account_id").(int))
 
 	// quota settings are optional
@@ -78,13 +77,13 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 	var quota_record QuotaRecord
 	arg_value, arg_set := d.GetOk("quota")
 	if arg_set {
-		log.Debugf("resourceResgroupCreate: setting Quota on RG requested")
+		log.Debugf(ctx, "resourceResgroupCreate: setting Quota on RG requested")
 		quota_record = makeQuotaRecord(arg_value.([]interface{}))
 		set_quota = true
 	}
 
-	c := m.(*controller.ControllerCfg)
-	log.Debugf("resourceResgroupCreate: called by user %q for RG name %s, account ID %d",
+	c := m.(controller.APICaller)
+	log.Debugf(ctx, "resourceResgroupCreate: called by user %q for RG name %s, account ID %d",
 		c.GetDecortUsername(),
 		rg_name.(string), d.Get("account_id").(int))
 
@@ -151,7 +150,7 @@ func resourceResgroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceResgroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceResgroupRead: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupRead: called for RG name %s, account ID %d",
 		d.Get("name").(string), d.Get("account_id").(int))
 
 	rg_facts, err := utilityResgroupCheckPresence(ctx, d, m)
@@ -162,11 +161,11 @@ func resourceResgroupRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(flattenResgroup(d, rg_facts))
+	return diag.FromErr(flattenResgroup(ctx, d, rg_facts))
 }
 
 func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceResgroupUpdate: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupUpdate: called for RG name %s, account ID %d",
 		d.Get("name").(string), d.Get("account_id").(int))
 
 	/* NOTE: we do not allow changing the following attributes of an existing RG via terraform:
@@ -191,13 +190,13 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	do_general_update := false // will be true if general RG update is necessary (API rg/update)
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	url_values := &url.Values{}
 	url_values.Add("rgId", d.Id())
 
 	name_new, name_set := d.GetOk("name")
 	if name_set {
-		log.Debugf("resourceResgroupUpdate: name specified - looking for deltas from the old settings.")
+		log.Debugf(ctx, "resourceResgroupUpdate: name specified - looking for deltas from the old settings.")
 		name_old, _ := d.GetChange("name")
 		if name_old.(string) != name_new.(string) {
 			do_general_update = true
@@ -207,45 +206,45 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	quota_value, quota_set := d.GetOk("quota")
 	if quota_set {
-		log.Debugf("resourceResgroupUpdate: quota specified - looking for deltas from the old quota.")
+		log.Debugf(ctx, "resourceResgroupUpdate: quota specified - looking for deltas from the old quota.")
 		quotarecord_new := makeQuotaRecord(quota_value.([]interface{}))
 		quota_value_old, _ := d.GetChange("quota") // returns old as 1st, new as 2nd return value
 		quotarecord_old := makeQuotaRecord(quota_value_old.([]interface{}))
 
 		if quotarecord_new.Cpu != quotarecord_old.Cpu {
 			do_general_update = true
-			log.Debugf("resourceResgroupUpdate: Cpu diff %d <- %d", quotarecord_new.Cpu, quotarecord_old.Cpu)
+			log.Debugf(ctx, "resourceResgroupUpdate: Cpu diff %d <- %d", quotarecord_new.Cpu, quotarecord_old.Cpu)
 			url_values.Add("maxCPUCapacity", fmt.Sprintf("%d", quotarecord_new.Cpu))
 		}
 
 		if quotarecord_new.Disk != quotarecord_old.Disk {
 			do_general_update = true
-			log.Debugf("resourceResgroupUpdate: Disk diff %d <- %d", quotarecord_new.Disk, quotarecord_old.Disk)
+			log.Debugf(ctx, "resourceResgroupUpdate: Disk diff %d <- %d", quotarecord_new.Disk, quotarecord_old.Disk)
 			url_values.Add("maxVDiskCapacity", fmt.Sprintf("%d", quotarecord_new.Disk))
 		}
 
 		if quotarecord_new.Ram != quotarecord_old.Ram { // NB: quota on RAM is stored as float32, in units of MB
 			do_general_update = true
-			log.Debugf("resourceResgroupUpdate: Ram diff %f <- %f", quotarecord_new.Ram, quotarecord_old.Ram)
+			log.Debugf(ctx, "resourceResgroupUpdate: Ram diff %f <- %f", quotarecord_new.Ram, quotarecord_old.Ram)
 			url_values.Add("maxMemoryCapacity", fmt.Sprintf("%f", quotarecord_new.Ram))
 		}
 
 		if quotarecord_new.ExtTraffic != quotarecord_old.ExtTraffic {
 			do_general_update = true
-			log.Debugf("resourceResgroupUpdate: ExtTraffic diff %d <- %d", quotarecord_new.ExtTraffic, quotarecord_old.ExtTraffic)
+			log.Debugf(ctx, "resourceResgroupUpdate: ExtTraffic diff %d <- %d", quotarecord_new.ExtTraffic, quotarecord_old.ExtTraffic)
 			url_values.Add("maxNetworkPeerTransfer", fmt.Sprintf("%d", quotarecord_new.ExtTraffic))
 		}
 
 		if quotarecord_new.ExtIPs != quotarecord_old.ExtIPs {
 			do_general_update = true
-			log.Debugf("resourceResgroupUpdate: ExtIPs diff %d <- %d", quotarecord_new.ExtIPs, quotarecord_old.ExtIPs)
+			log.Debugf(ctx, "resourceResgroupUpdate: ExtIPs diff %d <- %d", quotarecord_new.ExtIPs, quotarecord_old.ExtIPs)
 			url_values.Add("maxNumPublicIP", fmt.Sprintf("%d", quotarecord_new.ExtIPs))
 		}
 	}
 
 	desc_new, desc_set := d.GetOk("description")
 	if desc_set {
-		log.Debugf("resourceResgroupUpdate: description specified - looking for deltas from the old settings.")
+		log.Debugf(ctx, "resourceResgroupUpdate: description specified - looking for deltas from the old settings.")
 		desc_old, _ := d.GetChange("description")
 		if desc_old.(string) != desc_new.(string) {
 			do_general_update = true
@@ -254,13 +253,13 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if do_general_update {
-		log.Debugf("resourceResgroupUpdate: detected delta between new and old RG specs - updating the RG")
+		log.Debugf(ctx, "resourceResgroupUpdate: detected delta between new and old RG specs - updating the RG")
 		_, err := c.DecortAPICall(ctx, "POST", ResgroupUpdateAPI, url_values)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
-		log.Debugf("resourceResgroupUpdate: no difference between old and new state - no update on the RG will be done")
+		log.Debugf(ctx, "resourceResgroupUpdate: no difference between old and new state - no update on the RG will be done")
 	}
 
 	return resourceResgroupRead(ctx, d, m)
@@ -269,7 +268,7 @@ func resourceResgroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 func resourceResgroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// NOTE: this method forcibly destroys target resource group with flag "permanently", so there is no way to
 	// restore the destroyed resource group as well all Computes & VINSes that existed in it
-	log.Debugf("resourceResgroupDelete: called for RG name %s, account ID %d",
+	log.Debugf(ctx, "resourceResgroupDelete: called for RG name %s, account ID %d",
 		d.Get("name").(string), d.Get("account_id").(int))
 
 	rg_facts, err := utilityResgroupCheckPresence(ctx, d, m)
@@ -288,7 +287,7 @@ func resourceResgroupDelete(ctx context.Context, d *schema.ResourceData, m inter
 	url_values.Add("permanently", "1")
 	url_values.Add("reason", "Destroyed by DECORT Terraform provider")
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	_, err = c.DecortAPICall(ctx, "POST", ResgroupDeleteAPI, url_values)
 	if err != nil {
 		return diag.FromErr(err)
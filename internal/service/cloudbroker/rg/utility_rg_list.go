@@ -38,13 +38,12 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityRgListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (ResgroupListResp, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	rgList := ResgroupListResp{}
@@ -59,7 +58,7 @@ func utilityRgListCheckPresence(ctx context.Context, d *schema.ResourceData, m i
 		urlValues.Add("includedeleted", strconv.FormatBool(includedeleted.(bool)))
 	}
 
-	log.Debugf("utilityRgListCheckPresence: load rg list")
+	log.Debugf(ctx, "utilityRgListCheckPresence: load rg list")
 	rgListRaw, err := c.DecortAPICall(ctx, "POST", ResgroupListAPI, urlValues)
 	if err != nil {
 		return nil, err
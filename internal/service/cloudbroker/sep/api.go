@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package sep
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const sepAddConsumerNodesAPI = "/restmachine/cloudbroker/sep/addConsumerNodes"
 const sepDelConsumerNodesAPI = "/restmachine/cloudbroker/sep/delConsumerNodes"
 const sepAddProviderNodesAPI = "/restmachine/cloudbroker/sep/addProviderNodes"
@@ -57,3 +59,5 @@ const sepDeleteAPI = "/restmachine/cloudbroker/sep/delete"
 const sepListAPI = "/restmachine/cloudbroker/sep/list"
 
 const sepUpdateCapacityLimitAPI = "/restmachine/cloudbroker/sep/updateCapacityLimit"
+
+var log = logging.New("cb_sep")
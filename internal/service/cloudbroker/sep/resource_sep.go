@@ -43,13 +43,12 @@ import (
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/flattens"
-	log "github.com/sirupsen/logrus"
 )
 
 func resourceSepCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceSepCreate: called for sep %s", d.Get("name").(string))
+	log.Debugf(ctx, "resourceSepCreate: called for sep %s", d.Get("name").(string))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("name", d.Get("name").(string))
@@ -109,7 +108,7 @@ func resourceSepCreate(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceSepRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceSepRead: called for %s id: %d", d.Get("name").(string), d.Get("sep_id").(int))
+	log.Debugf(ctx, "resourceSepRead: called for %s id: %d", d.Get("name").(string), d.Get("sep_id").(int))
 
 	sep, err := utilitySepCheckPresence(ctx, d, m)
 	if sep == nil {
@@ -137,7 +136,7 @@ func resourceSepRead(ctx context.Context, d *schema.ResourceData, m interface{})
 }
 
 func resourceSepDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceSepDelete: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
+	log.Debugf(ctx, "resourceSepDelete: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
 
 	sepDes, err := utilitySepCheckPresence(ctx, d, m)
 	if sepDes == nil {
@@ -147,7 +146,7 @@ func resourceSepDelete(ctx context.Context, d *schema.ResourceData, m interface{
 		return nil
 	}
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("sep_id", strconv.Itoa(d.Get("sep_id").(int)))
 
@@ -161,8 +160,8 @@ func resourceSepDelete(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceSepEdit(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceSepEdit: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceSepEdit: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
+	c := m.(controller.APICaller)
 
 	urlValues := &url.Values{}
 	if d.HasChange("decommission") {
@@ -254,7 +253,7 @@ func resourceSepEdit(ctx context.Context, d *schema.ResourceData, m interface{})
 func resourceSepChangeEnabled(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	var api string
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("sep_id", strconv.Itoa(d.Get("sep_id").(int)))
 	if d.Get("enable").(bool) {
@@ -277,8 +276,8 @@ func resourceSepChangeEnabled(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceSepUpdateNodes(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceSepUpdateNodes: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceSepUpdateNodes: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	t1, t2 := d.GetChange("consumed_by")
@@ -329,8 +328,8 @@ func findElInt(sl []interface{}, el interface{}) bool {
 }
 
 func resourceSepUpdateProviders(ctx context.Context, d *schema.ResourceData, m interface{}) error {
-	log.Debugf("resourceSepUpdateProviders: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
-	c := m.(*controller.ControllerCfg)
+	log.Debugf(ctx, "resourceSepUpdateProviders: called for %s, id: %d", d.Get("name").(string), d.Get("sep_id").(int))
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 	urlValues.Add("sep_id", strconv.Itoa(d.Get("sep_id").(int)))
 	providerIds := d.Get("provided_by").([]interface{})
@@ -38,13 +38,12 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilitySepCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (*Sep, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	sep := &Sep{}
@@ -55,7 +54,7 @@ func utilitySepCheckPresence(ctx context.Context, d *schema.ResourceData, m inte
 		urlValues.Add("sep_id", strconv.Itoa(d.Get("sep_id").(int)))
 	}
 
-	log.Debugf("utilitySepCheckPresence: load sep")
+	log.Debugf(ctx, "utilitySepCheckPresence: load sep")
 	sepRaw, err := c.DecortAPICall(ctx, "POST", sepGetAPI, urlValues)
 	if err != nil {
 		return nil, err
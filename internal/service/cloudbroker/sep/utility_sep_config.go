@@ -38,20 +38,19 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilitySepConfigCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (SepConfig, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	sepConfig := SepConfig{}
 
 	urlValues.Add("sep_id", strconv.Itoa(d.Get("sep_id").(int)))
 
-	log.Debugf("utilitySepConfigCheckPresence: load sep config")
+	log.Debugf(ctx, "utilitySepConfigCheckPresence: load sep config")
 	sepConfigRaw, err := c.DecortAPICall(ctx, "POST", sepGetConfigAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -38,13 +38,12 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilitySepDiskListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) ([]int, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	sepDiskList := SepDiskList{}
@@ -55,7 +54,7 @@ func utilitySepDiskListCheckPresence(ctx context.Context, d *schema.ResourceData
 		urlValues.Add("pool_name", poolName.(string))
 	}
 
-	log.Debugf("utilitySepDiskListCheckPresence: load sep")
+	log.Debugf(ctx, "utilitySepDiskListCheckPresence: load sep")
 	sepDiskListRaw, err := c.DecortAPICall(ctx, "POST", sepDiskListAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -38,13 +38,12 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilitySepPoolCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (SepPool, error) {
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	sepPool := SepPool{}
@@ -52,7 +51,7 @@ func utilitySepPoolCheckPresence(ctx context.Context, d *schema.ResourceData, m
 	urlValues.Add("sep_id", strconv.Itoa(d.Get("sep_id").(int)))
 	urlValues.Add("pool_name", d.Get("pool_name").(string))
 
-	log.Debugf("utilitySepDesPoolCheckPresence: load sep")
+	log.Debugf(ctx, "utilitySepDesPoolCheckPresence: load sep")
 	sepPoolRaw, err := c.DecortAPICall(ctx, "POST", sepGetPoolAPI, urlValues)
 	if err != nil {
 		return nil, err
@@ -31,7 +31,11 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package snapshot
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const snapshotCreateAPI = "/restmachine/cloudbroker/compute/snapshotCreate"
 const snapshotDeleteAPI = "/restmachine/cloudbroker/compute/snapshotDelete"
 const snapshotRollbackAPI = "/restmachine/cloudbroker/compute/snapshotRollback"
 const snapshotListAPI = "/restmachine/cloudbroker/compute/snapshotList"
+
+var log = logging.New("cb_snapshot")
@@ -31,6 +31,8 @@ Documentation: https://repository.basistech.ru/BASIS/terraform-provider-decort/w
 
 package vins
 
+import "repository.basistech.ru/BASIS/terraform-provider-decort/internal/logging"
+
 const VinsListAPI = "/restmachine/cloudbroker/vins/list"
 const VinsGetAPI = "/restmachine/cloudbroker/vins/get"
 const VinsSearchAPI = "/restmachine/cloudbroker/vins/search"
@@ -42,3 +44,5 @@ const VinsExtNetConnectAPI = "/restmachine/cloudbroker/vins/extNetConnect"
 const VinsExtNetDisconnectAPI = "/restmachine/cloudbroker/vins/extNetDisconnect"
 
 const VinsDeleteAPI = "/restmachine/cloudbroker/vins/delete"
+
+var log = logging.New("cb_vins")
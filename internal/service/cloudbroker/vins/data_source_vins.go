@@ -37,7 +37,6 @@ import (
 	"fmt"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
-	log "github.com/sirupsen/logrus"
 
 	// "net/url"
 
@@ -47,7 +46,7 @@ import (
 )
 
 // vins_facts is a response string from API vins/get
-func flattenVins(d *schema.ResourceData, vins_facts string) diag.Diagnostics {
+func flattenVins(ctx context.Context, d *schema.ResourceData, vins_facts string) diag.Diagnostics {
 	// NOTE: this function modifies ResourceData argument - as such it should never be called
 	// from resourceVinsExists(...) method
 	// log.Debugf("flattenVins: ready to decode response body from API %s", vins_facts)
@@ -57,7 +56,7 @@ func flattenVins(d *schema.ResourceData, vins_facts string) diag.Diagnostics {
 		return diag.FromErr(err)
 	}
 
-	log.Debugf("flattenVins: decoded ViNS name:ID %s:%d, account ID %d, RG ID %d",
+	log.Debugf(ctx, "flattenVins: decoded ViNS name:ID %s:%d, account ID %d, RG ID %d",
 		vinsRecord.Name, vinsRecord.ID, vinsRecord.AccountID, vinsRecord.RgID)
 
 	d.SetId(fmt.Sprintf("%d", vinsRecord.ID))
@@ -71,11 +70,11 @@ func flattenVins(d *schema.ResourceData, vins_facts string) diag.Diagnostics {
 	noExtNetConnection := true
 	for _, value := range vinsRecord.VNFs {
 		if value.Type == "GW" {
-			log.Debugf("flattenVins: discovered GW VNF ID %d in ViNS ID %d", value.ID, vinsRecord.ID)
+			log.Debugf(ctx, "flattenVins: discovered GW VNF ID %d in ViNS ID %d", value.ID, vinsRecord.ID)
 			extNetID, idOk := value.Config["ext_net_id"] // NOTE: unknown numbers are unmarshalled to float64. This is by design!
 			extNetIP, ipOk := value.Config["ext_net_ip"]
 			if idOk && ipOk {
-				log.Debugf("flattenVins: ViNS ext_net_id=%d, ext_net_ip=%s", int(extNetID.(float64)), extNetIP.(string))
+				log.Debugf(ctx, "flattenVins: ViNS ext_net_id=%d, ext_net_ip=%s", int(extNetID.(float64)), extNetIP.(string))
 				d.Set("ext_ip_addr", extNetIP.(string))
 				d.Set("ext_net_id", int(extNetID.(float64)))
 			} else {
@@ -91,7 +90,7 @@ func flattenVins(d *schema.ResourceData, vins_facts string) diag.Diagnostics {
 		d.Set("ext_net_id", 0)
 	}
 
-	log.Debugf("flattenVins: EXTRA CHECK - schema rg_id=%d, ext_net_id=%d", d.Get("rg_id").(int), d.Get("ext_net_id").(int))
+	log.Debugf(ctx, "flattenVins: EXTRA CHECK - schema rg_id=%d, ext_net_id=%d", d.Get("rg_id").(int), d.Get("ext_net_id").(int))
 
 	return nil
 }
@@ -105,7 +104,7 @@ func dataSourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	return flattenVins(d, vinsFacts)
+	return flattenVins(ctx, d, vinsFacts)
 }
 
 func DataSourceVins() *schema.Resource {
@@ -39,7 +39,6 @@ import (
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/constants"
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,20 +50,18 @@ func ipcidrDiffSupperss(key, oldVal, newVal string, d *schema.ResourceData) bool
 		// if old value for "ipcidr" resource is empty string, it means that we are creating new ViNS
 		// and there is a chance that the user will want specific IP address range for this ViNS -
 		// check if "ipcidr" is explicitly set in TF file to a non-empty string.
-		log.Debugf("ipcidrDiffSupperss: key=%s, oldVal=%q, newVal=%q -> suppress=FALSE", key, oldVal, newVal)
 		return false // there is a difference between stored and new value
 	}
-	log.Debugf("ipcidrDiffSupperss: key=%s, oldVal=%q, newVal=%q -> suppress=TRUE", key, oldVal, newVal)
 	return true // suppress difference
 }
 
 func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceVinsCreate: called for ViNS name %s, Account ID %d, RG ID %d",
+	log.Debugf(ctx, "resourceVinsCreate: called for ViNS name %s, Account ID %d, RG ID %d",
 		d.Get("name").(string), d.Get("account_id").(int), d.Get("rg_id").(int))
 
 	apiToCall := VinsCreateInAccountAPI
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	urlValues.Add("name", d.Get("name").(string))
@@ -106,7 +103,7 @@ func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 	argVal, argSet = d.GetOk("ipcidr")
 	if argSet && argVal.(string) != "" {
-		log.Debugf("resourceVinsCreate: ipcidr is set to %s", argVal.(string))
+		log.Debugf(ctx, "resourceVinsCreate: ipcidr is set to %s", argVal.(string))
 		urlValues.Add("ipcidr", argVal.(string))
 	}
 
@@ -123,7 +120,7 @@ func resourceVinsCreate(ctx context.Context, d *schema.ResourceData, m interface
 	d.SetId(apiResp) // update ID of the resource to tell Terraform that the ViNS resource exists
 	vinsId, _ := strconv.Atoi(apiResp)
 
-	log.Debugf("resourceVinsCreate: new ViNS ID / name %d / %s creation sequence complete", vinsId, d.Get("name").(string))
+	log.Debugf(ctx, "resourceVinsCreate: new ViNS ID / name %d / %s creation sequence complete", vinsId, d.Get("name").(string))
 
 	// We may reuse dataSourceVinsRead here as we maintain similarity
 	// between ViNS resource and ViNS data source schemas
@@ -141,20 +138,20 @@ func resourceVinsRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.FromErr(err)
 	}
 
-	return flattenVins(d, vinsFacts)
+	return flattenVins(ctx, d, vinsFacts)
 }
 
 func resourceVinsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	log.Debugf("resourceVinsUpdate: called for ViNS ID / name %s / %s,  Account ID %d, RG ID %d",
+	log.Debugf(ctx, "resourceVinsUpdate: called for ViNS ID / name %s / %s,  Account ID %d, RG ID %d",
 		d.Id(), d.Get("name").(string), d.Get("account_id").(int), d.Get("rg_id").(int))
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 
 	// 1. Handle external network connection change
 	oldExtNetId, newExtNedId := d.GetChange("ext_net_id")
 	if oldExtNetId.(int) != newExtNedId.(int) {
-		log.Debugf("resourceVinsUpdate: changing ViNS ID %s - ext_net_id %d -> %d", d.Id(), oldExtNetId.(int), newExtNedId.(int))
+		log.Debugf(ctx, "resourceVinsUpdate: changing ViNS ID %s - ext_net_id %d -> %d", d.Id(), oldExtNetId.(int), newExtNedId.(int))
 
 		extnetParams := &url.Values{}
 		extnetParams.Add("vinsId", d.Id())
@@ -183,7 +180,7 @@ func resourceVinsUpdate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceVinsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Debugf("resourceVinsDelete: called for ViNS ID / name %s / %s, Account ID %d, RG ID %d",
+	log.Debugf(ctx, "resourceVinsDelete: called for ViNS ID / name %s / %s, Account ID %d, RG ID %d",
 		d.Id(), d.Get("name").(string), d.Get("account_id").(int), d.Get("rg_id").(int))
 
 	vinsFacts, err := utilityVinsCheckPresence(ctx, d, m)
@@ -201,7 +198,7 @@ func resourceVinsDelete(ctx context.Context, d *schema.ResourceData, m interface
 	params.Add("force", "1")       // disconnect all computes before deleting ViNS
 	params.Add("permanently", "1") // delete ViNS immediately bypassing recycle bin
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	_, err = c.DecortAPICall(ctx, "POST", VinsDeleteAPI, params)
 	if err != nil {
 		return diag.FromErr(err)
@@ -39,7 +39,6 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -60,7 +59,7 @@ func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m int
 	// method for the Terraform resource Exists method.
 	//
 
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	// make it possible to use "read" & "check presence" functions with ViNS ID set so
@@ -79,7 +78,7 @@ func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m int
 
 	if idSet {
 		// ViNS ID is specified, try to get compute instance straight by this ID
-		log.Debugf("utilityVinsCheckPresence: locating ViNS by its ID %d", theId)
+		log.Debugf(ctx, "utilityVinsCheckPresence: locating ViNS by its ID %d", theId)
 		urlValues.Add("vinsId", fmt.Sprintf("%d", theId))
 		vinsFacts, err := c.DecortAPICall(ctx, "POST", VinsGetAPI, urlValues)
 		if err != nil {
@@ -98,17 +97,17 @@ func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m int
 	}
 	urlValues.Add("name", vinsName.(string))
 	urlValues.Add("show_all", "false")
-	log.Debugf("utilityVinsCheckPresence: preparing to locate ViNS name %s", vinsName.(string))
+	log.Debugf(ctx, "utilityVinsCheckPresence: preparing to locate ViNS name %s", vinsName.(string))
 
 	rgId, rgSet := d.GetOk("rg_id")
 	if rgSet {
-		log.Debugf("utilityVinsCheckPresence: limiting ViNS search to RG ID %d", rgId.(int))
+		log.Debugf(ctx, "utilityVinsCheckPresence: limiting ViNS search to RG ID %d", rgId.(int))
 		urlValues.Add("rgId", fmt.Sprintf("%d", rgId.(int)))
 	}
 
 	accountId, accountSet := d.GetOk("account_id")
 	if accountSet {
-		log.Debugf("utilityVinsCheckPresence: limiting ViNS search to Account ID %d", accountId.(int))
+		log.Debugf(ctx, "utilityVinsCheckPresence: limiting ViNS search to Account ID %d", accountId.(int))
 		urlValues.Add("accountId", fmt.Sprintf("%d", accountId.(int)))
 	}
 
@@ -125,7 +124,7 @@ func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m int
 		return "", err
 	}
 
-	log.Debugf("utilityVinsCheckPresence: traversing decoded Json of length %d", len(model))
+	log.Debugf(ctx, "utilityVinsCheckPresence: traversing decoded Json of length %d", len(model))
 	for index, item := range model {
 		if item.Name == vinsName.(string) {
 			if (accountSet && item.AccountID != accountId.(int)) ||
@@ -134,7 +133,7 @@ func utilityVinsCheckPresence(ctx context.Context, d *schema.ResourceData, m int
 				continue
 			}
 
-			log.Debugf("utilityVinsCheckPresence: match ViNS name %s / ID %d, account ID %d, RG ID %d at index %d",
+			log.Debugf(ctx, "utilityVinsCheckPresence: match ViNS name %s / ID %d, account ID %d, RG ID %d at index %d",
 				item.Name, item.ID, item.AccountID, item.RgID, index)
 
 			// element returned by API vins/search does not contain all information we may need to
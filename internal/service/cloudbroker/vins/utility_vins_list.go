@@ -38,14 +38,13 @@ import (
 	"strconv"
 
 	"repository.basistech.ru/BASIS/terraform-provider-decort/internal/controller"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func utilityVinsListCheckPresence(ctx context.Context, d *schema.ResourceData, m interface{}) (VinsList, error) {
 	vinsList := VinsList{}
-	c := m.(*controller.ControllerCfg)
+	c := m.(controller.APICaller)
 	urlValues := &url.Values{}
 
 	if includeDeleted, ok := d.GetOk("include_deleted"); ok {
@@ -58,7 +57,7 @@ func utilityVinsListCheckPresence(ctx context.Context, d *schema.ResourceData, m
 		urlValues.Add("size", strconv.Itoa(size.(int)))
 	}
 
-	log.Debugf("utilityVinsListCheckPresence")
+	log.Debugf(ctx, "utilityVinsListCheckPresence")
 	vinsListRaw, err := c.DecortAPICall(ctx, "POST", VinsListAPI, urlValues)
 	if err != nil {
 		return nil, err
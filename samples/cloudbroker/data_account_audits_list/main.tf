@@ -27,7 +27,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_audits_list" "aal" {
+data "decort_cb_account_audits_list" "aal" {
   #id аккаунта
   #обязательный параметр
   #тип - число
@@ -36,5 +36,5 @@ data "decort_account_audits_list" "aal" {
 }
 
 output "test" {
-  value = data.decort_account_audits_list.aal
+  value = data.decort_cb_account_audits_list.aal
 }
@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_computes_list" "acl" {
+data "decort_cb_account_computes_list" "acl" {
   #id аккаунта
   #обязательный параметр
   #тип - число
@@ -35,5 +35,5 @@ data "decort_account_computes_list" "acl" {
 }
 
 output "test" {
-  value = data.decort_account_computes_list.acl
+  value = data.decort_cb_account_computes_list.acl
 }
@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_deleted_list" "adl" {
+data "decort_cb_account_deleted_list" "adl" {
   #номер страницы для отображения
   #опциональный параметр
   #тип - число
@@ -41,5 +41,5 @@ data "decort_account_deleted_list" "adl" {
 }
 
 output "test" {
-  value = data.decort_account_deleted_list.adl
+  value = data.decort_cb_account_deleted_list.adl
 }
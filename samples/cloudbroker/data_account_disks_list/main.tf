@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_disks_list" "adl" {
+data "decort_cb_account_disks_list" "adl" {
   #id аккаунта
   #обязательный параметр
   #тип - число
@@ -35,5 +35,5 @@ data "decort_account_disks_list" "adl" {
 }
 
 output "test" {
-  value = data.decort_account_disks_list.adl
+  value = data.decort_cb_account_disks_list.adl
 }
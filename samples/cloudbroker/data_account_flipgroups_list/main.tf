@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_flipgroups_list" "afgl" {
+data "decort_cb_account_flipgroups_list" "afgl" {
   #id аккаунта
   #обязательный параметр
   #тип - число
@@ -34,5 +34,5 @@ data "decort_account_flipgroups_list" "afgl" {
 }
 
 output "test" {
-  value = data.decort_account_flipgroups_list.afgl
+  value = data.decort_cb_account_flipgroups_list.afgl
 }
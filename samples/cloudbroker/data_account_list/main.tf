@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_list" "al" {
+data "decort_cb_account_list" "al" {
   #номер страницы для отображения
   #опциональный параметр
   #тип - число
@@ -40,5 +40,5 @@ data "decort_account_list" "al" {
 }
 
 output "test" {
-  value = data.decort_account_list.al
+  value = data.decort_cb_account_list.al
 }
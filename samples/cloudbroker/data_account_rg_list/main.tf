@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_rg_list" "argl" {
+data "decort_cb_account_rg_list" "argl" {
   #id аккаунта
   #обязательный параметр
   #тип - число
@@ -33,5 +33,5 @@ data "decort_account_rg_list" "argl" {
 }
 
 output "test" {
-  value = data.decort_account_rg_list.argl
+  value = data.decort_cb_account_rg_list.argl
 }
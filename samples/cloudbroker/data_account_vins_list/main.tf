@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_account_vins_list" "avl" {
+data "decort_cb_account_vins_list" "avl" {
   #id аккаунта
   #обязательный параметр
   #тип - число
@@ -35,5 +35,5 @@ data "decort_account_vins_list" "avl" {
 }
 
 output "test" {
-  value = data.decort_account_vins_list.avl
+  value = data.decort_cb_account_vins_list.avl
 }
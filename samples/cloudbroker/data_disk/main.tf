@@ -25,11 +25,11 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_disk" "acl" {
+data "decort_cb_disk" "acl" {
   disk_id = 49304
 
 }
 
 output "test" {
-  value = data.decort_disk.acl
+  value = data.decort_cb_disk.acl
 }
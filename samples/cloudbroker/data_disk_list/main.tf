@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_disk_list" "dl" {
+data "decort_cb_disk_list" "dl" {
   #id аккаунта для получения списка дисков
   #опциональный параметр 
   #тип - число
@@ -50,5 +50,5 @@ data "decort_disk_list" "dl" {
 }
 
 output "test" {
-  value = data.decort_disk_list.dl
+  value = data.decort_cb_disk_list.dl
 }
@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_grid" "image" {
+data "decort_cb_grid" "image" {
   #id grid для получения информации
   #обязательный параметр 
   #тип - число
@@ -34,5 +34,5 @@ data "decort_grid" "image" {
 }
 
 output "test" {
-  value = data.decort_grid.image
+  value = data.decort_cb_grid.image
 }
@@ -28,7 +28,7 @@ provider "decort" {
 }
 
 
-data "decort_grid_list" "gl" {
+data "decort_cb_grid_list" "gl" {
   #номер страницы для отображения
   #опциональный параметр, тип - число
   #если не задан - выводятся все доступные данные
@@ -41,5 +41,5 @@ data "decort_grid_list" "gl" {
 }
 
 output "test" {
-  value = data.decort_grid_list.gl
+  value = data.decort_cb_grid_list.gl
 }
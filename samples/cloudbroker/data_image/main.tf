@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_image" "image" {
+data "decort_cb_image" "image" {
   #id образа
   #обязательный параметр
   #тип - число
@@ -34,5 +34,5 @@ data "decort_image" "image" {
 }
 
 output "test" {
-  value = data.decort_image.image
+  value = data.decort_cb_image.image
 }
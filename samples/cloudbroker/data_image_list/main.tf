@@ -27,7 +27,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_image_list" "il" {
+data "decort_cb_image_list" "il" {
   #номер страницы для отображения
   #опциональный параметр
   #тип - число
@@ -54,5 +54,5 @@ data "decort_image_list" "il" {
 }
 
 output "test" {
-  value = data.decort_image_list.il
+  value = data.decort_cb_image_list.il
 }
@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_image_list_stacks" "im" {
+data "decort_cb_image_list_stacks" "im" {
   #id образа
   #обязательный параметр
   #тип - число
@@ -45,5 +45,5 @@ data "decort_image_list_stacks" "im" {
 }
 
 output "test" {
-  value = data.decort_image_list_stacks.im
+  value = data.decort_cb_image_list_stacks.im
 }
@@ -27,7 +27,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_pcidevice" "pd" {
+data "decort_cb_pcidevice" "pd" {
   #id устройства
   #обязательный параметр
   #тип - число
@@ -35,5 +35,5 @@ data "decort_pcidevice" "pd" {
 }
 
 output "test" {
-  value = data.decort_pcidevice.pd
+  value = data.decort_cb_pcidevice.pd
 }
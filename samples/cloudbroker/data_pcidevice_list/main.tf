@@ -27,8 +27,8 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_pcidevice_list" "pdl" {}
+data "decort_cb_pcidevice_list" "pdl" {}
 
 output "test" {
-  value = data.decort_pcidevice_list.pdl.items
+  value = data.decort_cb_pcidevice_list.pdl.items
 }
@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_sep" "sd" {
+data "decort_cb_sep" "sd" {
   #id sep
   #обязательный параметр
   #тип - число
@@ -34,9 +34,9 @@ data "decort_sep" "sd" {
 }
 
 output "test" {
-  value = data.decort_sep.sd
+  value = data.decort_cb_sep.sd
 }
 
 output "config" {
-  value = jsondecode(data.decort_sep.sd.config)
+  value = jsondecode(data.decort_cb_sep.sd.config)
 }
@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_sep_config" "sc" {
+data "decort_cb_sep_config" "sc" {
   #id sep
   #обязательный параметр
   #тип - число
@@ -33,9 +33,9 @@ data "decort_sep_config" "sc" {
 }
 
 output "test" {
-  value = data.decort_sep_config.sc
+  value = data.decort_cb_sep_config.sc
 }
 
 output "config" {
-  value = jsondecode(data.decort_config.sc.config)
+  value = jsondecode(data.decort_cb_sep_config.sc.config)
 }
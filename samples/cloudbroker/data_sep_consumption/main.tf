@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_sep_consumption" "scons" {
+data "decort_cb_sep_consumption" "scons" {
   #id sep
   #обязательный параметр
   #тип - число
@@ -33,5 +33,5 @@ data "decort_sep_consumption" "scons" {
 }
 
 output "test" {
-  value = data.decort_sep_consumption.scons
+  value = data.decort_cb_sep_consumption.scons
 }
@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_sep_disk_list" "sdl" {
+data "decort_cb_sep_disk_list" "sdl" {
   #id sep
   #обязательный параметр
   #тип - число
@@ -38,5 +38,5 @@ data "decort_sep_disk_list" "sdl" {
 }
 
 output "test" {
-  value = data.decort_sep_disk_list.sdl
+  value = data.decort_cb_sep_disk_list.sdl
 }
@@ -24,7 +24,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_sep_list" "sl" {
+data "decort_cb_sep_list" "sl" {
   #страница
   #необязательный параметр
   #тип - число
@@ -36,5 +36,5 @@ data "decort_sep_list" "sl" {
 }
 
 output "test" {
-  value = data.decort_sep_list.sl
+  value = data.decort_cb_sep_list.sl
 }
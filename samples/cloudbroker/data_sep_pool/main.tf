@@ -26,7 +26,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-data "decort_sep_pool" "sp" {
+data "decort_cb_sep_pool" "sp" {
   #id sep
   #обязательный параметр
   #тип - число
@@ -38,9 +38,9 @@ data "decort_sep_pool" "sp" {
 }
 
 output "test" {
-  value = data.decort_sep_pool.sp
+  value = data.decort_cb_sep_pool.sp
 }
 
 output "pool" {
-  value = jsondecode(data.decort_sep_pool.sp.pool)
+  value = jsondecode(data.decort_cb_sep_pool.sp.pool)
 }
@@ -28,7 +28,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_delete_images" "my_images" {
+resource "decort_cb_delete_images" "my_images" {
   #массив, содержащий набор id образов для удаления
   #обязательный параметр 
   #тип - массив чисел
@@ -47,7 +47,7 @@ resource "decort_delete_images" "my_images" {
 }
 
 output "test" {
-  value = decort_delete_images.my_images
+  value = decort_cb_delete_images.my_images
 }
 
 /*
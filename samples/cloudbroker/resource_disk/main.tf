@@ -25,7 +25,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_disk" "acl" {
+resource "decort_cb_disk" "acl" {
   account_id  = 88366
   gid         = 212
   disk_name   = "super-disk-re"
@@ -52,5 +52,5 @@ resource "decort_disk" "acl" {
 }
 
 output "test" {
-  value = decort_disk.acl
+  value = decort_cb_disk.acl
 }
@@ -31,7 +31,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_image" "my_image" {
+resource "decort_cb_image" "my_image" {
   #имя образа
   #обязательный параметр
   #тип - строка
@@ -141,5 +141,5 @@ resource "decort_image" "my_image" {
 }
 
 output "test" {
-  value = decort_image.my_image
+  value = decort_cb_image.my_image
 }
@@ -30,7 +30,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_cdrom_image" "my_image" {
+resource "decort_cb_cdrom_image" "my_image" {
   #имя образа
   #обязательный параметр
   #тип - строка
@@ -123,5 +123,5 @@ resource "decort_cdrom_image" "my_image" {
 }
 
 output "test" {
-  value = decort_cdrom_image.my_image
+  value = decort_cb_cdrom_image.my_image
 }
@@ -32,7 +32,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_pcidevice" "pd" {
+resource "decort_cb_pcidevice" "pd" {
   #имя устройства
   #обязательный параметр
   #тип - строка
@@ -81,5 +81,5 @@ resource "decort_pcidevice" "pd" {
 }
 
 output "test" {
-  value = decort_pcidevice.pd
+  value = decort_cb_pcidevice.pd
 }
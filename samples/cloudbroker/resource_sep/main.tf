@@ -30,7 +30,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_sep" "s" {
+resource "decort_cb_sep" "s" {
   #grid id
   #обязательный параметр
   #тип - число
@@ -115,10 +115,10 @@ resource "decort_sep" "s" {
 }
 
 output "test" {
-  value = decort_sep.s
+  value = decort_cb_sep.s
 }
 
 output "config" {
-  value = jsondecode(decort_sep.s.config)
+  value = jsondecode(decort_cb_sep.s.config)
 
 }
@@ -29,7 +29,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_sep_config" "sc" {
+resource "decort_cb_sep_config" "sc" {
   #id sep
   #обязательный параметр
   #тип - число
@@ -65,9 +65,9 @@ resource "decort_sep_config" "sc" {
 }
 
 output "sep_config" {
-  value = decort_sep_config.sc
+  value = decort_cb_sep_config.sc
 }
 
 output "sep_config_json" {
-  value = jsondecode(decort_sep_config.sc.config)
+  value = jsondecode(decort_cb_sep_config.sc.config)
 }
@@ -30,7 +30,7 @@ provider "decort" {
   allow_unverified_ssl = true
 }
 
-resource "decort_virtual_image" "my_image" {
+resource "decort_cb_virtual_image" "my_image" {
   #имя виртуального образа
   #обязательный параметр
   #тип - строка
@@ -94,5 +94,5 @@ resource "decort_virtual_image" "my_image" {
 }
 
 output "test" {
-  value = decort_virtual_image.my_image
+  value = decort_cb_virtual_image.my_image
 }